@@ -0,0 +1,170 @@
+package db
+
+import (
+	"database/sql"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+
+	"git.neds.sh/matty/entain/accounts/proto/accounts"
+	"git.neds.sh/matty/entain/common/errs"
+)
+
+func (r *ledgerRepo) Reserve(customerID string, amount int64, reference string, guards ...Guard) (*accounts.Hold, error) {
+	var hold *accounts.Hold
+
+	err := r.inTx(func(tx *sql.Tx) error {
+		var err error
+		hold, err = reserve(tx, customerID, amount, reference, guards)
+
+		return err
+	})
+
+	return hold, wrapError(err)
+}
+
+// reserve holds funds in a database transaction, as LedgerRepo.Reserve does.
+func reserve(tx *sql.Tx, customerID string, amount int64, reference string, guards []Guard) (*accounts.Hold, error) {
+	held, err := getHold(tx, holdsGetByRef, customerID, reference)
+	if err != nil {
+		return nil, err
+	}
+
+	if held != nil {
+		if held.Amount != amount {
+			return nil, errs.Invalid("reference", "was already used for hold %d, which differs from this one", held.Id)
+		}
+
+		return held, nil
+	}
+
+	// The reservation shares the hold's reference, which is as unique among reservations as among holds.
+	reservation, err := post(tx, customerID, accounts.Transaction_RESERVATION, amount, reference, guards)
+	if err != nil {
+		return nil, err
+	}
+
+	hold := &accounts.Hold{
+		CustomerId: customerID,
+		Amount:     amount,
+		Reference:  reference,
+		Status:     accounts.Hold_RESERVED,
+		CreateTime: reservation.CreateTime,
+	}
+
+	createTime, err := ptypes.Timestamp(reservation.CreateTime)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := tx.Exec(getLedgerQueries()[holdsInsert], customerID, amount, reference, hold.Status.String(),
+		createTime.Format(time.RFC3339Nano))
+	if err != nil {
+		return nil, err
+	}
+
+	if hold.Id, err = result.LastInsertId(); err != nil {
+		return nil, err
+	}
+
+	return hold, nil
+}
+
+func (r *ledgerRepo) Commit(holdID int64) (*accounts.Hold, error) {
+	hold, err := r.finish(holdID, accounts.Hold_COMMITTED, accounts.Transaction_STAKE)
+
+	return hold, wrapError(err)
+}
+
+func (r *ledgerRepo) Release(holdID int64) (*accounts.Hold, error) {
+	hold, err := r.finish(holdID, accounts.Hold_RELEASED, accounts.Transaction_RELEASE)
+
+	return hold, wrapError(err)
+}
+
+// finish moves a reserved hold to the given status, posting the transaction that moves its funds. A hold
+// already in the status is returned as it is.
+func (r *ledgerRepo) finish(holdID int64, status accounts.Hold_Status, transactionType accounts.Transaction_Type) (*accounts.Hold, error) {
+	var hold *accounts.Hold
+
+	err := r.inTx(func(tx *sql.Tx) error {
+		var err error
+		if hold, err = getHold(tx, holdsGet, holdID); err != nil {
+			return err
+		}
+
+		switch {
+		case hold == nil:
+			return &errs.NotFound{Resource: "hold", ID: strconv.FormatInt(holdID, 10)}
+		case hold.Status == status:
+			return nil
+		case hold.Status != accounts.Hold_RESERVED:
+			return &errs.FailedPrecondition{
+				Resource: "hold",
+				ID:       strconv.FormatInt(holdID, 10),
+				Reason:   "the hold has already been " + strings.ToLower(hold.Status.String()),
+			}
+		}
+
+		transaction, err := post(tx, hold.CustomerId, transactionType, hold.Amount, hold.Reference, nil)
+		if err != nil {
+			return err
+		}
+
+		updateTime, err := ptypes.Timestamp(transaction.CreateTime)
+		if err != nil {
+			return err
+		}
+
+		if _, err := tx.Exec(getLedgerQueries()[holdsUpdate], status.String(), updateTime.Format(time.RFC3339Nano), holdID); err != nil {
+			return err
+		}
+
+		hold.Status = status
+		hold.UpdateTime = transaction.CreateTime
+
+		return nil
+	})
+
+	return hold, err
+}
+
+// getHold runs one of the hold queries, returning the hold it selects, or nil if there is none.
+func getHold(tx *sql.Tx, name string, args ...interface{}) (*accounts.Hold, error) {
+	rows, err := tx.Query(getLedgerQueries()[name], args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		return nil, rows.Err()
+	}
+
+	var (
+		hold       accounts.Hold
+		status     string
+		createTime time.Time
+		updateTime sql.NullTime
+	)
+
+	if err := rows.Scan(&hold.Id, &hold.CustomerId, &hold.Amount, &hold.Reference, &status, &createTime, &updateTime); err != nil {
+		return nil, err
+	}
+
+	hold.Status = accounts.Hold_Status(accounts.Hold_Status_value[status])
+
+	if hold.CreateTime, err = ptypes.TimestampProto(createTime); err != nil {
+		return nil, err
+	}
+
+	if updateTime.Valid {
+		if hold.UpdateTime, err = ptypes.TimestampProto(updateTime.Time); err != nil {
+			return nil, err
+		}
+	}
+
+	return &hold, nil
+}
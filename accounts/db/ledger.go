@@ -0,0 +1,374 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	_ "github.com/mattn/go-sqlite3"
+
+	"git.neds.sh/matty/entain/accounts/ledger"
+	"git.neds.sh/matty/entain/accounts/proto/accounts"
+	"git.neds.sh/matty/entain/common/errs"
+)
+
+// LedgerRepo provides repository access to the ledger of customer accounts.
+type LedgerRepo interface {
+	// Init will initialise our ledger repository, creating its tables.
+	Init() error
+
+	// Balance will return the balance of a customer.
+	Balance(customerID string) (*accounts.Balance, error)
+
+	// ListTransactions will return up to limit of a customer's transactions with their entries, newest
+	// first, from before the transaction with the given ID, or from the newest if that is zero.
+	ListTransactions(customerID string, beforeID int64, limit int) ([]*accounts.Transaction, error)
+
+	// Totals will return the totals of a customer's transactions since the given time.
+	Totals(customerID string, since time.Time) (Totals, error)
+
+	// Post will record a transaction of the given type moving an amount, which must be positive, for a
+	// customer, and return it. A transaction of the type already posted with the reference is returned
+	// instead, unchanged, or an *errs.InvalidArgument error if it was for a different amount. It returns an
+	// *errs.FailedPrecondition error if the customer cannot afford it, and the error of any guard that
+	// refuses it.
+	Post(customerID string, transactionType accounts.Transaction_Type, amount int64, reference string, guards ...Guard) (*accounts.Transaction, error)
+
+	// Reserve will hold an amount of a customer's available funds, and return the hold. A hold already
+	// reserved with the reference is returned instead, unchanged, or an *errs.InvalidArgument error if it
+	// is for a different amount. It returns an *errs.FailedPrecondition error if the customer cannot
+	// afford it, and the error of any guard that refuses it.
+	Reserve(customerID string, amount int64, reference string, guards ...Guard) (*accounts.Hold, error)
+
+	// Commit will pay the funds of a hold to the house, and return the hold. A committed hold is returned
+	// unchanged, and a released one is an *errs.FailedPrecondition error.
+	Commit(holdID int64) (*accounts.Hold, error)
+
+	// Release will return the funds of a hold to the customer, and return the hold. A released hold is
+	// returned unchanged, and a committed one is an *errs.FailedPrecondition error.
+	Release(holdID int64) (*accounts.Hold, error)
+}
+
+// Totals are the amounts a customer's transactions moved over a window of time, in cents.
+type Totals struct {
+	// Deposited is the amount deposited.
+	Deposited int64
+	// Lost is the amount staked, counting stakes held for bets being placed, less the amount returned.
+	Lost int64
+}
+
+// A Guard checks a new transaction against the totals of the customer's transactions since a time, which
+// are read in the database transaction posting it, so that transactions posted at the same time cannot
+// between them go past what it allows.
+type Guard struct {
+	// Since is the start of the window the totals are over.
+	Since time.Time
+	// Check returns an error if the transaction is not allowed, given the totals before it.
+	Check func(totals Totals) error
+}
+
+// Open opens the accounts database at the given path. Its transactions take the database's write lock
+// as they begin, rather than on their first write, so that a transaction checking a customer can afford
+// a change has the balance it checked until it commits. Transactions are thereby serialised, and waiting
+// for the lock is bounded by the busy timeout.
+func Open(path string) (*sql.DB, error) {
+	return sql.Open("sqlite3", fmt.Sprintf("file:%s?_txlock=immediate&_busy_timeout=5000", path))
+}
+
+type ledgerRepo struct {
+	db   *sql.DB
+	init sync.Once
+}
+
+// NewLedgerRepo creates a new ledger repository, on a database opened by Open.
+func NewLedgerRepo(db *sql.DB) LedgerRepo {
+	return &ledgerRepo{db: db}
+}
+
+// Init prepares the ledger repository, migrating its database.
+func (r *ledgerRepo) Init() error {
+	var err error
+
+	r.init.Do(func() {
+		err = migrate(r.db, ledgerMigrations)
+	})
+
+	return err
+}
+
+// queryer is what the repository queries, the database or one of its transactions.
+type queryer interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+	Query(query string, args ...interface{}) (*sql.Rows, error)
+	QueryRow(query string, args ...interface{}) *sql.Row
+}
+
+func (r *ledgerRepo) Balance(customerID string) (*accounts.Balance, error) {
+	balance := &accounts.Balance{CustomerId: customerID}
+
+	err := r.db.QueryRow(getLedgerQueries()[accountsCustomer], ledger.Available(customerID), ledger.Held(customerID)).
+		Scan(&balance.Available, &balance.Held)
+	if err != nil {
+		return nil, wrapError(err)
+	}
+
+	return balance, nil
+}
+
+func (r *ledgerRepo) ListTransactions(customerID string, beforeID int64, limit int) ([]*accounts.Transaction, error) {
+	transactions, err := r.listTransactions(customerID, beforeID, limit)
+
+	return transactions, wrapError(err)
+}
+
+func (r *ledgerRepo) listTransactions(customerID string, beforeID int64, limit int) ([]*accounts.Transaction, error) {
+	rows, err := r.db.Query(getLedgerQueries()[transactionsList], customerID, beforeID, beforeID, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	transactions, err := scanTransactions(rows)
+	if err != nil || len(transactions) == 0 {
+		return transactions, err
+	}
+
+	return transactions, withEntries(r.db, transactions)
+}
+
+func (r *ledgerRepo) Totals(customerID string, since time.Time) (Totals, error) {
+	totals, err := totalsSince(r.db, customerID, since)
+
+	return totals, wrapError(err)
+}
+
+// totalsSince reads the totals of a customer's transactions since a time.
+func totalsSince(q queryer, customerID string, since time.Time) (Totals, error) {
+	var totals Totals
+
+	err := q.QueryRow(getLedgerQueries()[transactionsTotals], customerID, since.UTC().Format(time.RFC3339Nano)).
+		Scan(&totals.Deposited, &totals.Lost)
+
+	return totals, err
+}
+
+func (r *ledgerRepo) Post(customerID string, transactionType accounts.Transaction_Type, amount int64, reference string, guards ...Guard) (*accounts.Transaction, error) {
+	var transaction *accounts.Transaction
+
+	err := r.inTx(func(tx *sql.Tx) error {
+		var err error
+		transaction, err = post(tx, customerID, transactionType, amount, reference, guards)
+
+		return err
+	})
+
+	return transaction, wrapError(err)
+}
+
+// inTx runs a function in a transaction, committing it if the function succeeds.
+func (r *ledgerRepo) inTx(f func(tx *sql.Tx) error) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := f(tx); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// post records a transaction in a database transaction, as LedgerRepo.Post does.
+func post(tx *sql.Tx, customerID string, transactionType accounts.Transaction_Type, amount int64, reference string, guards []Guard) (*accounts.Transaction, error) {
+	queries := getLedgerQueries()
+
+	posted, err := getTransaction(tx, transactionsGetByRef, customerID, transactionType.String(), reference)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := ledger.Entries(transactionType, customerID, amount)
+	if err != nil {
+		return nil, err
+	}
+
+	change := ledger.Change(transactionType, customerID, entries)
+
+	if posted != nil {
+		if posted.Amount != change {
+			return nil, errs.Invalid("reference", "was already used for transaction %d, which differs from this one", posted.Id)
+		}
+
+		return posted, nil
+	}
+
+	if !ledger.MayOverdraw(transactionType) {
+		if err := checkFunds(tx, customerID, entries); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, guard := range guards {
+		totals, err := totalsSince(tx, customerID, guard.Since)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := guard.Check(totals); err != nil {
+			return nil, err
+		}
+	}
+
+	now := time.Now().UTC().Truncate(time.Millisecond)
+
+	result, err := tx.Exec(queries[transactionsInsert], customerID, transactionType.String(), change, reference, now.Format(time.RFC3339Nano))
+	if err != nil {
+		return nil, err
+	}
+
+	transaction := &accounts.Transaction{
+		CustomerId: customerID,
+		Type:       transactionType,
+		Amount:     change,
+		Reference:  reference,
+		Entries:    entries,
+	}
+
+	if transaction.Id, err = result.LastInsertId(); err != nil {
+		return nil, err
+	}
+
+	if transaction.CreateTime, err = ptypes.TimestampProto(now); err != nil {
+		return nil, err
+	}
+
+	for _, entry := range entries {
+		if _, err := tx.Exec(queries[entriesInsert], transaction.Id, entry.Account, entry.Amount); err != nil {
+			return nil, err
+		}
+
+		if _, err := tx.Exec(queries[accountsPost], entry.Account, entry.Amount); err != nil {
+			return nil, err
+		}
+	}
+
+	return transaction, nil
+}
+
+// checkFunds returns an *errs.FailedPrecondition error if entries would take a customer's available funds
+// below zero.
+func checkFunds(tx *sql.Tx, customerID string, entries []*accounts.Entry) error {
+	account := ledger.Available(customerID)
+
+	for _, entry := range entries {
+		if entry.Account != account || entry.Amount >= 0 {
+			continue
+		}
+
+		var balance int64
+		if err := tx.QueryRow(getLedgerQueries()[accountsBalance], account).Scan(&balance); err != nil {
+			return err
+		}
+
+		if balance+entry.Amount < 0 {
+			return &errs.FailedPrecondition{
+				Resource: "account",
+				ID:       customerID,
+				Reason:   fmt.Sprintf("insufficient funds: %d available, %d needed", balance, -entry.Amount),
+			}
+		}
+	}
+
+	return nil
+}
+
+// getTransaction runs one of the transaction queries with its entries, returning the transaction it
+// selects, or nil if there is none.
+func getTransaction(q queryer, name string, args ...interface{}) (*accounts.Transaction, error) {
+	rows, err := q.Query(getLedgerQueries()[name], args...)
+	if err != nil {
+		return nil, err
+	}
+
+	transactions, err := scanTransactions(rows)
+	if err != nil || len(transactions) == 0 {
+		return nil, err
+	}
+
+	return transactions[0], withEntries(q, transactions)
+}
+
+// withEntries reads the entries of transactions of a single customer.
+func withEntries(q queryer, transactions []*accounts.Transaction) error {
+	byID := make(map[int64]*accounts.Transaction, len(transactions))
+	first, last := transactions[0].Id, transactions[0].Id
+
+	for _, transaction := range transactions {
+		byID[transaction.Id] = transaction
+
+		if transaction.Id < first {
+			first = transaction.Id
+		}
+
+		if transaction.Id > last {
+			last = transaction.Id
+		}
+	}
+
+	rows, err := q.Query(getLedgerQueries()[transactionsListEntries], transactions[0].CustomerId, first, last)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			transactionID int64
+			entry         accounts.Entry
+		)
+
+		if err := rows.Scan(&transactionID, &entry.Account, &entry.Amount); err != nil {
+			return err
+		}
+
+		if transaction, ok := byID[transactionID]; ok {
+			transaction.Entries = append(transaction.Entries, &entry)
+		}
+	}
+
+	return rows.Err()
+}
+
+// scanTransactions reads transactions of transactionColumns from rows, closing them.
+func scanTransactions(rows *sql.Rows) ([]*accounts.Transaction, error) {
+	defer rows.Close()
+
+	var transactions []*accounts.Transaction
+
+	for rows.Next() {
+		var (
+			transaction     accounts.Transaction
+			transactionType string
+			createTime      time.Time
+		)
+
+		if err := rows.Scan(&transaction.Id, &transaction.CustomerId, &transactionType, &transaction.Amount,
+			&transaction.Reference, &createTime); err != nil {
+			return nil, err
+		}
+
+		transaction.Type = accounts.Transaction_Type(accounts.Transaction_Type_value[transactionType])
+
+		var err error
+		if transaction.CreateTime, err = ptypes.TimestampProto(createTime); err != nil {
+			return nil, err
+		}
+
+		transactions = append(transactions, &transaction)
+	}
+
+	return transactions, rows.Err()
+}
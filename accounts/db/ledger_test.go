@@ -0,0 +1,386 @@
+package db
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+
+	"git.neds.sh/matty/entain/accounts/proto/accounts"
+	"git.neds.sh/matty/entain/common/errs"
+)
+
+// customer is the customer whose account the tests post to.
+const customer = "customer-1"
+
+// newLedger returns a ledger on a new database, with 1000 cents deposited for the customer under the
+// reference "deposit-1".
+func newLedger(t *testing.T) LedgerRepo {
+	t.Helper()
+
+	db, err := Open(filepath.Join(t.TempDir(), "accounts.db"))
+	if err != nil {
+		t.Fatalf("Open() = %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	r := NewLedgerRepo(db)
+	if err := r.Init(); err != nil {
+		t.Fatalf("Init() = %v", err)
+	}
+
+	if _, err := r.Post(customer, accounts.Transaction_DEPOSIT, 1000, "deposit-1"); err != nil {
+		t.Fatalf("Post() of the deposit = %v", err)
+	}
+
+	return r
+}
+
+// checkBalance fails the test if the customer's balance is not the given one.
+func checkBalance(t *testing.T, r LedgerRepo, available, held int64) {
+	t.Helper()
+
+	balance, err := r.Balance(customer)
+	if err != nil {
+		t.Fatalf("Balance() = %v", err)
+	}
+
+	if balance.Available != available || balance.Held != held {
+		t.Errorf("balance = %d available, %d held, want %d available, %d held", balance.Available, balance.Held, available, held)
+	}
+}
+
+func TestPost(t *testing.T) {
+	// refuse is a guard refusing every transaction.
+	refuse := Guard{Check: func(Totals) error { return &errs.FailedPrecondition{Reason: "refused"} }}
+
+	tests := []struct {
+		name            string
+		transactionType accounts.Transaction_Type
+		amount          int64
+		reference       string
+		guards          []Guard
+		wantAmount      int64
+		wantErr         error
+		wantAvailable   int64
+	}{
+		{
+			name:            "deposit",
+			transactionType: accounts.Transaction_DEPOSIT,
+			amount:          500,
+			reference:       "deposit-2",
+			wantAmount:      500,
+			wantAvailable:   1500,
+		},
+		{
+			name:            "deposit again with the reference",
+			transactionType: accounts.Transaction_DEPOSIT,
+			amount:          1000,
+			reference:       "deposit-1",
+			wantAmount:      1000,
+			wantAvailable:   1000,
+		},
+		{
+			name:            "different amount with the reference",
+			transactionType: accounts.Transaction_DEPOSIT,
+			amount:          500,
+			reference:       "deposit-1",
+			wantErr:         &errs.InvalidArgument{},
+			wantAvailable:   1000,
+		},
+		{
+			name:            "another type with the reference",
+			transactionType: accounts.Transaction_WITHDRAWAL,
+			amount:          300,
+			reference:       "deposit-1",
+			wantAmount:      -300,
+			wantAvailable:   700,
+		},
+		{
+			name:            "withdraw everything",
+			transactionType: accounts.Transaction_WITHDRAWAL,
+			amount:          1000,
+			reference:       "withdrawal-1",
+			wantAmount:      -1000,
+			wantAvailable:   0,
+		},
+		{
+			name:            "withdraw more than available",
+			transactionType: accounts.Transaction_WITHDRAWAL,
+			amount:          1001,
+			reference:       "withdrawal-1",
+			wantErr:         &errs.FailedPrecondition{},
+			wantAvailable:   1000,
+		},
+		{
+			name:            "return",
+			transactionType: accounts.Transaction_RETURN,
+			amount:          2500,
+			reference:       "bet-1",
+			wantAmount:      2500,
+			wantAvailable:   3500,
+		},
+		{
+			name:            "reverse more than available",
+			transactionType: accounts.Transaction_REVERSAL,
+			amount:          1500,
+			reference:       "bet-1",
+			wantAmount:      -1500,
+			wantAvailable:   -500,
+		},
+		{
+			name:            "refused by a guard",
+			transactionType: accounts.Transaction_DEPOSIT,
+			amount:          500,
+			reference:       "deposit-2",
+			guards:          []Guard{refuse},
+			wantErr:         &errs.FailedPrecondition{},
+			wantAvailable:   1000,
+		},
+		{
+			name:            "guarded reference already posted",
+			transactionType: accounts.Transaction_DEPOSIT,
+			amount:          1000,
+			reference:       "deposit-1",
+			guards:          []Guard{refuse},
+			wantAmount:      1000,
+			wantAvailable:   1000,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newLedger(t)
+
+			got, err := r.Post(customer, tt.transactionType, tt.amount, tt.reference, tt.guards...)
+			if reflect.TypeOf(err) != reflect.TypeOf(tt.wantErr) {
+				t.Fatalf("Post() error = %v, want %T", err, tt.wantErr)
+			}
+
+			if err == nil && (got.Type != tt.transactionType || got.Amount != tt.wantAmount || got.Reference != tt.reference) {
+				t.Errorf("Post() = %v, want a %s of %d with reference %s", got, tt.transactionType, tt.wantAmount, tt.reference)
+			}
+
+			checkBalance(t, r, tt.wantAvailable, 0)
+		})
+	}
+}
+
+func TestPostIsIdempotent(t *testing.T) {
+	r := newLedger(t)
+
+	first, err := r.Post(customer, accounts.Transaction_DEPOSIT, 500, "deposit-2")
+	if err != nil {
+		t.Fatalf("Post() = %v", err)
+	}
+
+	again, err := r.Post(customer, accounts.Transaction_DEPOSIT, 500, "deposit-2")
+	if err != nil {
+		t.Fatalf("Post() again = %v", err)
+	}
+
+	if !proto.Equal(again, first) {
+		t.Errorf("Post() again = %v, want %v", again, first)
+	}
+
+	transactions, err := r.ListTransactions(customer, 0, 10)
+	if err != nil {
+		t.Fatalf("ListTransactions() = %v", err)
+	}
+
+	if len(transactions) != 2 {
+		t.Errorf("transactions = %v, want the deposits posted once each", transactions)
+	}
+}
+
+func TestHolds(t *testing.T) {
+	// A finish commits or releases a hold.
+	type finish func(r LedgerRepo, id int64) (*accounts.Hold, error)
+
+	var (
+		commit  finish = func(r LedgerRepo, id int64) (*accounts.Hold, error) { return r.Commit(id) }
+		release finish = func(r LedgerRepo, id int64) (*accounts.Hold, error) { return r.Release(id) }
+	)
+
+	tests := []struct {
+		name          string
+		finishes      []finish
+		wantStatus    accounts.Hold_Status
+		wantErr       error
+		wantAvailable int64
+	}{
+		{name: "reserved", wantStatus: accounts.Hold_RESERVED, wantAvailable: 600},
+		{name: "committed", finishes: []finish{commit}, wantStatus: accounts.Hold_COMMITTED, wantAvailable: 600},
+		{name: "released", finishes: []finish{release}, wantStatus: accounts.Hold_RELEASED, wantAvailable: 1000},
+		{name: "committed twice", finishes: []finish{commit, commit}, wantStatus: accounts.Hold_COMMITTED, wantAvailable: 600},
+		{name: "released twice", finishes: []finish{release, release}, wantStatus: accounts.Hold_RELEASED, wantAvailable: 1000},
+		{
+			name:          "released once committed",
+			finishes:      []finish{commit, release},
+			wantStatus:    accounts.Hold_COMMITTED,
+			wantErr:       &errs.FailedPrecondition{},
+			wantAvailable: 600,
+		},
+		{
+			name:          "committed once released",
+			finishes:      []finish{release, commit},
+			wantStatus:    accounts.Hold_RELEASED,
+			wantErr:       &errs.FailedPrecondition{},
+			wantAvailable: 1000,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newLedger(t)
+
+			hold, err := r.Reserve(customer, 400, "bet-1")
+			if err != nil {
+				t.Fatalf("Reserve() = %v", err)
+			}
+
+			var finished *accounts.Hold
+			for i, f := range tt.finishes {
+				got, err := f(r, hold.Id)
+
+				last := i == len(tt.finishes)-1
+				if last && reflect.TypeOf(err) != reflect.TypeOf(tt.wantErr) {
+					t.Fatalf("finishing the hold = %v, want %T", err, tt.wantErr)
+				} else if !last && err != nil {
+					t.Fatalf("finishing the hold = %v", err)
+				}
+
+				if finished != nil && err == nil && !proto.Equal(got, finished) {
+					t.Errorf("finishing the hold again = %v, want it unchanged at %v", got, finished)
+				}
+
+				if err == nil {
+					finished = got
+				}
+			}
+
+			if finished == nil {
+				finished = hold
+			}
+
+			if finished.Status != tt.wantStatus {
+				t.Errorf("hold status = %s, want %s", finished.Status, tt.wantStatus)
+			}
+
+			var held int64
+			if tt.wantStatus == accounts.Hold_RESERVED {
+				held = 400
+			}
+
+			checkBalance(t, r, tt.wantAvailable, held)
+		})
+	}
+}
+
+func TestReserve(t *testing.T) {
+	tests := []struct {
+		name          string
+		amount        int64
+		reference     string
+		wantErr       error
+		wantAvailable int64
+		wantHeld      int64
+	}{
+		{name: "again with the reference", amount: 400, reference: "bet-1", wantAvailable: 600, wantHeld: 400},
+		{name: "different amount with the reference", amount: 500, reference: "bet-1", wantErr: &errs.InvalidArgument{}, wantAvailable: 600, wantHeld: 400},
+		{name: "everything left", amount: 600, reference: "bet-2", wantAvailable: 0, wantHeld: 1000},
+		{name: "more than available", amount: 601, reference: "bet-2", wantErr: &errs.FailedPrecondition{}, wantAvailable: 600, wantHeld: 400},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newLedger(t)
+
+			first, err := r.Reserve(customer, 400, "bet-1")
+			if err != nil {
+				t.Fatalf("Reserve() = %v", err)
+			}
+
+			got, err := r.Reserve(customer, tt.amount, tt.reference)
+			if reflect.TypeOf(err) != reflect.TypeOf(tt.wantErr) {
+				t.Fatalf("Reserve() error = %v, want %T", err, tt.wantErr)
+			}
+
+			if err == nil && tt.reference == first.Reference && !proto.Equal(got, first) {
+				t.Errorf("Reserve() = %v, want %v", got, first)
+			}
+
+			checkBalance(t, r, tt.wantAvailable, tt.wantHeld)
+		})
+	}
+}
+
+func TestFinishUnknownHold(t *testing.T) {
+	r := newLedger(t)
+
+	if _, err := r.Commit(99); reflect.TypeOf(err) != reflect.TypeOf(&errs.NotFound{}) {
+		t.Errorf("Commit() = %v, want not found", err)
+	}
+
+	if _, err := r.Release(99); reflect.TypeOf(err) != reflect.TypeOf(&errs.NotFound{}) {
+		t.Errorf("Release() = %v, want not found", err)
+	}
+}
+
+func TestSettle(t *testing.T) {
+	r := newLedger(t)
+
+	hold, err := r.Reserve(customer, 400, "bet-1")
+	if err != nil {
+		t.Fatalf("Reserve() = %v", err)
+	}
+
+	if _, err := r.Commit(hold.Id); err != nil {
+		t.Fatalf("Commit() = %v", err)
+	}
+
+	if _, err := r.Reserve(customer, 100, "bet-2"); err != nil {
+		t.Fatalf("Reserve() = %v", err)
+	}
+
+	// The first bet wins 1000 cents, has its result changed and is reversed, then wins again.
+	steps := []struct {
+		transactionType accounts.Transaction_Type
+		reference       string
+		wantAvailable   int64
+		wantLost        int64
+	}{
+		{transactionType: accounts.Transaction_RETURN, reference: "bet-1", wantAvailable: 1500, wantLost: -500},
+		{transactionType: accounts.Transaction_RETURN, reference: "bet-1", wantAvailable: 1500, wantLost: -500},
+		{transactionType: accounts.Transaction_REVERSAL, reference: "bet-1", wantAvailable: 500, wantLost: 500},
+		{transactionType: accounts.Transaction_RETURN, reference: "bet-1.2", wantAvailable: 1500, wantLost: -500},
+	}
+
+	for _, step := range steps {
+		if _, err := r.Post(customer, step.transactionType, 1000, step.reference); err != nil {
+			t.Fatalf("Post() of a %s = %v", step.transactionType, err)
+		}
+
+		checkBalance(t, r, step.wantAvailable, 100)
+
+		totals, err := r.Totals(customer, time.Time{})
+		if err != nil {
+			t.Fatalf("Totals() = %v", err)
+		}
+
+		if want := (Totals{Deposited: 1000, Lost: step.wantLost}); totals != want {
+			t.Errorf("Totals() after a %s = %+v, want %+v", step.transactionType, totals, want)
+		}
+	}
+
+	totals, err := r.Totals(customer, time.Now().Add(time.Hour))
+	if err != nil {
+		t.Fatalf("Totals() = %v", err)
+	}
+
+	if totals != (Totals{}) {
+		t.Errorf("Totals() since a later time = %+v, want none", totals)
+	}
+}
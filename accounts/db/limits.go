@@ -0,0 +1,260 @@
+package db
+
+import (
+	"database/sql"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/duration"
+
+	"git.neds.sh/matty/entain/accounts/proto/limits"
+	"git.neds.sh/matty/entain/accounts/responsible"
+)
+
+// LimitsRepo provides repository access to customers' responsible gambling limits.
+type LimitsRepo interface {
+	// Init will initialise our limits repository, creating its tables.
+	Init() error
+
+	// List will return a customer's limits as stored, with any pending change whether or not it is due.
+	List(customerID string) ([]*limits.Limit, error)
+
+	// Save will store a limit, replacing the customer's limit of its type and period.
+	Save(limit *limits.Limit) error
+
+	// Delete will remove a customer's limit of a type and period, if they have one.
+	Delete(customerID string, limitType limits.Limit_Type, period limits.Limit_Period) error
+
+	// Exclusion will return a customer's latest self-exclusion, or nil if they have never self-excluded.
+	Exclusion(customerID string) (*limits.SelfExclusion, error)
+
+	// SaveExclusion will store a customer's self-exclusion, replacing their last.
+	SaveExclusion(exclusion *limits.SelfExclusion) error
+
+	// Session will return a customer's last session of betting, which is zero if they have not bet.
+	Session(customerID string) (responsible.Session, error)
+
+	// SaveSession will store a customer's session of betting, replacing their last.
+	SaveSession(customerID string, session responsible.Session) error
+}
+
+type limitsRepo struct {
+	db   *sql.DB
+	init sync.Once
+}
+
+// NewLimitsRepo creates a new limits repository, on a database opened by Open.
+func NewLimitsRepo(db *sql.DB) LimitsRepo {
+	return &limitsRepo{db: db}
+}
+
+// Init prepares the limits repository, migrating its database.
+func (r *limitsRepo) Init() error {
+	var err error
+
+	r.init.Do(func() {
+		err = migrate(r.db, limitsMigrations)
+	})
+
+	return err
+}
+
+func (r *limitsRepo) List(customerID string) ([]*limits.Limit, error) {
+	customerLimits, err := r.list(customerID)
+
+	return customerLimits, wrapError(err)
+}
+
+func (r *limitsRepo) list(customerID string) ([]*limits.Limit, error) {
+	rows, err := r.db.Query(getLimitsQueries()[limitsList], customerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var customerLimits []*limits.Limit
+
+	for rows.Next() {
+		var (
+			limit                          limits.Limit
+			limitType, period              string
+			duration                       int64
+			pendingRemove                  bool
+			pendingAmount, pendingDuration int64
+			pendingEffectiveTime           sql.NullTime
+			updateTime                     time.Time
+		)
+
+		if err := rows.Scan(&limit.CustomerId, &limitType, &period, &limit.Amount, &duration, &pendingRemove,
+			&pendingAmount, &pendingDuration, &pendingEffectiveTime, &updateTime); err != nil {
+			return nil, err
+		}
+
+		limit.Type = limits.Limit_Type(limits.Limit_Type_value[limitType])
+		limit.Period = limits.Limit_Period(limits.Limit_Period_value[period])
+
+		if limit.Type == limits.Limit_SESSION {
+			limit.Duration = ptypes.DurationProto(time.Duration(duration))
+		}
+
+		var err error
+		if limit.UpdateTime, err = ptypes.TimestampProto(updateTime); err != nil {
+			return nil, err
+		}
+
+		if pendingEffectiveTime.Valid {
+			limit.Pending = &limits.PendingChange{Remove: pendingRemove, Amount: pendingAmount}
+
+			if limit.Type == limits.Limit_SESSION && !pendingRemove {
+				limit.Pending.Duration = ptypes.DurationProto(time.Duration(pendingDuration))
+			}
+
+			if limit.Pending.EffectiveTime, err = ptypes.TimestampProto(pendingEffectiveTime.Time); err != nil {
+				return nil, err
+			}
+		}
+
+		customerLimits = append(customerLimits, &limit)
+	}
+
+	return customerLimits, rows.Err()
+}
+
+func (r *limitsRepo) Save(limit *limits.Limit) error {
+	return wrapError(r.save(limit))
+}
+
+func (r *limitsRepo) save(limit *limits.Limit) error {
+	duration, err := durationOf(limit.Duration)
+	if err != nil {
+		return err
+	}
+
+	updateTime, err := ptypes.Timestamp(limit.UpdateTime)
+	if err != nil {
+		return err
+	}
+
+	var (
+		pendingRemove        bool
+		pendingAmount        int64
+		pendingDuration      time.Duration
+		pendingEffectiveTime interface{}
+	)
+
+	if pending := limit.Pending; pending != nil {
+		pendingRemove, pendingAmount = pending.Remove, pending.Amount
+
+		if pendingDuration, err = durationOf(pending.Duration); err != nil {
+			return err
+		}
+
+		effectiveTime, err := ptypes.Timestamp(pending.EffectiveTime)
+		if err != nil {
+			return err
+		}
+
+		pendingEffectiveTime = effectiveTime.Format(time.RFC3339Nano)
+	}
+
+	_, err = r.db.Exec(getLimitsQueries()[limitsSave], limit.CustomerId, limit.Type.String(), limit.Period.String(),
+		limit.Amount, int64(duration), pendingRemove, pendingAmount, int64(pendingDuration), pendingEffectiveTime,
+		updateTime.Format(time.RFC3339Nano))
+
+	return err
+}
+
+// durationOf returns a duration, which is zero if unset.
+func durationOf(d *duration.Duration) (time.Duration, error) {
+	if d == nil {
+		return 0, nil
+	}
+
+	return ptypes.Duration(d)
+}
+
+func (r *limitsRepo) Delete(customerID string, limitType limits.Limit_Type, period limits.Limit_Period) error {
+	_, err := r.db.Exec(getLimitsQueries()[limitsDelete], customerID, limitType.String(), period.String())
+
+	return wrapError(err)
+}
+
+func (r *limitsRepo) Exclusion(customerID string) (*limits.SelfExclusion, error) {
+	exclusion, err := r.exclusion(customerID)
+
+	return exclusion, wrapError(err)
+}
+
+func (r *limitsRepo) exclusion(customerID string) (*limits.SelfExclusion, error) {
+	var (
+		exclusion = limits.SelfExclusion{CustomerId: customerID}
+		startTime time.Time
+		endTime   sql.NullTime
+	)
+
+	err := r.db.QueryRow(getLimitsQueries()[exclusionsGet], customerID).Scan(&startTime, &endTime, &exclusion.Reason)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if exclusion.StartTime, err = ptypes.TimestampProto(startTime); err != nil {
+		return nil, err
+	}
+
+	if endTime.Valid {
+		if exclusion.EndTime, err = ptypes.TimestampProto(endTime.Time); err != nil {
+			return nil, err
+		}
+	}
+
+	return &exclusion, nil
+}
+
+func (r *limitsRepo) SaveExclusion(exclusion *limits.SelfExclusion) error {
+	return wrapError(r.saveExclusion(exclusion))
+}
+
+func (r *limitsRepo) saveExclusion(exclusion *limits.SelfExclusion) error {
+	startTime, err := ptypes.Timestamp(exclusion.StartTime)
+	if err != nil {
+		return err
+	}
+
+	var endTime interface{}
+
+	if exclusion.EndTime != nil {
+		end, err := ptypes.Timestamp(exclusion.EndTime)
+		if err != nil {
+			return err
+		}
+
+		endTime = end.Format(time.RFC3339Nano)
+	}
+
+	_, err = r.db.Exec(getLimitsQueries()[exclusionsSave], exclusion.CustomerId, startTime.Format(time.RFC3339Nano), endTime, exclusion.Reason)
+
+	return err
+}
+
+func (r *limitsRepo) Session(customerID string) (responsible.Session, error) {
+	var session responsible.Session
+
+	err := r.db.QueryRow(getLimitsQueries()[sessionsGet], customerID).Scan(&session.Start, &session.LastActive)
+	if err == sql.ErrNoRows {
+		return responsible.Session{}, nil
+	}
+
+	return session, wrapError(err)
+}
+
+func (r *limitsRepo) SaveSession(customerID string, session responsible.Session) error {
+	_, err := r.db.Exec(getLimitsQueries()[sessionsSave], customerID,
+		session.Start.UTC().Format(time.RFC3339Nano), session.LastActive.UTC().Format(time.RFC3339Nano))
+
+	return wrapError(err)
+}
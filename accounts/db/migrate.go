@@ -0,0 +1,104 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// ledgerMigrations create the ledger's tables in the accounts database, and bring databases created by
+// earlier versions of the accounts service up to date. Each must be safe to run against a database it
+// has already been applied to.
+var ledgerMigrations = []func(db *sql.DB) error{
+	func(db *sql.DB) error {
+		return execAll(db, []string{
+			// The balance of each ledger account is kept alongside its entries, so that checking a customer
+			// can afford a transaction does not sum their whole history.
+			`CREATE TABLE IF NOT EXISTS ledger_accounts (
+				name TEXT PRIMARY KEY,
+				balance INTEGER NOT NULL
+			)`,
+			// A reference is only used once for each type of transaction of a customer.
+			`CREATE TABLE IF NOT EXISTS transactions (
+				id INTEGER PRIMARY KEY,
+				customer_id TEXT NOT NULL,
+				type TEXT NOT NULL,
+				amount INTEGER NOT NULL,
+				reference TEXT NOT NULL,
+				create_time DATETIME NOT NULL,
+				UNIQUE (customer_id, type, reference)
+			)`,
+			`CREATE TABLE IF NOT EXISTS entries (
+				id INTEGER PRIMARY KEY,
+				transaction_id INTEGER NOT NULL,
+				account TEXT NOT NULL,
+				amount INTEGER NOT NULL
+			)`,
+			`CREATE INDEX IF NOT EXISTS entries_transaction_id ON entries (transaction_id)`,
+			`CREATE TABLE IF NOT EXISTS holds (
+				id INTEGER PRIMARY KEY,
+				customer_id TEXT NOT NULL,
+				amount INTEGER NOT NULL,
+				reference TEXT NOT NULL,
+				status TEXT NOT NULL,
+				create_time DATETIME NOT NULL,
+				update_time DATETIME,
+				UNIQUE (customer_id, reference)
+			)`,
+		})
+	},
+}
+
+// limitsMigrations create the tables of customers' limits, kept apart from the ledger's, in the same way.
+var limitsMigrations = []func(db *sql.DB) error{
+	func(db *sql.DB) error {
+		return execAll(db, []string{
+			// A pending change is recorded alongside the limit it changes, with an effective time when there is one.
+			`CREATE TABLE IF NOT EXISTS limits (
+				customer_id TEXT NOT NULL,
+				type TEXT NOT NULL,
+				period TEXT NOT NULL,
+				amount INTEGER NOT NULL,
+				duration INTEGER NOT NULL,
+				pending_remove INTEGER NOT NULL DEFAULT 0,
+				pending_amount INTEGER NOT NULL DEFAULT 0,
+				pending_duration INTEGER NOT NULL DEFAULT 0,
+				pending_effective_time DATETIME,
+				update_time DATETIME NOT NULL,
+				PRIMARY KEY (customer_id, type, period)
+			)`,
+			`CREATE TABLE IF NOT EXISTS self_exclusions (
+				customer_id TEXT PRIMARY KEY,
+				start_time DATETIME NOT NULL,
+				end_time DATETIME,
+				reason TEXT NOT NULL
+			)`,
+			`CREATE TABLE IF NOT EXISTS sessions (
+				customer_id TEXT PRIMARY KEY,
+				start_time DATETIME NOT NULL,
+				last_active_time DATETIME NOT NULL
+			)`,
+		})
+	},
+}
+
+// migrate applies every migration in order.
+func migrate(db *sql.DB, migrations []func(db *sql.DB) error) error {
+	for i, migration := range migrations {
+		if err := migration(db); err != nil {
+			return fmt.Errorf("applying migration %d: %w", i+1, err)
+		}
+	}
+
+	return nil
+}
+
+// execAll runs each statement in turn.
+func execAll(db *sql.DB, statements []string) error {
+	for _, statement := range statements {
+		if _, err := db.Exec(statement); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
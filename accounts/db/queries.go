@@ -0,0 +1,159 @@
+package db
+
+const (
+	accountsBalance  = "accounts_balance"
+	accountsCustomer = "accounts_customer"
+	accountsPost     = "accounts_post"
+
+	transactionsGet         = "transactions_get"
+	transactionsGetByRef    = "transactions_get_by_ref"
+	transactionsInsert      = "transactions_insert"
+	transactionsList        = "transactions_list"
+	transactionsTotals      = "transactions_totals"
+	transactionsListEntries = "transactions_list_entries"
+	entriesInsert           = "entries_insert"
+
+	holdsGet      = "holds_get"
+	holdsGetByRef = "holds_get_by_ref"
+	holdsInsert   = "holds_insert"
+	holdsUpdate   = "holds_update"
+)
+
+func getLedgerQueries() map[string]string {
+	return map[string]string{
+		// Accounts without entries have no row, and a balance of zero.
+		accountsBalance: `
+			SELECT COALESCE((SELECT balance FROM ledger_accounts WHERE name = ?), 0)
+		`,
+		// Both of a customer's accounts are read by the one statement, so that they are read as of the same
+		// transaction.
+		accountsCustomer: `
+			SELECT
+				COALESCE(SUM(CASE WHEN name = ? THEN balance END), 0),
+				COALESCE(SUM(CASE WHEN name = ? THEN balance END), 0)
+			FROM ledger_accounts
+		`,
+		accountsPost: `
+			INSERT INTO ledger_accounts(name, balance)
+			VALUES (?,?)
+			ON CONFLICT(name) DO UPDATE SET balance = balance + excluded.balance
+		`,
+		transactionsGet: `
+			SELECT ` + transactionColumns + `
+			FROM transactions
+			WHERE id = ?
+		`,
+		transactionsGetByRef: `
+			SELECT ` + transactionColumns + `
+			FROM transactions
+			WHERE customer_id = ? AND type = ? AND reference = ?
+		`,
+		transactionsInsert: `
+			INSERT INTO transactions(customer_id, type, amount, reference, create_time)
+			VALUES (?,?,?,?,?)
+		`,
+		// Transactions are listed newest first, from before the last one of the previous page.
+		transactionsList: `
+			SELECT ` + transactionColumns + `
+			FROM transactions
+			WHERE customer_id = ? AND (? = 0 OR id < ?)
+			ORDER BY id DESC
+			LIMIT ?
+		`,
+		// The entries of a page are those of the customer's transactions from its first to its last.
+		// Amounts on the available account are negative when they take from it, so losses are the negative
+		// of what stakes held, and returned, left there.
+		transactionsTotals: `
+			SELECT
+				COALESCE(SUM(CASE WHEN type = 'DEPOSIT' THEN amount END), 0),
+				COALESCE(-SUM(CASE WHEN type IN ('RESERVATION', 'RELEASE', 'RETURN', 'REVERSAL') THEN amount END), 0)
+			FROM transactions
+			WHERE customer_id = ? AND create_time >= ?
+		`,
+		transactionsListEntries: `
+			SELECT transaction_id, account, amount
+			FROM entries
+			WHERE transaction_id IN (SELECT id FROM transactions WHERE customer_id = ? AND id BETWEEN ? AND ?)
+			ORDER BY id
+		`,
+		entriesInsert: `
+			INSERT INTO entries(transaction_id, account, amount)
+			VALUES (?,?,?)
+		`,
+		holdsGet: `
+			SELECT ` + holdColumns + `
+			FROM holds
+			WHERE id = ?
+		`,
+		holdsGetByRef: `
+			SELECT ` + holdColumns + `
+			FROM holds
+			WHERE customer_id = ? AND reference = ?
+		`,
+		holdsInsert: `
+			INSERT INTO holds(customer_id, amount, reference, status, create_time)
+			VALUES (?,?,?,?,?)
+		`,
+		// The hold is only changed if it is still reserved.
+		holdsUpdate: `
+			UPDATE holds SET status = ?, update_time = ?
+			WHERE id = ? AND status = 'RESERVED'
+		`,
+	}
+}
+
+const (
+	// transactionColumns are the columns of a transaction, in the order scanTransactions reads them.
+	transactionColumns = `id, customer_id, type, amount, reference, create_time`
+	// holdColumns are the columns of a hold, in the order scanHolds reads them.
+	holdColumns = `id, customer_id, amount, reference, status, create_time, update_time`
+)
+
+const (
+	limitsList   = "limits_list"
+	limitsSave   = "limits_save"
+	limitsDelete = "limits_delete"
+
+	exclusionsGet  = "exclusions_get"
+	exclusionsSave = "exclusions_save"
+
+	sessionsGet  = "sessions_get"
+	sessionsSave = "sessions_save"
+)
+
+func getLimitsQueries() map[string]string {
+	return map[string]string{
+		limitsList: `
+			SELECT customer_id, type, period, amount, duration, pending_remove, pending_amount, pending_duration, pending_effective_time, update_time
+			FROM limits
+			WHERE customer_id = ?
+			ORDER BY type, period
+		`,
+		limitsSave: `
+			INSERT OR REPLACE INTO limits(customer_id, type, period, amount, duration, pending_remove, pending_amount, pending_duration, pending_effective_time, update_time)
+			VALUES (?,?,?,?,?,?,?,?,?,?)
+		`,
+		limitsDelete: `
+			DELETE FROM limits
+			WHERE customer_id = ? AND type = ? AND period = ?
+		`,
+		exclusionsGet: `
+			SELECT start_time, end_time, reason
+			FROM self_exclusions
+			WHERE customer_id = ?
+		`,
+		exclusionsSave: `
+			INSERT OR REPLACE INTO self_exclusions(customer_id, start_time, end_time, reason)
+			VALUES (?,?,?,?)
+		`,
+		sessionsGet: `
+			SELECT start_time, last_active_time
+			FROM sessions
+			WHERE customer_id = ?
+		`,
+		sessionsSave: `
+			INSERT OR REPLACE INTO sessions(customer_id, start_time, last_active_time)
+			VALUES (?,?,?)
+		`,
+	}
+}
@@ -0,0 +1,89 @@
+// Package ledger describes the transactions of the accounts service as double-entry ledger entries.
+//
+// Each customer has two ledger accounts: their available funds, which they may bet or withdraw, and their
+// held funds, reserved for bets being placed. Money comes into the ledger from the bank on deposit, and
+// leaves it for the bank on withdrawal, while stakes are paid to the house and returns paid from it. Every
+// transaction moves an amount from one of these accounts to another, so the entries of a transaction, and
+// the balances of all the accounts, always sum to zero.
+package ledger
+
+import (
+	"fmt"
+
+	"git.neds.sh/matty/entain/accounts/proto/accounts"
+)
+
+const (
+	// Bank is the ledger account that deposits come from and withdrawals go to.
+	Bank = "bank"
+	// House is the ledger account that stakes are paid to and returns paid from.
+	House = "house"
+)
+
+// Available returns the ledger account of a customer's available funds.
+func Available(customerID string) string {
+	return fmt.Sprintf("customers/%s/available", customerID)
+}
+
+// Held returns the ledger account of a customer's held funds.
+func Held(customerID string) string {
+	return fmt.Sprintf("customers/%s/held", customerID)
+}
+
+// transfer is the movement of money a type of transaction makes, from one ledger account to another.
+type transfer struct {
+	from, to func(customerID string) string
+}
+
+func bank(string) string  { return Bank }
+func house(string) string { return House }
+
+var transfers = map[accounts.Transaction_Type]transfer{
+	accounts.Transaction_DEPOSIT:     {from: bank, to: Available},
+	accounts.Transaction_WITHDRAWAL:  {from: Available, to: bank},
+	accounts.Transaction_RESERVATION: {from: Available, to: Held},
+	accounts.Transaction_RELEASE:     {from: Held, to: Available},
+	accounts.Transaction_STAKE:       {from: Held, to: house},
+	accounts.Transaction_RETURN:      {from: house, to: Available},
+	accounts.Transaction_REVERSAL:    {from: Available, to: house},
+}
+
+// Entries returns the ledger entries of a transaction of the given type moving an amount, which must be
+// positive, for a customer. The first entry is the account the amount comes from.
+func Entries(transactionType accounts.Transaction_Type, customerID string, amount int64) ([]*accounts.Entry, error) {
+	t, ok := transfers[transactionType]
+	if !ok {
+		return nil, fmt.Errorf("unknown transaction type %s", transactionType)
+	}
+
+	return []*accounts.Entry{
+		{Account: t.from(customerID), Amount: -amount},
+		{Account: t.to(customerID), Amount: amount},
+	}, nil
+}
+
+// Change returns the change the entries of a transaction of the given type make to the customer, as
+// recorded on the transaction: to their available funds, or for stakes, which never touch those, to their
+// held funds.
+func Change(transactionType accounts.Transaction_Type, customerID string, entries []*accounts.Entry) int64 {
+	account := Available(customerID)
+	if transactionType == accounts.Transaction_STAKE {
+		account = Held(customerID)
+	}
+
+	var change int64
+
+	for _, entry := range entries {
+		if entry.Account == account {
+			change += entry.Amount
+		}
+	}
+
+	return change
+}
+
+// MayOverdraw reports whether a transaction of the given type may take a customer's available funds below
+// zero. Only reversals may, as they take back returns the customer may already have spent.
+func MayOverdraw(transactionType accounts.Transaction_Type) bool {
+	return transactionType == accounts.Transaction_REVERSAL
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"flag"
+	"log"
+	"net"
+
+	"git.neds.sh/matty/entain/accounts/db"
+	"git.neds.sh/matty/entain/accounts/proto/accounts"
+	"git.neds.sh/matty/entain/accounts/proto/limits"
+	"git.neds.sh/matty/entain/accounts/responsible"
+	"git.neds.sh/matty/entain/accounts/service"
+	"git.neds.sh/matty/entain/common/errs"
+	"git.neds.sh/matty/entain/common/validate"
+	"google.golang.org/grpc"
+)
+
+// domain identifies the accounts service in the ErrorInfo of its errors.
+const domain = "accounts.entain.com"
+
+var (
+	grpcEndpoint = flag.String("grpc-endpoint", "localhost:9200", "gRPC server endpoint")
+	dbPath       = flag.String("db-path", "./db/accounts.db", "path to the accounts SQLite database")
+
+	coolingOff   = flag.Duration("cooling-off", responsible.DefaultCoolingOff, "how long raising or removing a limit takes to apply")
+	sessionBreak = flag.Duration("session-break", responsible.DefaultSessionBreak, "how long a customer must go without betting for their session to end")
+)
+
+func main() {
+	flag.Parse()
+
+	if err := run(); err != nil {
+		log.Fatalf("failed running grpc server: %s\n", err)
+	}
+}
+
+func run() error {
+	conn, err := net.Listen("tcp", *grpcEndpoint)
+	if err != nil {
+		return err
+	}
+
+	accountsDB, err := db.Open(*dbPath)
+	if err != nil {
+		return err
+	}
+
+	ledgerRepo := db.NewLedgerRepo(accountsDB)
+	if err := ledgerRepo.Init(); err != nil {
+		return err
+	}
+
+	limitsRepo := db.NewLimitsRepo(accountsDB)
+	if err := limitsRepo.Init(); err != nil {
+		return err
+	}
+
+	// Errors are converted to statuses outermost, so that they cover validation failures too.
+	grpcServer := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(errs.UnaryServerInterceptor(domain), validate.UnaryServerInterceptor()),
+		grpc.ChainStreamInterceptor(errs.StreamServerInterceptor(domain), validate.StreamServerInterceptor()),
+	)
+
+	accounts.RegisterAccountsServer(
+		grpcServer,
+		service.NewAccountsService(ledgerRepo, limitsRepo, *sessionBreak),
+	)
+
+	limits.RegisterLimitsServer(
+		grpcServer,
+		service.NewLimitsService(limitsRepo, ledgerRepo, *coolingOff, *sessionBreak),
+	)
+
+	log.Printf("gRPC server listening on: %s\n", *grpcEndpoint)
+
+	return grpcServer.Serve(conn)
+}
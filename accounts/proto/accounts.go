@@ -0,0 +1,7 @@
+package proto
+
+// The protos are shared with the api gateway, and live in the proto directory at the root of the repo.
+// They declare no go_package, so each module maps them onto its own packages here. The validation rules
+// are those of the common module, whose validate package enforces them.
+//go:generate protoc -I ../../proto --go_out . --go_opt paths=source_relative --go_opt Maccounts/accounts.proto=git.neds.sh/matty/entain/accounts/proto/accounts --go_opt Mvalidate/validate.proto=git.neds.sh/matty/entain/common/proto/validate --go-grpc_out . --go-grpc_opt paths=source_relative --go-grpc_opt Maccounts/accounts.proto=git.neds.sh/matty/entain/accounts/proto/accounts --go-grpc_opt Mvalidate/validate.proto=git.neds.sh/matty/entain/common/proto/validate --go-grpc_opt require_unimplemented_servers=false accounts/accounts.proto
+//go:generate protoc -I ../../proto --go_out . --go_opt paths=source_relative --go_opt Mlimits/limits.proto=git.neds.sh/matty/entain/accounts/proto/limits --go_opt Mvalidate/validate.proto=git.neds.sh/matty/entain/common/proto/validate --go-grpc_out . --go-grpc_opt paths=source_relative --go-grpc_opt Mlimits/limits.proto=git.neds.sh/matty/entain/accounts/proto/limits --go-grpc_opt Mvalidate/validate.proto=git.neds.sh/matty/entain/common/proto/validate --go-grpc_opt require_unimplemented_servers=false limits/limits.proto
@@ -0,0 +1,223 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+
+package limits
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// LimitsClient is the client API for Limits service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type LimitsClient interface {
+	// GetLimits will return a customer's limits and self-exclusion, along with how much of each limit has
+	// been used.
+	GetLimits(ctx context.Context, in *GetLimitsRequest, opts ...grpc.CallOption) (*CustomerLimits, error)
+	// SetLimit will set one of a customer's limits. A lower limit applies at once, and a higher one is left
+	// pending until the cooling-off period has passed.
+	SetLimit(ctx context.Context, in *SetLimitRequest, opts ...grpc.CallOption) (*Limit, error)
+	// RemoveLimit will remove one of a customer's limits once the cooling-off period has passed, leaving the
+	// removal pending until then.
+	RemoveLimit(ctx context.Context, in *RemoveLimitRequest, opts ...grpc.CallOption) (*Limit, error)
+	// SelfExclude will exclude a customer from depositing and betting, for a time or for good. An exclusion
+	// can be extended, but never shortened.
+	SelfExclude(ctx context.Context, in *SelfExcludeRequest, opts ...grpc.CallOption) (*SelfExclusion, error)
+}
+
+type limitsClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewLimitsClient(cc grpc.ClientConnInterface) LimitsClient {
+	return &limitsClient{cc}
+}
+
+func (c *limitsClient) GetLimits(ctx context.Context, in *GetLimitsRequest, opts ...grpc.CallOption) (*CustomerLimits, error) {
+	out := new(CustomerLimits)
+	err := c.cc.Invoke(ctx, "/limits.Limits/GetLimits", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *limitsClient) SetLimit(ctx context.Context, in *SetLimitRequest, opts ...grpc.CallOption) (*Limit, error) {
+	out := new(Limit)
+	err := c.cc.Invoke(ctx, "/limits.Limits/SetLimit", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *limitsClient) RemoveLimit(ctx context.Context, in *RemoveLimitRequest, opts ...grpc.CallOption) (*Limit, error) {
+	out := new(Limit)
+	err := c.cc.Invoke(ctx, "/limits.Limits/RemoveLimit", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *limitsClient) SelfExclude(ctx context.Context, in *SelfExcludeRequest, opts ...grpc.CallOption) (*SelfExclusion, error) {
+	out := new(SelfExclusion)
+	err := c.cc.Invoke(ctx, "/limits.Limits/SelfExclude", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// LimitsServer is the server API for Limits service.
+// All implementations should embed UnimplementedLimitsServer
+// for forward compatibility
+type LimitsServer interface {
+	// GetLimits will return a customer's limits and self-exclusion, along with how much of each limit has
+	// been used.
+	GetLimits(context.Context, *GetLimitsRequest) (*CustomerLimits, error)
+	// SetLimit will set one of a customer's limits. A lower limit applies at once, and a higher one is left
+	// pending until the cooling-off period has passed.
+	SetLimit(context.Context, *SetLimitRequest) (*Limit, error)
+	// RemoveLimit will remove one of a customer's limits once the cooling-off period has passed, leaving the
+	// removal pending until then.
+	RemoveLimit(context.Context, *RemoveLimitRequest) (*Limit, error)
+	// SelfExclude will exclude a customer from depositing and betting, for a time or for good. An exclusion
+	// can be extended, but never shortened.
+	SelfExclude(context.Context, *SelfExcludeRequest) (*SelfExclusion, error)
+}
+
+// UnimplementedLimitsServer should be embedded to have forward compatible implementations.
+type UnimplementedLimitsServer struct {
+}
+
+func (UnimplementedLimitsServer) GetLimits(context.Context, *GetLimitsRequest) (*CustomerLimits, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLimits not implemented")
+}
+func (UnimplementedLimitsServer) SetLimit(context.Context, *SetLimitRequest) (*Limit, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetLimit not implemented")
+}
+func (UnimplementedLimitsServer) RemoveLimit(context.Context, *RemoveLimitRequest) (*Limit, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveLimit not implemented")
+}
+func (UnimplementedLimitsServer) SelfExclude(context.Context, *SelfExcludeRequest) (*SelfExclusion, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SelfExclude not implemented")
+}
+
+// UnsafeLimitsServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to LimitsServer will
+// result in compilation errors.
+type UnsafeLimitsServer interface {
+	mustEmbedUnimplementedLimitsServer()
+}
+
+func RegisterLimitsServer(s grpc.ServiceRegistrar, srv LimitsServer) {
+	s.RegisterService(&Limits_ServiceDesc, srv)
+}
+
+func _Limits_GetLimits_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLimitsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LimitsServer).GetLimits(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/limits.Limits/GetLimits",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LimitsServer).GetLimits(ctx, req.(*GetLimitsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Limits_SetLimit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetLimitRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LimitsServer).SetLimit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/limits.Limits/SetLimit",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LimitsServer).SetLimit(ctx, req.(*SetLimitRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Limits_RemoveLimit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveLimitRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LimitsServer).RemoveLimit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/limits.Limits/RemoveLimit",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LimitsServer).RemoveLimit(ctx, req.(*RemoveLimitRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Limits_SelfExclude_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SelfExcludeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LimitsServer).SelfExclude(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/limits.Limits/SelfExclude",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LimitsServer).SelfExclude(ctx, req.(*SelfExcludeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Limits_ServiceDesc is the grpc.ServiceDesc for Limits service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Limits_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "limits.Limits",
+	HandlerType: (*LimitsServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetLimits",
+			Handler:    _Limits_GetLimits_Handler,
+		},
+		{
+			MethodName: "SetLimit",
+			Handler:    _Limits_SetLimit_Handler,
+		},
+		{
+			MethodName: "RemoveLimit",
+			Handler:    _Limits_RemoveLimit_Handler,
+		},
+		{
+			MethodName: "SelfExclude",
+			Handler:    _Limits_SelfExclude_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "limits/limits.proto",
+}
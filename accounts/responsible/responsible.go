@@ -0,0 +1,314 @@
+// Package responsible works out the responsible gambling limits of customers: what a change to a limit does,
+// and whether a deposit or a bet stays within them.
+//
+// Deposit and loss limits apply over a rolling window ending now: the last day, week or month. Lowering
+// a limit, or setting one where there was none, applies at once, as it can only protect the customer.
+// Raising or removing one is left pending until the cooling-off period has passed, so that it cannot be
+// done on impulse. Session limits cap how long a session of betting lasts, where a session starts with a
+// bet and lasts until the customer goes the session break without placing one.
+package responsible
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+
+	"git.neds.sh/matty/entain/accounts/proto/limits"
+	"git.neds.sh/matty/entain/common/errs"
+)
+
+const (
+	// DefaultCoolingOff is how long raising or removing a limit takes to apply, unless the service is
+	// given another.
+	DefaultCoolingOff = 7 * 24 * time.Hour
+
+	// DefaultSessionBreak is how long a customer must go without betting for their session to end, unless
+	// the service is given another.
+	DefaultSessionBreak = 30 * time.Minute
+
+	// MinExclusion is the shortest a self-exclusion may be.
+	MinExclusion = 24 * time.Hour
+)
+
+// Window returns the length of the rolling window of a period, or 0 if it is not one.
+func Window(period limits.Limit_Period) time.Duration {
+	switch period {
+	case limits.Limit_DAY:
+		return 24 * time.Hour
+	case limits.Limit_WEEK:
+		return 7 * 24 * time.Hour
+	case limits.Limit_MONTH:
+		return 30 * 24 * time.Hour
+	default:
+		return 0
+	}
+}
+
+// Session is a customer's session of betting.
+type Session struct {
+	// Start is when the session started, with its first bet.
+	Start time.Time
+	// LastActive is when the last bet of the session was placed.
+	LastActive time.Time
+}
+
+// Continue returns the session a bet placed at the given time is part of: the customer's last session if
+// they have not since gone the session break without betting, and a new one otherwise.
+func Continue(last Session, now time.Time, sessionBreak time.Duration) Session {
+	if last.Start.IsZero() || now.Sub(last.LastActive) >= sessionBreak {
+		return Session{Start: now, LastActive: now}
+	}
+
+	return Session{Start: last.Start, LastActive: now}
+}
+
+// Check returns an *errs.InvalidArgument error if a limit is not one a customer may set.
+func Check(limit *limits.Limit) error {
+	switch limit.Type {
+	case limits.Limit_DEPOSIT, limits.Limit_LOSS:
+		if Window(limit.Period) == 0 {
+			return errs.Invalid("period", "must be DAY, WEEK or MONTH for %s limits", limit.Type)
+		}
+
+		if limit.Duration != nil {
+			return errs.Invalid("duration", "must not be given for %s limits", limit.Type)
+		}
+	case limits.Limit_SESSION:
+		if limit.Period != limits.Limit_PERIOD_UNSPECIFIED {
+			return errs.Invalid("period", "must not be given for SESSION limits")
+		}
+
+		if limit.Amount != 0 {
+			return errs.Invalid("amount", "must not be given for SESSION limits")
+		}
+	default:
+		return errs.Invalid("type", "must be DEPOSIT, LOSS or SESSION")
+	}
+
+	return nil
+}
+
+// CheckValue returns an *errs.InvalidArgument error if a limit being set has no value.
+func CheckValue(limit *limits.Limit) error {
+	if limit.Type == limits.Limit_SESSION {
+		if duration, err := ptypes.Duration(limit.Duration); err != nil || duration < time.Minute {
+			return errs.Invalid("duration", "must be at least a minute")
+		}
+
+		return nil
+	}
+
+	if limit.Amount <= 0 {
+		return errs.Invalid("amount", "must be greater than 0")
+	}
+
+	return nil
+}
+
+// Effective returns a limit as it stands at the given time, with its pending change applied if it is due,
+// or nil if it has been removed.
+func Effective(limit *limits.Limit, now time.Time) *limits.Limit {
+	if limit == nil || limit.Pending == nil {
+		return limit
+	}
+
+	effective, err := ptypes.Timestamp(limit.Pending.EffectiveTime)
+	if err != nil || now.Before(effective) {
+		return limit
+	}
+
+	if limit.Pending.Remove {
+		return nil
+	}
+
+	return &limits.Limit{
+		CustomerId: limit.CustomerId,
+		Type:       limit.Type,
+		Period:     limit.Period,
+		Amount:     limit.Pending.Amount,
+		Duration:   limit.Pending.Duration,
+		UpdateTime: limit.Pending.EffectiveTime,
+	}
+}
+
+// Change returns a limit as asking to change it at the given time leaves it: to the requested limit at
+// once if that is no higher, or with a pending change to it after the cooling-off period otherwise. A nil
+// current limit is one not set, and a nil requested limit asks for the limit's removal, which leaves it
+// nil if it was not set.
+func Change(current, requested *limits.Limit, now time.Time, coolingOff time.Duration) (*limits.Limit, error) {
+	nowProto, err := ptypes.TimestampProto(now)
+	if err != nil {
+		return nil, err
+	}
+
+	if current == nil {
+		if requested != nil {
+			requested.UpdateTime = nowProto
+		}
+
+		return requested, nil
+	}
+
+	if requested != nil && value(requested) <= value(current) {
+		requested.UpdateTime = nowProto
+
+		return requested, nil
+	}
+
+	effectiveTime, err := ptypes.TimestampProto(now.Add(coolingOff))
+	if err != nil {
+		return nil, err
+	}
+
+	changed := &limits.Limit{
+		CustomerId: current.CustomerId,
+		Type:       current.Type,
+		Period:     current.Period,
+		Amount:     current.Amount,
+		Duration:   current.Duration,
+		UpdateTime: nowProto,
+		Pending:    &limits.PendingChange{Remove: requested == nil, EffectiveTime: effectiveTime},
+	}
+
+	if requested != nil {
+		changed.Pending.Amount = requested.Amount
+		changed.Pending.Duration = requested.Duration
+	}
+
+	return changed, nil
+}
+
+// value is what a limit caps deposits, losses or sessions at, for comparing limits of the same type.
+func value(limit *limits.Limit) int64 {
+	if limit.Type == limits.Limit_SESSION {
+		duration, _ := ptypes.Duration(limit.Duration)
+		return int64(duration)
+	}
+
+	return limit.Amount
+}
+
+// CheckAmount returns an *errs.FailedPrecondition error if adding an amount to what has been deposited or
+// lost in a limit's period would take it over the limit.
+func CheckAmount(limit *limits.Limit, used, amount int64) error {
+	if used+amount <= limit.Amount {
+		return nil
+	}
+
+	what := "deposits of"
+	if limit.Type == limits.Limit_LOSS {
+		what = "losses of up to"
+	}
+
+	return &errs.FailedPrecondition{
+		Resource: "limit",
+		ID:       ID(limit),
+		Reason:   fmt.Sprintf("%s %d in the last %s would exceed the limit of %d", what, used+amount, periodName(limit.Period), limit.Amount),
+	}
+}
+
+// CheckSession returns an *errs.FailedPrecondition error if a session has lasted as long as a session
+// limit allows.
+func CheckSession(limit *limits.Limit, session Session, sessionBreak time.Duration) error {
+	duration, err := ptypes.Duration(limit.Duration)
+	if err != nil {
+		return err
+	}
+
+	if session.LastActive.Sub(session.Start) < duration {
+		return nil
+	}
+
+	return &errs.FailedPrecondition{
+		Resource: "limit",
+		ID:       ID(limit),
+		Reason:   fmt.Sprintf("the session has reached its limit of %s, and bets can be placed again after a break of %s", duration, sessionBreak),
+	}
+}
+
+// CheckExclusion returns an *errs.FailedPrecondition error if a customer is self-excluded at the given
+// time.
+func CheckExclusion(exclusion *limits.SelfExclusion, now time.Time) error {
+	if !Excluded(exclusion, now) {
+		return nil
+	}
+
+	reason := "the customer has self-excluded permanently"
+	if exclusion.EndTime != nil {
+		end, _ := ptypes.Timestamp(exclusion.EndTime)
+		reason = fmt.Sprintf("the customer has self-excluded until %s", end.Format(time.RFC3339))
+	}
+
+	return &errs.FailedPrecondition{Resource: "self exclusion", ID: exclusion.CustomerId, Reason: reason}
+}
+
+// Excluded reports whether a self-exclusion, which may be nil, excludes the customer at the given time.
+func Excluded(exclusion *limits.SelfExclusion, now time.Time) bool {
+	if exclusion == nil {
+		return false
+	}
+
+	if exclusion.EndTime == nil {
+		return true
+	}
+
+	end, err := ptypes.Timestamp(exclusion.EndTime)
+
+	return err != nil || now.Before(end)
+}
+
+// Exclude returns the self-exclusion asking to exclude a customer for a duration, or permanently if
+// duration is 0, at the given time leaves them with. An exclusion still in force can only be extended.
+func Exclude(current *limits.SelfExclusion, customerID string, duration time.Duration, reason string, now time.Time) (*limits.SelfExclusion, error) {
+	nowProto, err := ptypes.TimestampProto(now)
+	if err != nil {
+		return nil, err
+	}
+
+	exclusion := &limits.SelfExclusion{CustomerId: customerID, StartTime: nowProto, Reason: reason}
+
+	if duration > 0 {
+		if exclusion.EndTime, err = ptypes.TimestampProto(now.Add(duration)); err != nil {
+			return nil, err
+		}
+	}
+
+	if !Excluded(current, now) {
+		return exclusion, nil
+	}
+
+	// The exclusion in force keeps its start, and can only be given a later end.
+	exclusion.StartTime = current.StartTime
+
+	if current.EndTime == nil || (exclusion.EndTime != nil && !exclusion.EndTime.AsTime().After(current.EndTime.AsTime())) {
+		return nil, &errs.FailedPrecondition{
+			Resource: "self exclusion",
+			ID:       customerID,
+			Reason:   "the customer is already self-excluded for at least as long, and exclusions cannot be shortened",
+		}
+	}
+
+	return exclusion, nil
+}
+
+// ID identifies a limit of a customer in errors, such as "LOSS/WEEK".
+func ID(limit *limits.Limit) string {
+	if limit.Period == limits.Limit_PERIOD_UNSPECIFIED {
+		return limit.Type.String()
+	}
+
+	return limit.Type.String() + "/" + limit.Period.String()
+}
+
+// periodName names the window of a period, such as "week".
+func periodName(period limits.Limit_Period) string {
+	switch period {
+	case limits.Limit_DAY:
+		return "day"
+	case limits.Limit_WEEK:
+		return "week"
+	default:
+		return "month"
+	}
+}
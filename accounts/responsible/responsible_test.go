@@ -0,0 +1,305 @@
+package responsible
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"git.neds.sh/matty/entain/accounts/proto/limits"
+	"git.neds.sh/matty/entain/common/errs"
+)
+
+// now is when the limits of the tests are changed and checked.
+var now = time.Date(2026, 11, 3, 14, 0, 0, 0, time.UTC)
+
+// timestamp returns the time the given duration after now, as a timestamp.
+func timestamp(after time.Duration) *timestamppb.Timestamp {
+	ts, _ := ptypes.TimestampProto(now.Add(after))
+	return ts
+}
+
+// loss returns a weekly loss limit of the given amount.
+func loss(amount int64) *limits.Limit {
+	return &limits.Limit{CustomerId: "customer-1", Type: limits.Limit_LOSS, Period: limits.Limit_WEEK, Amount: amount}
+}
+
+// session returns a session limit of the given duration.
+func session(d time.Duration) *limits.Limit {
+	return &limits.Limit{CustomerId: "customer-1", Type: limits.Limit_SESSION, Duration: ptypes.DurationProto(d)}
+}
+
+// updated returns a limit updated now, with a pending change if given.
+func updated(limit *limits.Limit, pending *limits.PendingChange) *limits.Limit {
+	limit.UpdateTime = timestamp(0)
+	limit.Pending = pending
+
+	return limit
+}
+
+func TestChange(t *testing.T) {
+	coolingOff := 24 * time.Hour
+
+	tests := []struct {
+		name      string
+		current   *limits.Limit
+		requested *limits.Limit
+		want      *limits.Limit
+	}{
+		{
+			name:      "set",
+			requested: loss(1000),
+			want:      updated(loss(1000), nil),
+		},
+		{
+			name:    "remove a limit not set",
+			current: nil,
+		},
+		{
+			name:      "lower",
+			current:   loss(1000),
+			requested: loss(500),
+			want:      updated(loss(500), nil),
+		},
+		{
+			name:      "unchanged",
+			current:   loss(1000),
+			requested: loss(1000),
+			want:      updated(loss(1000), nil),
+		},
+		{
+			name:      "raise",
+			current:   loss(1000),
+			requested: loss(1500),
+			want:      updated(loss(1000), &limits.PendingChange{Amount: 1500, EffectiveTime: timestamp(coolingOff)}),
+		},
+		{
+			name:    "remove",
+			current: loss(1000),
+			want:    updated(loss(1000), &limits.PendingChange{Remove: true, EffectiveTime: timestamp(coolingOff)}),
+		},
+		{
+			name:      "lower with a raise pending",
+			current:   updated(loss(1000), &limits.PendingChange{Amount: 1500, EffectiveTime: timestamp(time.Hour)}),
+			requested: loss(800),
+			want:      updated(loss(800), nil),
+		},
+		{
+			name:      "shorten a session",
+			current:   session(time.Hour),
+			requested: session(30 * time.Minute),
+			want:      updated(session(30*time.Minute), nil),
+		},
+		{
+			name:      "lengthen a session",
+			current:   session(time.Hour),
+			requested: session(2 * time.Hour),
+			want:      updated(session(time.Hour), &limits.PendingChange{Duration: ptypes.DurationProto(2 * time.Hour), EffectiveTime: timestamp(coolingOff)}),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Change(tt.current, tt.requested, now, coolingOff)
+			if err != nil {
+				t.Fatalf("Change() = %v", err)
+			}
+
+			if !proto.Equal(got, tt.want) {
+				t.Errorf("Change() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEffective(t *testing.T) {
+	raised := updated(loss(1000), &limits.PendingChange{Amount: 1500, EffectiveTime: timestamp(time.Hour)})
+	removed := updated(loss(1000), &limits.PendingChange{Remove: true, EffectiveTime: timestamp(time.Hour)})
+
+	tests := []struct {
+		name  string
+		limit *limits.Limit
+		at    time.Time
+		want  *limits.Limit
+	}{
+		{name: "not set", at: now},
+		{name: "nothing pending", limit: loss(1000), at: now, want: loss(1000)},
+		{name: "raise cooling off", limit: raised, at: now.Add(time.Hour - time.Millisecond), want: raised},
+		{
+			name:  "raise due",
+			limit: raised,
+			at:    now.Add(time.Hour),
+			want:  &limits.Limit{CustomerId: "customer-1", Type: limits.Limit_LOSS, Period: limits.Limit_WEEK, Amount: 1500, UpdateTime: timestamp(time.Hour)},
+		},
+		{name: "removal cooling off", limit: removed, at: now, want: removed},
+		{name: "removal due", limit: removed, at: now.Add(2 * time.Hour)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Effective(tt.limit, tt.at); !proto.Equal(got, tt.want) {
+				t.Errorf("Effective() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestContinue(t *testing.T) {
+	sessionBreak := 30 * time.Minute
+	last := Session{Start: now, LastActive: now.Add(time.Hour)}
+
+	tests := []struct {
+		name string
+		last Session
+		at   time.Time
+		want Session
+	}{
+		{name: "first bet", at: now, want: Session{Start: now, LastActive: now}},
+		{name: "within the break", last: last, at: now.Add(89 * time.Minute), want: Session{Start: now, LastActive: now.Add(89 * time.Minute)}},
+		{name: "after the break", last: last, at: now.Add(90 * time.Minute), want: Session{Start: now.Add(90 * time.Minute), LastActive: now.Add(90 * time.Minute)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Continue(tt.last, tt.at, sessionBreak); got != tt.want {
+				t.Errorf("Continue() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckSession(t *testing.T) {
+	tests := []struct {
+		name    string
+		lasted  time.Duration
+		wantErr error
+	}{
+		{name: "just started", lasted: 0},
+		{name: "within the limit", lasted: time.Hour - time.Millisecond},
+		{name: "at the limit", lasted: time.Hour, wantErr: &errs.FailedPrecondition{}},
+		{name: "beyond the limit", lasted: 2 * time.Hour, wantErr: &errs.FailedPrecondition{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := Session{Start: now, LastActive: now.Add(tt.lasted)}
+
+			if err := CheckSession(session(time.Hour), s, DefaultSessionBreak); reflect.TypeOf(err) != reflect.TypeOf(tt.wantErr) {
+				t.Errorf("CheckSession() = %v, want %T", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCheckAmount(t *testing.T) {
+	tests := []struct {
+		name    string
+		used    int64
+		amount  int64
+		wantErr error
+	}{
+		{name: "nothing used", amount: 1000},
+		{name: "up to the limit", used: 600, amount: 400},
+		{name: "over the limit", used: 600, amount: 401, wantErr: &errs.FailedPrecondition{}},
+		{name: "already over the limit", used: 1200, amount: 1, wantErr: &errs.FailedPrecondition{}},
+		{name: "returns taking losses below zero", used: -500, amount: 1500},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := CheckAmount(loss(1000), tt.used, tt.amount); reflect.TypeOf(err) != reflect.TypeOf(tt.wantErr) {
+				t.Errorf("CheckAmount() = %v, want %T", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestExclude(t *testing.T) {
+	week := &limits.SelfExclusion{CustomerId: "customer-1", StartTime: timestamp(-time.Hour), EndTime: timestamp(7 * 24 * time.Hour)}
+	permanent := &limits.SelfExclusion{CustomerId: "customer-1", StartTime: timestamp(-time.Hour)}
+	ended := &limits.SelfExclusion{CustomerId: "customer-1", StartTime: timestamp(-48 * time.Hour), EndTime: timestamp(-24 * time.Hour)}
+
+	tests := []struct {
+		name     string
+		current  *limits.SelfExclusion
+		duration time.Duration
+		want     *limits.SelfExclusion
+		wantErr  error
+	}{
+		{
+			name:     "for a while",
+			duration: 24 * time.Hour,
+			want:     &limits.SelfExclusion{CustomerId: "customer-1", StartTime: timestamp(0), EndTime: timestamp(24 * time.Hour)},
+		},
+		{
+			name: "permanently",
+			want: &limits.SelfExclusion{CustomerId: "customer-1", StartTime: timestamp(0)},
+		},
+		{
+			name:     "after one has ended",
+			current:  ended,
+			duration: 24 * time.Hour,
+			want:     &limits.SelfExclusion{CustomerId: "customer-1", StartTime: timestamp(0), EndTime: timestamp(24 * time.Hour)},
+		},
+		{
+			name:     "extending one in force",
+			current:  week,
+			duration: 14 * 24 * time.Hour,
+			want:     &limits.SelfExclusion{CustomerId: "customer-1", StartTime: timestamp(-time.Hour), EndTime: timestamp(14 * 24 * time.Hour)},
+		},
+		{
+			name:    "making one in force permanent",
+			current: week,
+			want:    &limits.SelfExclusion{CustomerId: "customer-1", StartTime: timestamp(-time.Hour)},
+		},
+		{
+			name:     "shortening one in force",
+			current:  week,
+			duration: 24 * time.Hour,
+			wantErr:  &errs.FailedPrecondition{},
+		},
+		{
+			name:     "ending a permanent one",
+			current:  permanent,
+			duration: 365 * 24 * time.Hour,
+			wantErr:  &errs.FailedPrecondition{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Exclude(tt.current, "customer-1", tt.duration, "", now)
+			if reflect.TypeOf(err) != reflect.TypeOf(tt.wantErr) {
+				t.Fatalf("Exclude() error = %v, want %T", err, tt.wantErr)
+			}
+
+			if !proto.Equal(got, tt.want) {
+				t.Errorf("Exclude() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckExclusion(t *testing.T) {
+	tests := []struct {
+		name      string
+		exclusion *limits.SelfExclusion
+		wantErr   error
+	}{
+		{name: "never excluded"},
+		{name: "excluded", exclusion: &limits.SelfExclusion{StartTime: timestamp(-time.Hour), EndTime: timestamp(time.Millisecond)}, wantErr: &errs.FailedPrecondition{}},
+		{name: "excluded permanently", exclusion: &limits.SelfExclusion{StartTime: timestamp(-time.Hour)}, wantErr: &errs.FailedPrecondition{}},
+		{name: "exclusion ended", exclusion: &limits.SelfExclusion{StartTime: timestamp(-time.Hour), EndTime: timestamp(0)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := CheckExclusion(tt.exclusion, now); reflect.TypeOf(err) != reflect.TypeOf(tt.wantErr) {
+				t.Errorf("CheckExclusion() = %v, want %T", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,139 @@
+package service
+
+import (
+	"log"
+	"time"
+
+	"git.neds.sh/matty/entain/accounts/db"
+	"git.neds.sh/matty/entain/accounts/proto/accounts"
+	"git.neds.sh/matty/entain/common/errs"
+	"golang.org/x/net/context"
+)
+
+// defaultPageSize is how many transactions ListTransactions returns when the request does not say.
+const defaultPageSize = 100
+
+type Accounts interface {
+	// GetBalance will return the balance of a customer.
+	GetBalance(ctx context.Context, in *accounts.GetBalanceRequest) (*accounts.Balance, error)
+
+	// ListTransactions will return a page of a customer's transactions, newest first.
+	ListTransactions(ctx context.Context, in *accounts.ListTransactionsRequest) (*accounts.ListTransactionsResponse, error)
+
+	// Deposit will add funds to a customer's available balance, within their limits.
+	Deposit(ctx context.Context, in *accounts.DepositRequest) (*accounts.Transaction, error)
+
+	// Withdraw will take funds from a customer's available balance.
+	Withdraw(ctx context.Context, in *accounts.WithdrawRequest) (*accounts.Transaction, error)
+
+	// Reserve will hold funds of a customer's available balance for a stake, within their limits.
+	Reserve(ctx context.Context, in *accounts.ReserveRequest) (*accounts.Hold, error)
+
+	// Commit will pay the funds of a hold to the house as a stake.
+	Commit(ctx context.Context, in *accounts.CommitRequest) (*accounts.Hold, error)
+
+	// Release will return the funds of a hold to the customer.
+	Release(ctx context.Context, in *accounts.ReleaseRequest) (*accounts.Hold, error)
+
+	// Settle will pay a customer a return, or reverse one.
+	Settle(ctx context.Context, in *accounts.SettleRequest) (*accounts.Transaction, error)
+}
+
+// accountsService implements the Accounts interface.
+type accountsService struct {
+	ledgerRepo db.LedgerRepo
+	limitsRepo db.LimitsRepo
+	guards     *guards
+}
+
+// NewAccountsService instantiates and returns a new accountsService, which keeps deposits and stakes within
+// the customer's limits, ending sessions of betting after the session break.
+func NewAccountsService(ledgerRepo db.LedgerRepo, limitsRepo db.LimitsRepo, sessionBreak time.Duration) Accounts {
+	return &accountsService{
+		ledgerRepo: ledgerRepo,
+		limitsRepo: limitsRepo,
+		guards:     &guards{limitsRepo: limitsRepo, sessionBreak: sessionBreak},
+	}
+}
+
+func (s *accountsService) GetBalance(ctx context.Context, in *accounts.GetBalanceRequest) (*accounts.Balance, error) {
+	return s.ledgerRepo.Balance(in.CustomerId)
+}
+
+func (s *accountsService) ListTransactions(ctx context.Context, in *accounts.ListTransactionsRequest) (*accounts.ListTransactionsResponse, error) {
+	beforeID, err := decodePageToken(in)
+	if err != nil {
+		return nil, err
+	}
+
+	pageSize := int(in.PageSize)
+	if pageSize == 0 {
+		pageSize = defaultPageSize
+	}
+
+	// One more transaction than the page holds is read, to tell whether there is another page.
+	transactions, err := s.ledgerRepo.ListTransactions(in.CustomerId, beforeID, pageSize+1)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &accounts.ListTransactionsResponse{Transactions: transactions}
+
+	if len(transactions) > pageSize {
+		resp.Transactions = transactions[:pageSize]
+		resp.NextPageToken = encodePageToken(in, resp.Transactions[pageSize-1].Id)
+	}
+
+	return resp, nil
+}
+
+func (s *accountsService) Deposit(ctx context.Context, in *accounts.DepositRequest) (*accounts.Transaction, error) {
+	guards, err := s.guards.deposit(in.CustomerId, in.Amount, currentTime())
+	if err != nil {
+		return nil, err
+	}
+
+	return s.ledgerRepo.Post(in.CustomerId, accounts.Transaction_DEPOSIT, in.Amount, in.Reference, guards...)
+}
+
+func (s *accountsService) Withdraw(ctx context.Context, in *accounts.WithdrawRequest) (*accounts.Transaction, error) {
+	return s.ledgerRepo.Post(in.CustomerId, accounts.Transaction_WITHDRAWAL, in.Amount, in.Reference)
+}
+
+func (s *accountsService) Reserve(ctx context.Context, in *accounts.ReserveRequest) (*accounts.Hold, error) {
+	guards, session, err := s.guards.stake(in.CustomerId, in.Amount, currentTime())
+	if err != nil {
+		return nil, err
+	}
+
+	hold, err := s.ledgerRepo.Reserve(in.CustomerId, in.Amount, in.Reference, guards...)
+	if err != nil {
+		return nil, err
+	}
+
+	// The session only goes on with the bets it allows. Failing to record it only delays the session limit.
+	if err := s.limitsRepo.SaveSession(in.CustomerId, session); err != nil {
+		log.Printf("failed saving session of customer %s: %s\n", in.CustomerId, err)
+	}
+
+	return hold, nil
+}
+
+func (s *accountsService) Commit(ctx context.Context, in *accounts.CommitRequest) (*accounts.Hold, error) {
+	return s.ledgerRepo.Commit(in.HoldId)
+}
+
+func (s *accountsService) Release(ctx context.Context, in *accounts.ReleaseRequest) (*accounts.Hold, error) {
+	return s.ledgerRepo.Release(in.HoldId)
+}
+
+func (s *accountsService) Settle(ctx context.Context, in *accounts.SettleRequest) (*accounts.Transaction, error) {
+	switch {
+	case in.Amount > 0:
+		return s.ledgerRepo.Post(in.CustomerId, accounts.Transaction_RETURN, in.Amount, in.Reference)
+	case in.Amount < 0:
+		return s.ledgerRepo.Post(in.CustomerId, accounts.Transaction_REVERSAL, -in.Amount, in.Reference)
+	default:
+		return nil, errs.Invalid("amount", "must not be zero")
+	}
+}
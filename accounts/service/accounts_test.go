@@ -0,0 +1,92 @@
+package service
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+
+	"git.neds.sh/matty/entain/accounts/db"
+	"git.neds.sh/matty/entain/accounts/proto/accounts"
+	"git.neds.sh/matty/entain/accounts/proto/limits"
+	"git.neds.sh/matty/entain/common/errs"
+)
+
+// newLedger returns a ledger on a new database, with 1000 cents deposited for customer-1.
+func newLedger(t *testing.T) db.LedgerRepo {
+	t.Helper()
+
+	sqlDB, err := db.Open(filepath.Join(t.TempDir(), "accounts.db"))
+	if err != nil {
+		t.Fatalf("Open() = %v", err)
+	}
+	t.Cleanup(func() { sqlDB.Close() })
+
+	r := db.NewLedgerRepo(sqlDB)
+	if err := r.Init(); err != nil {
+		t.Fatalf("Init() = %v", err)
+	}
+
+	if _, err := r.Post("customer-1", accounts.Transaction_DEPOSIT, 1000, "deposit-1"); err != nil {
+		t.Fatalf("Post() of the deposit = %v", err)
+	}
+
+	return r
+}
+
+func TestReserve(t *testing.T) {
+	tests := []struct {
+		name          string
+		limits        []*limits.Limit
+		exclusion     *limits.SelfExclusion
+		wantErr       error
+		wantAvailable int64
+	}{
+		{name: "no limits", wantAvailable: 600},
+		{
+			name:          "within a loss limit",
+			limits:        []*limits.Limit{limit(limits.Limit_LOSS, limits.Limit_WEEK, 400, 0, 0)},
+			wantAvailable: 600,
+		},
+		{
+			name:          "over a loss limit",
+			limits:        []*limits.Limit{limit(limits.Limit_LOSS, limits.Limit_WEEK, 399, 0, 0)},
+			wantErr:       &errs.FailedPrecondition{},
+			wantAvailable: 1000,
+		},
+		{
+			name:          "self-excluded",
+			exclusion:     &limits.SelfExclusion{CustomerId: "customer-1"},
+			wantErr:       &errs.FailedPrecondition{},
+			wantAvailable: 1000,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ledgerRepo := newLedger(t)
+			limitsRepo := &limitsRepo{limits: tt.limits, exclusion: tt.exclusion}
+			s := NewAccountsService(ledgerRepo, limitsRepo, time.Hour)
+
+			_, err := s.Reserve(context.Background(), &accounts.ReserveRequest{CustomerId: "customer-1", Amount: 400, Reference: "bet-1"})
+			if reflect.TypeOf(err) != reflect.TypeOf(tt.wantErr) {
+				t.Fatalf("Reserve() = %v, want %T", err, tt.wantErr)
+			}
+
+			balance, err := ledgerRepo.Balance("customer-1")
+			if err != nil {
+				t.Fatalf("Balance() = %v", err)
+			}
+
+			if balance.Available != tt.wantAvailable {
+				t.Errorf("available = %d, want %d", balance.Available, tt.wantAvailable)
+			}
+
+			if limitsRepo.saved != (tt.wantErr == nil) {
+				t.Errorf("session saved = %t, want it saved only for bets placed", limitsRepo.saved)
+			}
+		})
+	}
+}
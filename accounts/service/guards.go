@@ -0,0 +1,99 @@
+package service
+
+import (
+	"time"
+
+	"git.neds.sh/matty/entain/accounts/db"
+	"git.neds.sh/matty/entain/accounts/proto/limits"
+	"git.neds.sh/matty/entain/accounts/responsible"
+)
+
+// guards are the checks of a customer's limits and self-exclusion on a deposit or a stake, which the
+// ledger makes as it posts them.
+type guards struct {
+	limitsRepo   db.LimitsRepo
+	sessionBreak time.Duration
+}
+
+// deposit returns the guards of a deposit of an amount: that the customer is not self-excluded, and is
+// within each of their deposit limits.
+func (g *guards) deposit(customerID string, amount int64, now time.Time) ([]db.Guard, error) {
+	exclusion, err := g.limitsRepo.Exclusion(customerID)
+	if err != nil {
+		return nil, err
+	}
+
+	customerLimits, err := effectiveLimits(g.limitsRepo, customerID, now)
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]db.Guard{excluded(exclusion, now)}, amountGuards(customerLimits, limits.Limit_DEPOSIT, amount, now)...), nil
+}
+
+// stake returns the guards of a stake of an amount, and the session of betting it is part of: that the
+// customer is not self-excluded, that the session is within their session limit, and that they are
+// within each of their loss limits, counting the whole stake as lost.
+func (g *guards) stake(customerID string, amount int64, now time.Time) ([]db.Guard, responsible.Session, error) {
+	exclusion, err := g.limitsRepo.Exclusion(customerID)
+	if err != nil {
+		return nil, responsible.Session{}, err
+	}
+
+	customerLimits, err := effectiveLimits(g.limitsRepo, customerID, now)
+	if err != nil {
+		return nil, responsible.Session{}, err
+	}
+
+	last, err := g.limitsRepo.Session(customerID)
+	if err != nil {
+		return nil, responsible.Session{}, err
+	}
+
+	session := responsible.Continue(last, now, g.sessionBreak)
+	stakeGuards := []db.Guard{excluded(exclusion, now)}
+
+	for _, limit := range customerLimits {
+		if limit.Type != limits.Limit_SESSION {
+			continue
+		}
+
+		limit := limit
+		stakeGuards = append(stakeGuards, db.Guard{Since: now, Check: func(db.Totals) error {
+			return responsible.CheckSession(limit, session, g.sessionBreak)
+		}})
+	}
+
+	return append(stakeGuards, amountGuards(customerLimits, limits.Limit_LOSS, amount, now)...), session, nil
+}
+
+// excluded returns a guard that the customer is not self-excluded.
+func excluded(exclusion *limits.SelfExclusion, now time.Time) db.Guard {
+	return db.Guard{Since: now, Check: func(db.Totals) error {
+		return responsible.CheckExclusion(exclusion, now)
+	}}
+}
+
+// amountGuards returns a guard for each deposit or loss limit of the given type, that an amount stays
+// within it over its window.
+func amountGuards(customerLimits []*limits.Limit, limitType limits.Limit_Type, amount int64, now time.Time) []db.Guard {
+	var amountGuards []db.Guard
+
+	for _, limit := range customerLimits {
+		if limit.Type != limitType {
+			continue
+		}
+
+		limit := limit
+		amountGuards = append(amountGuards, db.Guard{Since: now.Add(-responsible.Window(limit.Period)), Check: func(totals db.Totals) error {
+			used := totals.Deposited
+			if limitType == limits.Limit_LOSS {
+				used = totals.Lost
+			}
+
+			return responsible.CheckAmount(limit, used, amount)
+		}})
+	}
+
+	return amountGuards
+}
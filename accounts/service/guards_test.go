@@ -0,0 +1,269 @@
+package service
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+
+	"git.neds.sh/matty/entain/accounts/db"
+	"git.neds.sh/matty/entain/accounts/proto/limits"
+	"git.neds.sh/matty/entain/accounts/responsible"
+	"git.neds.sh/matty/entain/common/errs"
+)
+
+// now is when the deposits and stakes of the tests are made.
+var now = time.Date(2026, 11, 3, 14, 0, 0, 0, time.UTC)
+
+// limitsRepo is a LimitsRepo holding a customer's limits, self-exclusion and last session.
+type limitsRepo struct {
+	db.LimitsRepo
+
+	limits    []*limits.Limit
+	exclusion *limits.SelfExclusion
+	session   responsible.Session
+	saved     bool
+}
+
+func (r *limitsRepo) List(string) ([]*limits.Limit, error) {
+	return r.limits, nil
+}
+
+func (r *limitsRepo) Exclusion(string) (*limits.SelfExclusion, error) {
+	return r.exclusion, nil
+}
+
+func (r *limitsRepo) Session(string) (responsible.Session, error) {
+	return r.session, nil
+}
+
+func (r *limitsRepo) SaveSession(_ string, session responsible.Session) error {
+	r.session = session
+	r.saved = true
+
+	return nil
+}
+
+// limit returns a limit of the given type, with a pending change to the given amount effective after the
+// given time if that amount is set.
+func limit(limitType limits.Limit_Type, period limits.Limit_Period, amount, pending int64, effective time.Duration) *limits.Limit {
+	l := &limits.Limit{CustomerId: "customer-1", Type: limitType, Period: period, Amount: amount}
+	if pending > 0 {
+		effectiveTime, _ := ptypes.TimestampProto(now.Add(effective))
+		l.Pending = &limits.PendingChange{Amount: pending, EffectiveTime: effectiveTime}
+	}
+
+	return l
+}
+
+// sessionLimit returns a session limit of the given duration.
+func sessionLimit(d time.Duration) *limits.Limit {
+	return &limits.Limit{CustomerId: "customer-1", Type: limits.Limit_SESSION, Duration: ptypes.DurationProto(d)}
+}
+
+// excludedFor returns a self-exclusion that started a day ago, and ends after the given time.
+func excludedFor(d time.Duration) *limits.SelfExclusion {
+	start, _ := ptypes.TimestampProto(now.Add(-24 * time.Hour))
+	end, _ := ptypes.TimestampProto(now.Add(d))
+
+	return &limits.SelfExclusion{CustomerId: "customer-1", StartTime: start, EndTime: end}
+}
+
+// checkGuards returns the error of the first guard refusing a transaction, given the totals each guard
+// reads, and fails the test if a guard's window is not the one of its limit.
+func checkGuards(t *testing.T, guards []db.Guard, totals db.Totals, windows []time.Duration) error {
+	t.Helper()
+
+	for _, guard := range guards {
+		if window := now.Sub(guard.Since); !containsDuration(windows, window) {
+			t.Errorf("guard window = %s, want one of %v", window, windows)
+		}
+	}
+
+	for _, guard := range guards {
+		if err := guard.Check(totals); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func containsDuration(durations []time.Duration, d time.Duration) bool {
+	for _, duration := range durations {
+		if duration == d {
+			return true
+		}
+	}
+
+	return false
+}
+
+func TestGuardsDeposit(t *testing.T) {
+	windows := []time.Duration{0, 24 * time.Hour, 7 * 24 * time.Hour}
+
+	tests := []struct {
+		name      string
+		limits    []*limits.Limit
+		exclusion *limits.SelfExclusion
+		totals    db.Totals
+		wantErr   error
+	}{
+		{name: "no limits", totals: db.Totals{Deposited: 1000000}},
+		{
+			name:   "up to a deposit limit",
+			limits: []*limits.Limit{limit(limits.Limit_DEPOSIT, limits.Limit_DAY, 1000, 0, 0)},
+			totals: db.Totals{Deposited: 600},
+		},
+		{
+			name:    "over a deposit limit",
+			limits:  []*limits.Limit{limit(limits.Limit_DEPOSIT, limits.Limit_DAY, 1000, 0, 0)},
+			totals:  db.Totals{Deposited: 601},
+			wantErr: &errs.FailedPrecondition{},
+		},
+		{
+			name: "over one of two deposit limits",
+			limits: []*limits.Limit{
+				limit(limits.Limit_DEPOSIT, limits.Limit_DAY, 1000, 0, 0),
+				limit(limits.Limit_DEPOSIT, limits.Limit_WEEK, 500, 0, 0),
+			},
+			totals:  db.Totals{Deposited: 200},
+			wantErr: &errs.FailedPrecondition{},
+		},
+		{
+			name:   "over a loss limit",
+			limits: []*limits.Limit{limit(limits.Limit_LOSS, limits.Limit_DAY, 100, 0, 0)},
+			totals: db.Totals{Lost: 1000},
+		},
+		{
+			name:    "raise cooling off",
+			limits:  []*limits.Limit{limit(limits.Limit_DEPOSIT, limits.Limit_DAY, 1000, 5000, time.Millisecond)},
+			totals:  db.Totals{Deposited: 900},
+			wantErr: &errs.FailedPrecondition{},
+		},
+		{
+			name:   "raise due",
+			limits: []*limits.Limit{limit(limits.Limit_DEPOSIT, limits.Limit_DAY, 1000, 5000, 0)},
+			totals: db.Totals{Deposited: 900},
+		},
+		{
+			name:      "self-excluded",
+			exclusion: excludedFor(time.Millisecond),
+			wantErr:   &errs.FailedPrecondition{},
+		},
+		{
+			name:      "self-exclusion ended",
+			exclusion: excludedFor(0),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := &guards{limitsRepo: &limitsRepo{limits: tt.limits, exclusion: tt.exclusion}, sessionBreak: time.Hour}
+
+			depositGuards, err := g.deposit("customer-1", 400, now)
+			if err != nil {
+				t.Fatalf("deposit() = %v", err)
+			}
+
+			if err := checkGuards(t, depositGuards, tt.totals, windows); reflect.TypeOf(err) != reflect.TypeOf(tt.wantErr) {
+				t.Errorf("guard error = %v, want %T", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGuardsStake(t *testing.T) {
+	windows := []time.Duration{0, 7 * 24 * time.Hour}
+	sessionBreak := 30 * time.Minute
+
+	// session is one that started two hours ago, with its last bet placed the given time ago.
+	session := func(ago time.Duration) responsible.Session {
+		return responsible.Session{Start: now.Add(-2 * time.Hour), LastActive: now.Add(-ago)}
+	}
+
+	tests := []struct {
+		name        string
+		limits      []*limits.Limit
+		exclusion   *limits.SelfExclusion
+		session     responsible.Session
+		totals      db.Totals
+		wantSession responsible.Session
+		wantErr     error
+	}{
+		{
+			name:        "first bet",
+			wantSession: responsible.Session{Start: now, LastActive: now},
+		},
+		{
+			name:        "up to a loss limit",
+			limits:      []*limits.Limit{limit(limits.Limit_LOSS, limits.Limit_WEEK, 1000, 0, 0)},
+			totals:      db.Totals{Lost: 600},
+			wantSession: responsible.Session{Start: now, LastActive: now},
+		},
+		{
+			name:        "over a loss limit",
+			limits:      []*limits.Limit{limit(limits.Limit_LOSS, limits.Limit_WEEK, 1000, 0, 0)},
+			totals:      db.Totals{Lost: 601},
+			wantSession: responsible.Session{Start: now, LastActive: now},
+			wantErr:     &errs.FailedPrecondition{},
+		},
+		{
+			name:        "returns within a loss limit",
+			limits:      []*limits.Limit{limit(limits.Limit_LOSS, limits.Limit_WEEK, 1000, 0, 0)},
+			totals:      db.Totals{Lost: -2000},
+			wantSession: responsible.Session{Start: now, LastActive: now},
+		},
+		{
+			name:        "over a deposit limit",
+			limits:      []*limits.Limit{limit(limits.Limit_DEPOSIT, limits.Limit_WEEK, 100, 0, 0)},
+			totals:      db.Totals{Deposited: 1000},
+			wantSession: responsible.Session{Start: now, LastActive: now},
+		},
+		{
+			name:        "session within its limit",
+			limits:      []*limits.Limit{sessionLimit(3 * time.Hour)},
+			session:     session(10 * time.Minute),
+			wantSession: responsible.Session{Start: now.Add(-2 * time.Hour), LastActive: now},
+		},
+		{
+			name:        "session at its limit",
+			limits:      []*limits.Limit{sessionLimit(2 * time.Hour)},
+			session:     session(10 * time.Minute),
+			wantSession: responsible.Session{Start: now.Add(-2 * time.Hour), LastActive: now},
+			wantErr:     &errs.FailedPrecondition{},
+		},
+		{
+			name:        "new session after a break",
+			limits:      []*limits.Limit{sessionLimit(2 * time.Hour)},
+			session:     session(sessionBreak),
+			wantSession: responsible.Session{Start: now, LastActive: now},
+		},
+		{
+			name:        "self-excluded",
+			exclusion:   excludedFor(time.Hour),
+			wantSession: responsible.Session{Start: now, LastActive: now},
+			wantErr:     &errs.FailedPrecondition{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := &guards{limitsRepo: &limitsRepo{limits: tt.limits, exclusion: tt.exclusion, session: tt.session}, sessionBreak: sessionBreak}
+
+			stakeGuards, session, err := g.stake("customer-1", 400, now)
+			if err != nil {
+				t.Fatalf("stake() = %v", err)
+			}
+
+			if session != tt.wantSession {
+				t.Errorf("stake() session = %+v, want %+v", session, tt.wantSession)
+			}
+
+			if err := checkGuards(t, stakeGuards, tt.totals, windows); reflect.TypeOf(err) != reflect.TypeOf(tt.wantErr) {
+				t.Errorf("guard error = %v, want %T", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,212 @@
+package service
+
+import (
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+
+	"git.neds.sh/matty/entain/accounts/db"
+	"git.neds.sh/matty/entain/accounts/proto/limits"
+	"git.neds.sh/matty/entain/accounts/responsible"
+	"git.neds.sh/matty/entain/common/errs"
+	"golang.org/x/net/context"
+)
+
+type Limits interface {
+	// GetLimits will return a customer's limits and self-exclusion.
+	GetLimits(ctx context.Context, in *limits.GetLimitsRequest) (*limits.CustomerLimits, error)
+
+	// SetLimit will set one of a customer's limits.
+	SetLimit(ctx context.Context, in *limits.SetLimitRequest) (*limits.Limit, error)
+
+	// RemoveLimit will remove one of a customer's limits.
+	RemoveLimit(ctx context.Context, in *limits.RemoveLimitRequest) (*limits.Limit, error)
+
+	// SelfExclude will exclude a customer from depositing and betting.
+	SelfExclude(ctx context.Context, in *limits.SelfExcludeRequest) (*limits.SelfExclusion, error)
+}
+
+// limitsService implements the Limits interface.
+type limitsService struct {
+	limitsRepo   db.LimitsRepo
+	ledgerRepo   db.LedgerRepo
+	coolingOff   time.Duration
+	sessionBreak time.Duration
+}
+
+// NewLimitsService instantiates and returns a new limitsService, which delays raising and removing limits
+// by the cooling-off period, and ends sessions after the session break.
+func NewLimitsService(limitsRepo db.LimitsRepo, ledgerRepo db.LedgerRepo, coolingOff, sessionBreak time.Duration) Limits {
+	return &limitsService{limitsRepo: limitsRepo, ledgerRepo: ledgerRepo, coolingOff: coolingOff, sessionBreak: sessionBreak}
+}
+
+func (s *limitsService) GetLimits(ctx context.Context, in *limits.GetLimitsRequest) (*limits.CustomerLimits, error) {
+	now := currentTime()
+
+	customerLimits, err := effectiveLimits(s.limitsRepo, in.CustomerId, now)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, limit := range customerLimits {
+		if err := s.withUsage(limit, now); err != nil {
+			return nil, err
+		}
+	}
+
+	exclusion, err := s.limitsRepo.Exclusion(in.CustomerId)
+	if err != nil {
+		return nil, err
+	}
+
+	if !responsible.Excluded(exclusion, now) {
+		exclusion = nil
+	}
+
+	return &limits.CustomerLimits{CustomerId: in.CustomerId, Limits: customerLimits, SelfExclusion: exclusion}, nil
+}
+
+func (s *limitsService) SetLimit(ctx context.Context, in *limits.SetLimitRequest) (*limits.Limit, error) {
+	requested := &limits.Limit{
+		CustomerId: in.CustomerId,
+		Type:       in.Type,
+		Period:     in.Period,
+		Amount:     in.Amount,
+		Duration:   in.Duration,
+	}
+
+	if err := responsible.Check(requested); err != nil {
+		return nil, err
+	}
+
+	if err := responsible.CheckValue(requested); err != nil {
+		return nil, err
+	}
+
+	return s.change(requested, requested)
+}
+
+func (s *limitsService) RemoveLimit(ctx context.Context, in *limits.RemoveLimitRequest) (*limits.Limit, error) {
+	removed := &limits.Limit{CustomerId: in.CustomerId, Type: in.Type, Period: in.Period}
+
+	if err := responsible.Check(removed); err != nil {
+		return nil, err
+	}
+
+	return s.change(removed, nil)
+}
+
+// change asks for a customer's limit of the type and period of the given one to be changed to the
+// requested limit, or removed if that is nil, and returns the limit as it leaves it.
+func (s *limitsService) change(of, requested *limits.Limit) (*limits.Limit, error) {
+	now := currentTime()
+
+	customerLimits, err := effectiveLimits(s.limitsRepo, of.CustomerId, now)
+	if err != nil {
+		return nil, err
+	}
+
+	var current *limits.Limit
+
+	for _, limit := range customerLimits {
+		if limit.Type == of.Type && limit.Period == of.Period {
+			current = limit
+		}
+	}
+
+	if current == nil && requested == nil {
+		return nil, &errs.NotFound{Resource: "limit", ID: responsible.ID(of)}
+	}
+
+	changed, err := responsible.Change(current, requested, now, s.coolingOff)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.limitsRepo.Save(changed); err != nil {
+		return nil, err
+	}
+
+	return changed, s.withUsage(changed, now)
+}
+
+func (s *limitsService) SelfExclude(ctx context.Context, in *limits.SelfExcludeRequest) (*limits.SelfExclusion, error) {
+	var duration time.Duration
+
+	switch {
+	case in.Permanent && in.Duration != nil:
+		return nil, errs.Invalid("duration", "must not be given for permanent exclusions")
+	case !in.Permanent:
+		var err error
+		if duration, err = ptypes.Duration(in.Duration); err != nil || duration < responsible.MinExclusion {
+			return nil, errs.Invalid("duration", "must be at least %s, unless the exclusion is permanent", responsible.MinExclusion)
+		}
+	}
+
+	current, err := s.limitsRepo.Exclusion(in.CustomerId)
+	if err != nil {
+		return nil, err
+	}
+
+	exclusion, err := responsible.Exclude(current, in.CustomerId, duration, in.Reason, currentTime())
+	if err != nil {
+		return nil, err
+	}
+
+	return exclusion, s.limitsRepo.SaveExclusion(exclusion)
+}
+
+// withUsage fills in how much of a limit has been used.
+func (s *limitsService) withUsage(limit *limits.Limit, now time.Time) error {
+	if limit.Type == limits.Limit_SESSION {
+		session, err := s.limitsRepo.Session(limit.CustomerId)
+		if err != nil {
+			return err
+		}
+
+		// A session that has ended has no time used.
+		var used time.Duration
+		if !session.Start.IsZero() && now.Sub(session.LastActive) < s.sessionBreak {
+			used = session.LastActive.Sub(session.Start)
+		}
+
+		limit.SessionUsed = ptypes.DurationProto(used)
+
+		return nil
+	}
+
+	totals, err := s.ledgerRepo.Totals(limit.CustomerId, now.Add(-responsible.Window(limit.Period)))
+	if err != nil {
+		return err
+	}
+
+	limit.Used = totals.Deposited
+	if limit.Type == limits.Limit_LOSS {
+		limit.Used = totals.Lost
+	}
+
+	return nil
+}
+
+// effectiveLimits returns a customer's limits as they stand at the given time.
+func effectiveLimits(limitsRepo db.LimitsRepo, customerID string, now time.Time) ([]*limits.Limit, error) {
+	stored, err := limitsRepo.List(customerID)
+	if err != nil {
+		return nil, err
+	}
+
+	var customerLimits []*limits.Limit
+
+	for _, limit := range stored {
+		if effective := responsible.Effective(limit, now); effective != nil {
+			customerLimits = append(customerLimits, effective)
+		}
+	}
+
+	return customerLimits, nil
+}
+
+// currentTime returns the current time, to the millisecond that times are stored to.
+func currentTime() time.Time {
+	return time.Now().UTC().Truncate(time.Millisecond)
+}
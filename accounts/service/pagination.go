@@ -0,0 +1,60 @@
+package service
+
+import (
+	"encoding/base64"
+	"fmt"
+	"hash/fnv"
+	"strconv"
+	"strings"
+
+	"git.neds.sh/matty/entain/accounts/proto/accounts"
+	"git.neds.sh/matty/entain/common/errs"
+)
+
+// pageTokenVersion prefixes page tokens, so that their format can change without misreading old ones.
+const pageTokenVersion = "v1"
+
+// encodePageToken returns a token continuing a listing before the transaction with the given ID.
+//
+// Tokens are opaque to clients. They record a fingerprint of the customer listed, so that a token cannot
+// be used to continue the listing of another.
+func encodePageToken(in *accounts.ListTransactionsRequest, beforeID int64) string {
+	token := fmt.Sprintf("%s:%d:%x", pageTokenVersion, beforeID, fingerprint(in))
+
+	return base64.RawURLEncoding.EncodeToString([]byte(token))
+}
+
+// decodePageToken returns the ID of the last transaction listed by the previous page, or zero for the
+// first page.
+func decodePageToken(in *accounts.ListTransactionsRequest) (int64, error) {
+	if in.PageToken == "" {
+		return 0, nil
+	}
+
+	invalid := errs.Invalid("page_token", "is invalid, or was issued for a different customer")
+
+	raw, err := base64.RawURLEncoding.DecodeString(in.PageToken)
+	if err != nil {
+		return 0, invalid
+	}
+
+	parts := strings.Split(string(raw), ":")
+	if len(parts) != 3 || parts[0] != pageTokenVersion || parts[2] != fmt.Sprintf("%x", fingerprint(in)) {
+		return 0, invalid
+	}
+
+	beforeID, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil || beforeID <= 0 {
+		return 0, invalid
+	}
+
+	return beforeID, nil
+}
+
+// fingerprint hashes the customer of a request.
+func fingerprint(in *accounts.ListTransactionsRequest) uint64 {
+	h := fnv.New64a()
+	fmt.Fprint(h, in.CustomerId)
+
+	return h.Sum64()
+}
@@ -0,0 +1,155 @@
+// Package apierror renders the errors of the gateway as a stable JSON envelope.
+//
+// Every error response has the same shape, whichever service it came from:
+//
+//	{
+//	  "error": {
+//	    "code": 400,
+//	    "status": "INVALID_ARGUMENT",
+//	    "message": "invalid filter_expression at position 11: expected a value, found end of filter",
+//	    "reason": "INVALID_FILTER",
+//	    "domain": "racing.entain.com",
+//	    "metadata": {"position": "11"},
+//	    "field_violations": [{"field": "filter_expression", "description": "..."}]
+//	  }
+//	}
+//
+// The reason, domain, metadata, field_violations and retry_delay members are only present when the
+// service attached the corresponding google.rpc error details.
+package apierror
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/genproto/googleapis/rpc/code"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/grpclog"
+	"google.golang.org/grpc/status"
+)
+
+// Envelope is the body of every error response.
+type Envelope struct {
+	Error Body `json:"error"`
+}
+
+// Body describes an error.
+type Body struct {
+	// Code is the HTTP status code.
+	Code int `json:"code"`
+	// Status is the name of the gRPC status code, such as NOT_FOUND.
+	Status string `json:"status"`
+	// Message is a developer facing description of the error.
+	Message string `json:"message"`
+	// Reason is a machine readable identifier of the cause, such as RACE_NOT_FOUND.
+	Reason string `json:"reason,omitempty"`
+	// Domain is the service the reason belongs to.
+	Domain string `json:"domain,omitempty"`
+	// Metadata adds structured detail to the reason.
+	Metadata map[string]string `json:"metadata,omitempty"`
+	// FieldViolations lists the problems with each field of a bad request.
+	FieldViolations []FieldViolation `json:"field_violations,omitempty"`
+	// RetryDelay is how long the client should wait before retrying, such as "1s".
+	RetryDelay string `json:"retry_delay,omitempty"`
+}
+
+// FieldViolation describes a problem with a single field of a request.
+type FieldViolation struct {
+	Field       string `json:"field"`
+	Description string `json:"description"`
+}
+
+// opaqueCodes are those whose messages, without details from one of our services, may come from
+// anywhere, such as a dial error naming an internal address. Their messages are replaced.
+var opaqueCodes = map[codes.Code]string{
+	codes.Unknown:     "internal error",
+	codes.Internal:    "internal error",
+	codes.DataLoss:    "internal error",
+	codes.Unavailable: "the service is temporarily unavailable, please retry",
+}
+
+// Handler is a runtime.ErrorHandlerFunc that writes errors as an Envelope.
+func Handler(ctx context.Context, mux *runtime.ServeMux, _ runtime.Marshaler, w http.ResponseWriter, r *http.Request, err error) {
+	Write(w, status.Convert(err))
+}
+
+// Write writes a gRPC status as an Envelope, for errors raised by the gateway itself.
+func Write(w http.ResponseWriter, st *status.Status) {
+	envelope := NewEnvelope(st)
+
+	w.Header().Del("Trailer")
+	w.Header().Del("Transfer-Encoding")
+	w.Header().Set("Content-Type", "application/json")
+
+	if delay, ok := retryDelay(st); ok {
+		w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(delay.Seconds()))))
+	}
+
+	body, err := json.Marshal(envelope)
+	if err != nil {
+		grpclog.Infof("Failed to marshal error envelope: %v", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(`{"error":{"code":500,"status":"INTERNAL","message":"internal error"}}`))
+
+		return
+	}
+
+	w.WriteHeader(envelope.Error.Code)
+	if _, err := w.Write(body); err != nil {
+		grpclog.Infof("Failed to write error response: %v", err)
+	}
+}
+
+// NewEnvelope describes a gRPC status as an Envelope.
+func NewEnvelope(st *status.Status) Envelope {
+	body := Body{
+		Code:    runtime.HTTPStatusFromCode(st.Code()),
+		Status:  code.Code_name[int32(st.Code())],
+		Message: st.Message(),
+	}
+
+	for _, detail := range st.Details() {
+		switch d := detail.(type) {
+		case *errdetails.ErrorInfo:
+			body.Reason = d.Reason
+			body.Domain = d.Domain
+			body.Metadata = d.Metadata
+		case *errdetails.BadRequest:
+			for _, violation := range d.FieldViolations {
+				body.FieldViolations = append(body.FieldViolations, FieldViolation{
+					Field:       violation.Field,
+					Description: violation.Description,
+				})
+			}
+		}
+	}
+
+	if delay, ok := retryDelay(st); ok {
+		body.RetryDelay = delay.String()
+	}
+
+	if message, ok := opaqueCodes[st.Code()]; ok && body.Domain == "" {
+		body.Message = message
+	}
+
+	return Envelope{Error: body}
+}
+
+// retryDelay returns how long the status asks clients to wait before retrying, if it says.
+func retryDelay(st *status.Status) (time.Duration, bool) {
+	for _, detail := range st.Details() {
+		if retryInfo, ok := detail.(*errdetails.RetryInfo); ok {
+			delay, err := ptypes.Duration(retryInfo.RetryDelay)
+			return delay, err == nil
+		}
+	}
+
+	return 0, false
+}
@@ -0,0 +1,185 @@
+// Package auth authenticates customers to the routes of the gateway that act on their behalf.
+//
+// Customers authenticate with a bearer token: a JSON Web Token signed with HMAC SHA-256 using the secret
+// shared with whoever issues them, whose subject is the customer's ID and which expires. Requests to a
+// customer route without a valid token are answered with 401 Unauthenticated, and requests with a token
+// for another customer than the one in the path with 403 Permission Denied, both as error envelopes.
+//
+// Some routes, such as those for bets, do not name the customer in their paths, and any customer may use
+// them on their own behalf. The gateway tells the services behind every route who the request was
+// authenticated for in the CustomerMetadata of the request, and those services check that what such routes
+// act on is the customer's own.
+package auth
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"git.neds.sh/matty/entain/api/apierror"
+)
+
+// CustomerMetadata is the gRPC metadata telling services the ID of the customer a request from the gateway
+// was authenticated for, which is empty for requests that were not.
+const CustomerMetadata = "authenticated-customer-id"
+
+// header is the header of every token, which is only ever signed one way.
+var header = base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`))
+
+// Claims are what a token says about its bearer.
+type Claims struct {
+	// Subject is the ID of the customer.
+	Subject string `json:"sub"`
+	// Expiry is when the token expires, in seconds since the Unix epoch.
+	Expiry int64 `json:"exp"`
+}
+
+// Sign returns a token for the claims, signed with the secret.
+func Sign(secret []byte, claims Claims) (string, error) {
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+
+	signed := header + "." + base64.RawURLEncoding.EncodeToString(payload)
+
+	return signed + "." + signature(secret, signed), nil
+}
+
+// Verify returns the claims of a token, or an error if it was not signed with the secret, or has expired.
+func Verify(secret []byte, token string, now time.Time) (Claims, error) {
+	var claims Claims
+
+	if len(secret) == 0 {
+		return claims, errors.New("no secret to verify tokens with")
+	}
+
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 || parts[0] != header {
+		return claims, errors.New("malformed token")
+	}
+
+	if !hmac.Equal([]byte(parts[2]), []byte(signature(secret, parts[0]+"."+parts[1]))) {
+		return claims, errors.New("invalid signature")
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return claims, errors.New("malformed token")
+	}
+
+	if err := json.Unmarshal(payload, &claims); err != nil || claims.Subject == "" {
+		return claims, errors.New("malformed token")
+	}
+
+	if now.Unix() >= claims.Expiry {
+		return claims, errors.New("expired token")
+	}
+
+	return claims, nil
+}
+
+// signature returns the HMAC SHA-256 signature of the signed part of a token.
+func signature(secret []byte, signed string) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(signed))
+
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// Route is a set of routes acting on behalf of a customer.
+type Route struct {
+	// Prefix is what the paths of the routes start with. Unless AnyCustomer is set, the ID of the customer
+	// follows it as the next segment, such as "/v1/accounts/".
+	Prefix string
+	// AnyCustomer is set for routes whose paths do not name the customer, such as "/v1/bets", which any
+	// customer with a valid token may use on their own behalf.
+	AnyCustomer bool
+}
+
+// customer returns the ID of the customer a path names, if it is one of the route's. It is empty for
+// routes any customer may use.
+func (r Route) customer(path string) (string, bool) {
+	if !strings.HasPrefix(path, r.Prefix) {
+		return "", false
+	}
+
+	if r.AnyCustomer {
+		return "", true
+	}
+
+	customerID := strings.SplitN(strings.TrimPrefix(path, r.Prefix), "/", 2)[0]
+
+	return customerID, customerID != ""
+}
+
+// customerKey is the context key of the ID of the customer a request was authenticated for.
+type customerKey struct{}
+
+// Handler wraps a handler so that only the customer named in the path may use the given routes, or any
+// customer with a valid token for routes that do not name one.
+func Handler(next http.Handler, secret []byte, routes ...Route) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		// The gateway forwards headers of this form as gRPC metadata, which must not let a request claim to
+		// be authenticated for a customer.
+		req.Header.Del("Grpc-Metadata-" + CustomerMetadata)
+
+		customerID, ok := match(routes, req.URL.Path)
+		if !ok {
+			next.ServeHTTP(w, req)
+			return
+		}
+
+		authorization := req.Header.Get("Authorization")
+		if !strings.HasPrefix(authorization, "Bearer ") {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="entain"`)
+			apierror.Write(w, status.New(codes.Unauthenticated, "a bearer token is required"))
+
+			return
+		}
+
+		claims, err := Verify(secret, strings.TrimPrefix(authorization, "Bearer "), time.Now())
+		if err != nil {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="entain", error="invalid_token"`)
+			apierror.Write(w, status.Newf(codes.Unauthenticated, "the bearer token is invalid: %s", err))
+
+			return
+		}
+
+		if customerID != "" && claims.Subject != customerID {
+			apierror.Write(w, status.Newf(codes.PermissionDenied, "the bearer token is not for customer %s", customerID))
+			return
+		}
+
+		next.ServeHTTP(w, req.WithContext(context.WithValue(req.Context(), customerKey{}, claims.Subject)))
+	})
+}
+
+// Metadata returns the CustomerMetadata of a request, for the gateway to send the services behind it.
+func Metadata(ctx context.Context, req *http.Request) metadata.MD {
+	customerID, _ := req.Context().Value(customerKey{}).(string)
+
+	return metadata.Pairs(CustomerMetadata, customerID)
+}
+
+// match returns the customer named by the path of a request to one of the routes, which is empty for
+// routes any customer may use.
+func match(routes []Route, path string) (string, bool) {
+	for _, route := range routes {
+		if customerID, ok := route.customer(path); ok {
+			return customerID, true
+		}
+	}
+
+	return "", false
+}
@@ -0,0 +1,153 @@
+package auth
+
+import (
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// secret is what the tokens of the tests are signed with.
+var secret = []byte("secret")
+
+// now is when the tokens of the tests are verified.
+var now = time.Date(2026, 11, 3, 14, 0, 0, 0, time.UTC)
+
+// token returns a token for the customer expiring the given time after now, signed with the given secret.
+func token(t *testing.T, secret []byte, customerID string, expiresIn time.Duration) string {
+	t.Helper()
+
+	signed, err := Sign(secret, Claims{Subject: customerID, Expiry: now.Add(expiresIn).Unix()})
+	if err != nil {
+		t.Fatalf("Sign() = %v", err)
+	}
+
+	return signed
+}
+
+func TestVerify(t *testing.T) {
+	valid := token(t, secret, "customer-1", time.Hour)
+	parts := strings.Split(valid, ".")
+
+	// The payload of another customer's token, under the signature of customer-1's.
+	forged := parts[0] + "." + strings.Split(token(t, secret, "customer-2", time.Hour), ".")[1] + "." + parts[2]
+	unsigned := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"none","typ":"JWT"}`)) + "." + parts[1] + "."
+
+	tests := []struct {
+		name    string
+		secret  []byte
+		token   string
+		want    string
+		wantErr bool
+	}{
+		{name: "valid", secret: secret, token: valid, want: "customer-1"},
+		{name: "expiring in a second", secret: secret, token: token(t, secret, "customer-1", time.Second), want: "customer-1"},
+		{name: "expiring now", secret: secret, token: token(t, secret, "customer-1", 0), wantErr: true},
+		{name: "expired", secret: secret, token: token(t, secret, "customer-1", -time.Hour), wantErr: true},
+		{name: "signed with another secret", secret: secret, token: token(t, []byte("other"), "customer-1", time.Hour), wantErr: true},
+		{name: "forged payload", secret: secret, token: forged, wantErr: true},
+		{name: "unsigned", secret: secret, token: unsigned, wantErr: true},
+		{name: "malformed", secret: secret, token: parts[0] + "." + parts[1], wantErr: true},
+		{name: "without a subject", secret: secret, token: token(t, secret, "", time.Hour), wantErr: true},
+		{name: "without a secret", token: token(t, nil, "customer-1", time.Hour), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims, err := Verify(tt.secret, tt.token, now)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Verify() error = %v, want error %t", err, tt.wantErr)
+			}
+
+			if err == nil && claims.Subject != tt.want {
+				t.Errorf("Verify() subject = %q, want %q", claims.Subject, tt.want)
+			}
+		})
+	}
+}
+
+func TestHandler(t *testing.T) {
+	routes := []Route{
+		{Prefix: "/v1/accounts/"},
+		{Prefix: "/v1/bets", AnyCustomer: true},
+	}
+
+	// The handler verifies tokens at the current time rather than now, so these expire an hour either side of it.
+	valid := token(t, secret, "customer-1", time.Since(now)+time.Hour)
+	expired := token(t, secret, "customer-1", time.Since(now)-time.Hour)
+
+	tests := []struct {
+		name          string
+		path          string
+		authorization string
+		wantStatus    int
+		wantCustomer  string
+		wantChallenge bool
+	}{
+		{name: "not a customer route", path: "/v1/list-races", wantStatus: http.StatusOK},
+		{name: "own account", path: "/v1/accounts/customer-1/balance", authorization: "Bearer " + valid, wantStatus: http.StatusOK, wantCustomer: "customer-1"},
+		{name: "any customer's route", path: "/v1/bets", authorization: "Bearer " + valid, wantStatus: http.StatusOK, wantCustomer: "customer-1"},
+		{name: "another customer's account", path: "/v1/accounts/customer-2/balance", authorization: "Bearer " + valid, wantStatus: http.StatusForbidden},
+		{name: "without a token", path: "/v1/accounts/customer-1/balance", wantStatus: http.StatusUnauthorized, wantChallenge: true},
+		{name: "not a bearer token", path: "/v1/accounts/customer-1/balance", authorization: "Basic Y3VzdG9tZXI6", wantStatus: http.StatusUnauthorized, wantChallenge: true},
+		{name: "expired token", path: "/v1/accounts/customer-1/balance", authorization: "Bearer " + expired, wantStatus: http.StatusUnauthorized, wantChallenge: true},
+		{
+			name:          "token signed with another secret",
+			path:          "/v1/bets",
+			authorization: "Bearer " + token(t, []byte("other"), "customer-1", time.Since(now)+time.Hour),
+			wantStatus:    http.StatusUnauthorized,
+			wantChallenge: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				served   bool
+				customer []string
+				spoofed  string
+			)
+
+			next := http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				served = true
+				customer = Metadata(req.Context(), req).Get(CustomerMetadata)
+				spoofed = req.Header.Get("Grpc-Metadata-" + CustomerMetadata)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			req.Header.Set("Grpc-Metadata-"+CustomerMetadata, "customer-2")
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+
+			rec := httptest.NewRecorder()
+			Handler(next, secret, routes...).ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, tt.wantStatus, rec.Body)
+			}
+
+			if challenged := rec.Header().Get("WWW-Authenticate") != ""; challenged != tt.wantChallenge {
+				t.Errorf("WWW-Authenticate = %q, want a challenge %t", rec.Header().Get("WWW-Authenticate"), tt.wantChallenge)
+			}
+
+			if served != (tt.wantStatus == http.StatusOK) {
+				t.Fatalf("served = %t, want it served only when allowed", served)
+			}
+
+			if !served {
+				return
+			}
+
+			if spoofed != "" {
+				t.Errorf("%s header = %q, want it stripped", "Grpc-Metadata-"+CustomerMetadata, spoofed)
+			}
+
+			if len(customer) != 1 || customer[0] != tt.wantCustomer {
+				t.Errorf("%s metadata = %q, want %q", CustomerMetadata, customer, tt.wantCustomer)
+			}
+		})
+	}
+}
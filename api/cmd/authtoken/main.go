@@ -0,0 +1,33 @@
+// Command authtoken issues bearer tokens for customers, for trying out the routes of the gateway that
+// need one. Anything holding the gateway's secret can issue them the same way.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"time"
+
+	"git.neds.sh/matty/entain/api/auth"
+)
+
+var (
+	secret     = flag.String("secret", "", "secret the gateway verifies tokens with")
+	customerID = flag.String("customer", "", "ID of the customer the token is for")
+	ttl        = flag.Duration("ttl", time.Hour, "how long the token is valid for")
+)
+
+func main() {
+	flag.Parse()
+
+	if *secret == "" || *customerID == "" {
+		log.Fatalf("both -secret and -customer are required\n")
+	}
+
+	token, err := auth.Sign([]byte(*secret), auth.Claims{Subject: *customerID, Expiry: time.Now().Add(*ttl).Unix()})
+	if err != nil {
+		log.Fatalf("failed signing token: %s\n", err)
+	}
+
+	fmt.Println(token)
+}
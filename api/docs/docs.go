@@ -0,0 +1,67 @@
+// Package docs serves the published contract of the REST API: the OpenAPI document of each service behind
+// it, and a Swagger UI to browse them.
+package docs
+
+import (
+	_ "embed"
+	"net/http"
+
+	swaggerFiles "github.com/swaggo/files"
+
+	"git.neds.sh/matty/entain/api/proto"
+)
+
+const (
+	// SpecPath is where the OpenAPI document of the racing service is served.
+	SpecPath = "/openapi.json"
+	// BettingSpecPath is where the OpenAPI document of the betting service is served.
+	BettingSpecPath = "/openapi/betting.json"
+	// AccountsSpecPath is where the OpenAPI document of the accounts service is served.
+	AccountsSpecPath = "/openapi/accounts.json"
+	// LimitsSpecPath is where the OpenAPI document of the limits of the accounts service is served.
+	LimitsSpecPath = "/openapi/limits.json"
+	// SportsSpecPath is where the OpenAPI document of the sports service is served.
+	SportsSpecPath = "/openapi/sports.json"
+	// UIPath is where the Swagger UI is served.
+	UIPath = "/docs/"
+)
+
+// index is the Swagger UI page, pointed at our OpenAPI documents rather than the Swagger UI demo.
+//
+//go:embed index.html
+var index []byte
+
+// Handler wraps a handler, serving the OpenAPI document and Swagger UI in front of it.
+func Handler(next http.Handler) http.Handler {
+	assets := http.StripPrefix(UIPath, http.FileServer(swaggerFiles.HTTP))
+
+	mux := http.NewServeMux()
+	mux.Handle("/", next)
+
+	mux.HandleFunc(SpecPath, spec(proto.OpenAPI))
+	mux.HandleFunc(BettingSpecPath, spec(proto.BettingOpenAPI))
+	mux.HandleFunc(AccountsSpecPath, spec(proto.AccountsOpenAPI))
+	mux.HandleFunc(LimitsSpecPath, spec(proto.LimitsOpenAPI))
+	mux.HandleFunc(SportsSpecPath, spec(proto.SportsOpenAPI))
+
+	mux.HandleFunc(UIPath, func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path == UIPath || req.URL.Path == UIPath+"index.html" {
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			_, _ = w.Write(index)
+
+			return
+		}
+
+		assets.ServeHTTP(w, req)
+	})
+
+	return mux
+}
+
+// spec serves an OpenAPI document.
+func spec(document []byte) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(document)
+	}
+}
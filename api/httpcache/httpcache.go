@@ -0,0 +1,147 @@
+// Package httpcache lets browsers and CDNs cache the read-only routes of the gateway.
+//
+// Responses to those routes are given a strong ETag computed over the response body, along with the
+// route's Cache-Control policy. Conditional requests whose If-None-Match or If-Modified-Since show the
+// client already has the current response are answered with 304 Not Modified and no body.
+package httpcache
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Route is a read-only route whose responses may be cached.
+type Route struct {
+	// Path is the exact request path, or a prefix of it when ending in *.
+	Path string
+	// CacheControl is sent as the Cache-Control header of successful responses.
+	CacheControl string
+}
+
+func (r Route) matches(path string) bool {
+	if strings.HasSuffix(r.Path, "*") {
+		return strings.HasPrefix(path, strings.TrimSuffix(r.Path, "*"))
+	}
+
+	return path == r.Path
+}
+
+// Handler wraps a handler so that GET requests to the given routes are cacheable.
+func Handler(next http.Handler, routes ...Route) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		route, ok := match(routes, req)
+		if !ok {
+			next.ServeHTTP(w, req)
+			return
+		}
+
+		buf := &bufferedWriter{header: w.Header(), status: http.StatusOK}
+		next.ServeHTTP(buf, req)
+
+		// Only complete, successful responses are worth caching.
+		if buf.status != http.StatusOK {
+			w.WriteHeader(buf.status)
+			_, _ = w.Write(buf.body.Bytes())
+
+			return
+		}
+
+		etag := ETag(buf.body.Bytes())
+		w.Header().Set("ETag", etag)
+		w.Header().Set("Cache-Control", route.CacheControl)
+
+		if notModified(req, etag, w.Header().Get("Last-Modified")) {
+			// A 304 has no body, so must not describe one.
+			w.Header().Del("Content-Length")
+			w.Header().Del("Content-Type")
+			w.WriteHeader(http.StatusNotModified)
+
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(buf.body.Bytes())
+	})
+}
+
+// ETag returns a strong entity tag for the given response body.
+func ETag(body []byte) string {
+	sum := sha256.Sum256(body)
+	return `"` + base64.RawURLEncoding.EncodeToString(sum[:]) + `"`
+}
+
+func match(routes []Route, req *http.Request) (Route, bool) {
+	if req.Method != http.MethodGet {
+		return Route{}, false
+	}
+
+	for _, route := range routes {
+		if route.matches(req.URL.Path) {
+			return route, true
+		}
+	}
+
+	return Route{}, false
+}
+
+// notModified evaluates the request's preconditions as per RFC 7232, where If-None-Match takes
+// precedence over If-Modified-Since.
+func notModified(req *http.Request, etag, lastModified string) bool {
+	if inm := req.Header.Get("If-None-Match"); inm != "" {
+		for _, candidate := range strings.Split(inm, ",") {
+			candidate = strings.TrimSpace(candidate)
+
+			// If-None-Match uses the weak comparison, so a weak validator still matches.
+			if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	ims := req.Header.Get("If-Modified-Since")
+	if ims == "" || lastModified == "" {
+		return false
+	}
+
+	since, err := http.ParseTime(ims)
+	if err != nil {
+		return false
+	}
+
+	modified, err := http.ParseTime(lastModified)
+	if err != nil {
+		return false
+	}
+
+	return !modified.After(since)
+}
+
+// LastModified formats a time for use in the Last-Modified header.
+func LastModified(t time.Time) string {
+	return t.UTC().Format(http.TimeFormat)
+}
+
+// bufferedWriter holds on to a response, so that its ETag can be computed before it is sent.
+type bufferedWriter struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func (w *bufferedWriter) Header() http.Header {
+	return w.header
+}
+
+func (w *bufferedWriter) WriteHeader(status int) {
+	w.status = status
+}
+
+func (w *bufferedWriter) Write(b []byte) (int, error) {
+	return w.body.Write(b)
+}
@@ -0,0 +1,191 @@
+package httpcache
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// modified is when the responses of the tests were last modified.
+var modified = time.Date(2026, 11, 3, 14, 0, 0, 0, time.UTC)
+
+// body is the body of the responses of the tests.
+const body = `{"races":[]}`
+
+// respond is a handler answering with the body, last modified at modified, or with 404 Not Found for
+// /v1/missing.
+var respond = http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+	if req.URL.Path == "/v1/missing" {
+		http.NotFound(w, req)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Last-Modified", LastModified(modified))
+	_, _ = w.Write([]byte(body))
+})
+
+func TestHandler(t *testing.T) {
+	etag := ETag([]byte(body))
+	routes := []Route{
+		{Path: "/v1/list-races", CacheControl: "public, max-age=5"},
+		{Path: "/v1/races/*", CacheControl: "public, max-age=30"},
+		{Path: "/v1/missing", CacheControl: "public, max-age=30"},
+	}
+
+	tests := []struct {
+		name             string
+		method           string
+		path             string
+		header           http.Header
+		wantStatus       int
+		wantCacheControl string
+	}{
+		{
+			name:             "unconditional",
+			path:             "/v1/list-races",
+			wantStatus:       http.StatusOK,
+			wantCacheControl: "public, max-age=5",
+		},
+		{
+			name:             "prefix route",
+			path:             "/v1/races/1",
+			wantStatus:       http.StatusOK,
+			wantCacheControl: "public, max-age=30",
+		},
+		{
+			name:             "matching If-None-Match",
+			path:             "/v1/list-races",
+			header:           http.Header{"If-None-Match": {etag}},
+			wantStatus:       http.StatusNotModified,
+			wantCacheControl: "public, max-age=5",
+		},
+		{
+			name:             "weak If-None-Match",
+			path:             "/v1/list-races",
+			header:           http.Header{"If-None-Match": {"W/" + etag}},
+			wantStatus:       http.StatusNotModified,
+			wantCacheControl: "public, max-age=5",
+		},
+		{
+			name:             "If-None-Match listing the ETag",
+			path:             "/v1/list-races",
+			header:           http.Header{"If-None-Match": {`"stale", ` + etag}},
+			wantStatus:       http.StatusNotModified,
+			wantCacheControl: "public, max-age=5",
+		},
+		{
+			name:             "If-None-Match of any",
+			path:             "/v1/list-races",
+			header:           http.Header{"If-None-Match": {"*"}},
+			wantStatus:       http.StatusNotModified,
+			wantCacheControl: "public, max-age=5",
+		},
+		{
+			name:             "stale If-None-Match",
+			path:             "/v1/list-races",
+			header:           http.Header{"If-None-Match": {`"stale"`}},
+			wantStatus:       http.StatusOK,
+			wantCacheControl: "public, max-age=5",
+		},
+		{
+			name:             "stale If-None-Match taking precedence",
+			path:             "/v1/list-races",
+			header:           http.Header{"If-None-Match": {`"stale"`}, "If-Modified-Since": {LastModified(modified)}},
+			wantStatus:       http.StatusOK,
+			wantCacheControl: "public, max-age=5",
+		},
+		{
+			name:             "If-Modified-Since the last modification",
+			path:             "/v1/list-races",
+			header:           http.Header{"If-Modified-Since": {LastModified(modified)}},
+			wantStatus:       http.StatusNotModified,
+			wantCacheControl: "public, max-age=5",
+		},
+		{
+			name:             "If-Modified-Since after the last modification",
+			path:             "/v1/list-races",
+			header:           http.Header{"If-Modified-Since": {LastModified(modified.Add(time.Hour))}},
+			wantStatus:       http.StatusNotModified,
+			wantCacheControl: "public, max-age=5",
+		},
+		{
+			name:             "If-Modified-Since before the last modification",
+			path:             "/v1/list-races",
+			header:           http.Header{"If-Modified-Since": {LastModified(modified.Add(-time.Second))}},
+			wantStatus:       http.StatusOK,
+			wantCacheControl: "public, max-age=5",
+		},
+		{
+			name:             "malformed If-Modified-Since",
+			path:             "/v1/list-races",
+			header:           http.Header{"If-Modified-Since": {"yesterday"}},
+			wantStatus:       http.StatusOK,
+			wantCacheControl: "public, max-age=5",
+		},
+		{
+			name:       "not a GET",
+			method:     http.MethodPost,
+			path:       "/v1/list-races",
+			header:     http.Header{"If-None-Match": {etag}},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "not a cached route",
+			path:       "/v1/bets",
+			header:     http.Header{"If-None-Match": {etag}},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "unsuccessful",
+			path:       "/v1/missing",
+			wantStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			method := tt.method
+			if method == "" {
+				method = http.MethodGet
+			}
+
+			req := httptest.NewRequest(method, tt.path, nil)
+			for name, values := range tt.header {
+				req.Header[name] = values
+			}
+
+			rec := httptest.NewRecorder()
+			Handler(respond, routes...).ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+
+			if got := rec.Header().Get("Cache-Control"); got != tt.wantCacheControl {
+				t.Errorf("Cache-Control = %q, want %q", got, tt.wantCacheControl)
+			}
+
+			wantETag := ""
+			if tt.wantCacheControl != "" {
+				wantETag = etag
+			}
+
+			if got := rec.Header().Get("ETag"); got != wantETag {
+				t.Errorf("ETag = %q, want %q", got, wantETag)
+			}
+
+			switch tt.wantStatus {
+			case http.StatusNotModified:
+				if rec.Body.Len() != 0 || rec.Header().Get("Content-Type") != "" {
+					t.Errorf("304 with body %q and Content-Type %q, want neither", rec.Body, rec.Header().Get("Content-Type"))
+				}
+			case http.StatusOK:
+				if rec.Body.String() != body {
+					t.Errorf("body = %q, want %q", rec.Body, body)
+				}
+			}
+		})
+	}
+}
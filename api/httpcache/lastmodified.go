@@ -0,0 +1,69 @@
+package httpcache
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// updateTimeField is the name of the field resources use to record when they last changed.
+const updateTimeField = "update_time"
+
+// SetLastModified is a gateway forward response option, which sets the Last-Modified header of a
+// response to the latest update_time of any resource within it. Streamed responses, which the gateway
+// passes no message for, have none.
+func SetLastModified(_ context.Context, w http.ResponseWriter, resp proto.Message) error {
+	if resp == nil {
+		return nil
+	}
+
+	if latest := latestUpdate(resp.ProtoReflect()); !latest.IsZero() {
+		w.Header().Set("Last-Modified", LastModified(latest))
+	}
+
+	return nil
+}
+
+// latestUpdate walks a message, returning the latest update_time found anywhere within it.
+func latestUpdate(msg protoreflect.Message) time.Time {
+	var latest time.Time
+
+	later := func(t time.Time) {
+		if t.After(latest) {
+			latest = t
+		}
+	}
+
+	msg.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		if fd.Message() == nil || fd.IsMap() {
+			return true
+		}
+
+		if fd.Name() == updateTimeField && !fd.IsList() {
+			if ts, ok := v.Message().Interface().(*timestamppb.Timestamp); ok {
+				later(ts.AsTime())
+			}
+
+			return true
+		}
+
+		if fd.IsList() {
+			list := v.List()
+			for i := 0; i < list.Len(); i++ {
+				later(latestUpdate(list.Get(i).Message()))
+			}
+
+			return true
+		}
+
+		later(latestUpdate(v.Message()))
+
+		return true
+	})
+
+	return latest
+}
@@ -0,0 +1,57 @@
+package httpcache
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"google.golang.org/protobuf/proto"
+
+	"git.neds.sh/matty/entain/api/proto/racing"
+)
+
+func TestSetLastModified(t *testing.T) {
+	// updated returns a race last updated the given number of minutes after modified.
+	updated := func(minutes int) *racing.Race {
+		ts, _ := ptypes.TimestampProto(modified.Add(time.Duration(minutes) * time.Minute))
+		return &racing.Race{UpdateTime: ts}
+	}
+
+	priced, _ := ptypes.TimestampProto(modified.Add(time.Hour))
+
+	tests := []struct {
+		name string
+		resp proto.Message
+		want string
+	}{
+		{name: "streamed", resp: nil},
+		{name: "one race", resp: updated(0), want: LastModified(modified)},
+		{
+			name: "the latest of a list",
+			resp: &racing.ListRacesResponse{Races: []*racing.Race{updated(5), updated(10), updated(0)}},
+			want: LastModified(modified.Add(10 * time.Minute)),
+		},
+		{
+			name: "nested within a list",
+			resp: &racing.Market{Runners: []*racing.RunnerPrices{{RunnerNumber: 1}, {RunnerNumber: 2, UpdateTime: priced}}},
+			want: LastModified(modified.Add(time.Hour)),
+		},
+		{name: "never updated", resp: &racing.ListRacesResponse{Races: []*racing.Race{{Id: 1}}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+
+			if err := SetLastModified(context.Background(), rec, tt.resp); err != nil {
+				t.Fatalf("SetLastModified() = %v", err)
+			}
+
+			if got := rec.Header().Get("Last-Modified"); got != tt.want {
+				t.Errorf("Last-Modified = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
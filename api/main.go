@@ -6,14 +6,43 @@ import (
 	"log"
 	"net/http"
 
+	"git.neds.sh/matty/entain/api/apierror"
+	"git.neds.sh/matty/entain/api/auth"
+	"git.neds.sh/matty/entain/api/docs"
+	"git.neds.sh/matty/entain/api/httpcache"
+	"git.neds.sh/matty/entain/api/proto/accounts"
+	"git.neds.sh/matty/entain/api/proto/betting"
+	"git.neds.sh/matty/entain/api/proto/limits"
 	"git.neds.sh/matty/entain/api/proto/racing"
+	"git.neds.sh/matty/entain/api/proto/sports"
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	"google.golang.org/grpc"
 )
 
+// cacheableRoutes are the read-only routes that browsers and CDNs may cache, along with for how long.
+var cacheableRoutes = []httpcache.Route{
+	{Path: "/v1/races", CacheControl: "public, max-age=10"},
+	{Path: "/v1/races:search", CacheControl: "public, max-age=10"},
+	{Path: "/v1/races/*", CacheControl: "public, max-age=10"},
+}
+
+// customerRoutes are the routes acting on behalf of the customer named in their paths, which only that
+// customer may use, and those any customer may use on their own behalf.
+var customerRoutes = []auth.Route{
+	{Prefix: "/v1/accounts/"},
+	{Prefix: "/v1/bets", AnyCustomer: true},
+	{Prefix: "/v1/multis", AnyCustomer: true},
+}
+
 var (
 	apiEndpoint  = flag.String("api-endpoint", "localhost:8000", "API endpoint")
 	grpcEndpoint = flag.String("grpc-endpoint", "localhost:9000", "gRPC server endpoint")
+
+	bettingEndpoint  = flag.String("betting-endpoint", "localhost:9100", "gRPC endpoint of the betting service")
+	accountsEndpoint = flag.String("accounts-endpoint", "localhost:9200", "gRPC endpoint of the accounts service, which also serves customers' limits")
+	sportsEndpoint   = flag.String("sports-endpoint", "localhost:9300", "gRPC endpoint of the sports service")
+
+	authSecret = flag.String("auth-secret", "", "secret that customers' bearer tokens are signed with, without which no customer may use their routes")
 )
 
 func main() {
@@ -29,7 +58,11 @@ func run() error {
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
 
-	mux := runtime.NewServeMux()
+	mux := runtime.NewServeMux(
+		runtime.WithForwardResponseOption(httpcache.SetLastModified),
+		runtime.WithErrorHandler(apierror.Handler),
+		runtime.WithMetadata(auth.Metadata),
+	)
 	if err := racing.RegisterRacingHandlerFromEndpoint(
 		ctx,
 		mux,
@@ -39,7 +72,47 @@ func run() error {
 		return err
 	}
 
+	if err := betting.RegisterBettingHandlerFromEndpoint(
+		ctx,
+		mux,
+		*bettingEndpoint,
+		[]grpc.DialOption{grpc.WithInsecure()},
+	); err != nil {
+		return err
+	}
+
+	if err := accounts.RegisterAccountsHandlerFromEndpoint(
+		ctx,
+		mux,
+		*accountsEndpoint,
+		[]grpc.DialOption{grpc.WithInsecure()},
+	); err != nil {
+		return err
+	}
+
+	if err := limits.RegisterLimitsHandlerFromEndpoint(
+		ctx,
+		mux,
+		*accountsEndpoint,
+		[]grpc.DialOption{grpc.WithInsecure()},
+	); err != nil {
+		return err
+	}
+
+	if err := sports.RegisterSportsHandlerFromEndpoint(
+		ctx,
+		mux,
+		*sportsEndpoint,
+		[]grpc.DialOption{grpc.WithInsecure()},
+	); err != nil {
+		return err
+	}
+
 	log.Printf("API server listening on: %s\n", *apiEndpoint)
 
-	return http.ListenAndServe(*apiEndpoint, mux)
+	handler := httpcache.Handler(mux, cacheableRoutes...)
+	handler = auth.Handler(handler, []byte(*authSecret), customerRoutes...)
+	handler = docs.Handler(handler)
+
+	return http.ListenAndServe(*apiEndpoint, handler)
 }
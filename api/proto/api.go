@@ -1,3 +1,37 @@
 package proto
 
-//go:generate protoc -I . --go_out . --go_opt paths=source_relative --go-grpc_out . --go-grpc_opt paths=source_relative --grpc-gateway_out . --grpc-gateway_opt paths=source_relative racing/racing.proto
+import _ "embed"
+
+// The protos are shared with the racing, betting, accounts and sports services, and live in the proto directory at the root of the repo.
+// They declare no go_package, so each module maps them onto its own packages here.
+//go:generate protoc -I ../../proto --go_out . --go_opt paths=source_relative --go_opt Mracing/racing.proto=git.neds.sh/matty/entain/api/proto/racing --go_opt Mvalidate/validate.proto=git.neds.sh/matty/entain/api/proto/validate --go-grpc_out . --go-grpc_opt paths=source_relative --go-grpc_opt Mracing/racing.proto=git.neds.sh/matty/entain/api/proto/racing --go-grpc_opt Mvalidate/validate.proto=git.neds.sh/matty/entain/api/proto/validate --grpc-gateway_out . --grpc-gateway_opt paths=source_relative --grpc-gateway_opt Mracing/racing.proto=git.neds.sh/matty/entain/api/proto/racing --grpc-gateway_opt Mvalidate/validate.proto=git.neds.sh/matty/entain/api/proto/validate racing/racing.proto validate/validate.proto
+//go:generate protoc -I ../../proto --go_out . --go_opt paths=source_relative --go_opt Mbetting/betting.proto=git.neds.sh/matty/entain/api/proto/betting --go_opt Mvalidate/validate.proto=git.neds.sh/matty/entain/api/proto/validate --go-grpc_out . --go-grpc_opt paths=source_relative --go-grpc_opt Mbetting/betting.proto=git.neds.sh/matty/entain/api/proto/betting --go-grpc_opt Mvalidate/validate.proto=git.neds.sh/matty/entain/api/proto/validate --grpc-gateway_out . --grpc-gateway_opt paths=source_relative --grpc-gateway_opt Mbetting/betting.proto=git.neds.sh/matty/entain/api/proto/betting --grpc-gateway_opt Mvalidate/validate.proto=git.neds.sh/matty/entain/api/proto/validate betting/betting.proto
+//go:generate protoc -I ../../proto --go_out . --go_opt paths=source_relative --go_opt Maccounts/accounts.proto=git.neds.sh/matty/entain/api/proto/accounts --go_opt Mvalidate/validate.proto=git.neds.sh/matty/entain/api/proto/validate --go-grpc_out . --go-grpc_opt paths=source_relative --go-grpc_opt Maccounts/accounts.proto=git.neds.sh/matty/entain/api/proto/accounts --go-grpc_opt Mvalidate/validate.proto=git.neds.sh/matty/entain/api/proto/validate --grpc-gateway_out . --grpc-gateway_opt paths=source_relative --grpc-gateway_opt Maccounts/accounts.proto=git.neds.sh/matty/entain/api/proto/accounts --grpc-gateway_opt Mvalidate/validate.proto=git.neds.sh/matty/entain/api/proto/validate accounts/accounts.proto
+//go:generate protoc -I ../../proto --go_out . --go_opt paths=source_relative --go_opt Mlimits/limits.proto=git.neds.sh/matty/entain/api/proto/limits --go_opt Mvalidate/validate.proto=git.neds.sh/matty/entain/api/proto/validate --go-grpc_out . --go-grpc_opt paths=source_relative --go-grpc_opt Mlimits/limits.proto=git.neds.sh/matty/entain/api/proto/limits --go-grpc_opt Mvalidate/validate.proto=git.neds.sh/matty/entain/api/proto/validate --grpc-gateway_out . --grpc-gateway_opt paths=source_relative --grpc-gateway_opt Mlimits/limits.proto=git.neds.sh/matty/entain/api/proto/limits --grpc-gateway_opt Mvalidate/validate.proto=git.neds.sh/matty/entain/api/proto/validate limits/limits.proto
+//go:generate protoc -I ../../proto --go_out . --go_opt paths=source_relative --go_opt Msports/sports.proto=git.neds.sh/matty/entain/api/proto/sports --go_opt Mvalidate/validate.proto=git.neds.sh/matty/entain/api/proto/validate --go-grpc_out . --go-grpc_opt paths=source_relative --go-grpc_opt Msports/sports.proto=git.neds.sh/matty/entain/api/proto/sports --go-grpc_opt Mvalidate/validate.proto=git.neds.sh/matty/entain/api/proto/validate --grpc-gateway_out . --grpc-gateway_opt paths=source_relative --grpc-gateway_opt Msports/sports.proto=git.neds.sh/matty/entain/api/proto/sports --grpc-gateway_opt Mvalidate/validate.proto=git.neds.sh/matty/entain/api/proto/validate sports/sports.proto
+//go:generate protoc -I ../../proto --openapiv2_out . --openapiv2_opt json_names_for_fields=true racing/racing.proto betting/betting.proto accounts/accounts.proto limits/limits.proto sports/sports.proto
+
+// OpenAPI is the OpenAPI v2 document describing the REST API of the racing service, as generated from its protos.
+//
+//go:embed racing/racing.swagger.json
+var OpenAPI []byte
+
+// BettingOpenAPI is the OpenAPI v2 document describing the REST API of the betting service.
+//
+//go:embed betting/betting.swagger.json
+var BettingOpenAPI []byte
+
+// AccountsOpenAPI is the OpenAPI v2 document describing the REST API of the accounts service.
+//
+//go:embed accounts/accounts.swagger.json
+var AccountsOpenAPI []byte
+
+// LimitsOpenAPI is the OpenAPI v2 document describing the REST API of the limits of the accounts service.
+//
+//go:embed limits/limits.swagger.json
+var LimitsOpenAPI []byte
+
+// SportsOpenAPI is the OpenAPI v2 document describing the REST API of the sports service.
+//
+//go:embed sports/sports.swagger.json
+var SportsOpenAPI []byte
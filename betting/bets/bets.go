@@ -0,0 +1,206 @@
+// Package bets checks fixed-odds bets against the race and market they are placed on, and strikes them at
+// the runner's current prices.
+package bets
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+
+	"git.neds.sh/matty/entain/betting/proto/betting"
+	"git.neds.sh/matty/entain/common/errs"
+	"git.neds.sh/matty/entain/racing/proto/racing"
+	"git.neds.sh/matty/entain/racing/suspensions"
+)
+
+// DefaultTolerance is how far, relatively, a price may shorten from the one the customer was offered
+// before bets asking for it are rejected, unless the service is given another.
+const DefaultTolerance = 0.05
+
+// Check returns an error explaining why a bet cannot be placed, if it cannot: an *errs.InvalidArgument if
+// the bet itself is malformed or names a runner not in the race, an *errs.FailedPrecondition if the race
+// is not open for betting, trading on it is suspended, or the runner is scratched or unpriced, or an
+// *errs.Conflict if the runner's price has shortened by more than the tolerance from the price asked for.
+// The race must have its runners, scratchings and suspensions, and the market its runners' current prices
+// as decimal odds.
+func Check(in *betting.PlaceBetRequest, race *racing.Race, market *racing.Market, tolerance float64) error {
+	if err := checkPrices(in); err != nil {
+		return err
+	}
+
+	prices, err := openRunner(race, market, in.RunnerNumber, "runner_number")
+	if err != nil {
+		return err
+	}
+
+	for _, odds := range wanted(in) {
+		current := odds.current(prices)
+		if current == 0 {
+			return &errs.FailedPrecondition{
+				Resource: "race",
+				ID:       strconv.FormatInt(race.Id, 10),
+				Reason:   fmt.Sprintf("runner %d has no %s price", in.RunnerNumber, odds.name),
+			}
+		}
+
+		if shortened(odds.asked, current, tolerance) {
+			return &errs.Conflict{
+				Resource: "price",
+				ID:       fmt.Sprintf("%d/%d", in.RaceId, in.RunnerNumber),
+				Reason:   fmt.Sprintf("the %s price has shortened from %.2f to %.2f", odds.name, odds.asked, current),
+			}
+		}
+	}
+
+	return nil
+}
+
+// New returns the bet asked for, struck at the runner's current prices from the market, which Check must
+// have accepted. The ID and struck time are left for the caller to set.
+func New(in *betting.PlaceBetRequest, market *racing.Market) *betting.Bet {
+	bet := &betting.Bet{
+		CustomerId:     in.CustomerId,
+		IdempotencyKey: in.IdempotencyKey,
+		RaceId:         in.RaceId,
+		RunnerNumber:   in.RunnerNumber,
+		Type:           in.Type,
+		Stake:          in.Stake,
+		TotalStake:     in.Stake,
+		Status:         betting.Bet_ACCEPTED,
+	}
+
+	if in.Type == betting.Bet_EACH_WAY {
+		bet.TotalStake = 2 * in.Stake
+	}
+
+	prices := runnerPrices(market, in.RunnerNumber)
+
+	for _, odds := range wanted(in) {
+		*odds.struck(bet) = odds.current(prices)
+	}
+
+	return bet
+}
+
+// Matches reports whether a bet already placed with an idempotency key is the one now asked for with it,
+// so that placing it again returns it rather than being rejected. The prices asked for are not compared,
+// as a retry may ask for the prices the customer has since been shown.
+func Matches(bet *betting.Bet, in *betting.PlaceBetRequest) bool {
+	return bet.CustomerId == in.CustomerId &&
+		bet.RaceId == in.RaceId &&
+		bet.RunnerNumber == in.RunnerNumber &&
+		bet.Type == in.Type &&
+		bet.Stake == in.Stake
+}
+
+// HoldReference returns the reference of the hold on a customer's funds for the stake of the bet a request
+// places, which is the same for every request with its idempotency key.
+func HoldReference(in *betting.PlaceBetRequest) string {
+	return "bets/" + in.IdempotencyKey
+}
+
+// odds is a price a bet is struck at: the win or the place.
+type odds struct {
+	name    string
+	asked   float64
+	current func(prices *racing.RunnerPrices) float64
+	struck  func(bet *betting.Bet) *float64
+}
+
+// wanted returns the prices a bet is struck at, along with those asked for.
+func wanted(in *betting.PlaceBetRequest) []odds {
+	win := odds{
+		name:    "win",
+		asked:   in.WinPrice,
+		current: func(prices *racing.RunnerPrices) float64 { return prices.Win.GetDecimal() },
+		struck:  func(bet *betting.Bet) *float64 { return &bet.WinPrice },
+	}
+
+	place := odds{
+		name:    "place",
+		asked:   in.PlacePrice,
+		current: func(prices *racing.RunnerPrices) float64 { return prices.Place.GetDecimal() },
+		struck:  func(bet *betting.Bet) *float64 { return &bet.PlacePrice },
+	}
+
+	switch in.Type {
+	case betting.Bet_WIN:
+		return []odds{win}
+	case betting.Bet_PLACE:
+		return []odds{place}
+	case betting.Bet_EACH_WAY:
+		return []odds{win, place}
+	}
+
+	return nil
+}
+
+// checkPrices returns an *errs.InvalidArgument listing the problems with the type of a bet and the prices
+// it asks for, if there are any.
+func checkPrices(in *betting.PlaceBetRequest) error {
+	var violations []errs.FieldViolation
+
+	violate := func(field, format string, args ...interface{}) {
+		violations = append(violations, errs.FieldViolation{Field: field, Description: fmt.Sprintf(format, args...)})
+	}
+
+	switch in.Type {
+	case betting.Bet_WIN, betting.Bet_PLACE, betting.Bet_EACH_WAY:
+	default:
+		violate("type", "must be WIN, PLACE or EACH_WAY")
+	}
+
+	for _, odds := range wanted(in) {
+		if odds.asked <= 0 || math.IsNaN(odds.asked) {
+			violate(odds.name+"_price", "must be given for %s bets", in.Type)
+		}
+	}
+
+	if len(violations) > 0 {
+		return &errs.InvalidArgument{Violations: violations}
+	}
+
+	return nil
+}
+
+// openRunner returns the prices of a runner in a race, or an error if it cannot be bet on: an
+// *errs.FailedPrecondition if the race is not open for betting, trading on it is suspended or the runner
+// is scratched, or an *errs.InvalidArgument for the given field if the runner is not in the race.
+func openRunner(race *racing.Race, market *racing.Market, runnerNumber int64, field string) (*racing.RunnerPrices, error) {
+	id := strconv.FormatInt(race.Id, 10)
+
+	if race.State != racing.Race_OPEN {
+		return nil, &errs.FailedPrecondition{Resource: "race", ID: id, Reason: fmt.Sprintf("%s races are not taking bets", race.State)}
+	}
+
+	if err := suspensions.CheckRace(race); err != nil {
+		return nil, err
+	}
+
+	prices := runnerPrices(market, runnerNumber)
+	if prices == nil {
+		return nil, errs.Invalid(field, "%d is not a runner in the race", runnerNumber)
+	}
+
+	if prices.Scratched {
+		return nil, &errs.FailedPrecondition{Resource: "race", ID: id, Reason: fmt.Sprintf("runner %d is scratched", runnerNumber)}
+	}
+
+	return prices, nil
+}
+
+// shortened reports whether a price has shortened by more than the tolerance from the one asked for.
+func shortened(asked, current, tolerance float64) bool {
+	return current < asked*(1-tolerance)
+}
+
+// runnerPrices returns the prices of a runner in a market, or nil if it is not in the race.
+func runnerPrices(market *racing.Market, number int64) *racing.RunnerPrices {
+	for _, runner := range market.Runners {
+		if runner.RunnerNumber == number {
+			return runner
+		}
+	}
+
+	return nil
+}
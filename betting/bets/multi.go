@@ -0,0 +1,186 @@
+package bets
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+
+	"git.neds.sh/matty/entain/betting/proto/betting"
+	"git.neds.sh/matty/entain/betting/proto/sports"
+	"git.neds.sh/matty/entain/common/errs"
+	"git.neds.sh/matty/entain/racing/proto/racing"
+)
+
+// CheckLegs returns an *errs.InvalidArgument listing the problems with the legs of a multi, if there are
+// any: each must name either a race and runner or an event and outcome, and give the price it asks for,
+// and no two may be on the same race or event, whose results would not be independent.
+func CheckLegs(in *betting.PlaceMultiRequest) error {
+	var violations []errs.FieldViolation
+
+	violate := func(field, format string, args ...interface{}) {
+		violations = append(violations, errs.FieldViolation{Field: field, Description: fmt.Sprintf(format, args...)})
+	}
+
+	races := make(map[int64]bool)
+	events := make(map[int64]bool)
+
+	for i, leg := range in.Legs {
+		field := fmt.Sprintf("legs[%d]", i)
+
+		racingLeg := leg.RaceId != 0 || leg.RunnerNumber != 0
+		sportsLeg := leg.EventId != 0 || leg.OutcomeId != 0
+
+		switch {
+		case racingLeg && sportsLeg:
+			violate(field, "must name either a race and runner or an event and outcome, not both")
+		case racingLeg && (leg.RaceId == 0 || leg.RunnerNumber == 0):
+			violate(field, "must name both a race and a runner")
+		case sportsLeg && (leg.EventId == 0 || leg.OutcomeId == 0):
+			violate(field, "must name both an event and an outcome")
+		case racingLeg && races[leg.RaceId]:
+			violate(field+".race_id", "%d already has a leg of the multi", leg.RaceId)
+		case sportsLeg && events[leg.EventId]:
+			violate(field+".event_id", "%d already has a leg of the multi", leg.EventId)
+		case !racingLeg && !sportsLeg:
+			violate(field, "must name a race and runner or an event and outcome")
+		}
+
+		races[leg.RaceId] = races[leg.RaceId] || racingLeg
+		events[leg.EventId] = events[leg.EventId] || sportsLeg
+
+		if leg.Price <= 1 || math.IsNaN(leg.Price) || math.IsInf(leg.Price, 0) {
+			violate(field+".price", "must be greater than 1")
+		}
+	}
+
+	if len(violations) > 0 {
+		return &errs.InvalidArgument{Violations: violations}
+	}
+
+	return nil
+}
+
+// RaceLegPrice returns the price the racing leg at the given index of a multi is struck at: its runner's
+// current win price from the market. It returns an error if the leg cannot be placed, as Check does for a
+// win bet on the runner.
+func RaceLegPrice(i int, leg *betting.Selection, race *racing.Race, market *racing.Market, tolerance float64) (float64, error) {
+	prices, err := openRunner(race, market, leg.RunnerNumber, fmt.Sprintf("legs[%d].runner_number", i))
+	if err != nil {
+		return 0, err
+	}
+
+	current := prices.Win.GetDecimal()
+	if current == 0 {
+		return 0, &errs.FailedPrecondition{
+			Resource: "race",
+			ID:       strconv.FormatInt(race.Id, 10),
+			Reason:   fmt.Sprintf("runner %d has no win price", leg.RunnerNumber),
+		}
+	}
+
+	if shortened(leg.Price, current, tolerance) {
+		return 0, &errs.Conflict{
+			Resource: "price",
+			ID:       fmt.Sprintf("%d/%d", leg.RaceId, leg.RunnerNumber),
+			Reason:   fmt.Sprintf("the win price of leg %d has shortened from %.2f to %.2f", i+1, leg.Price, current),
+		}
+	}
+
+	return current, nil
+}
+
+// EventLegPrice returns the price the sports leg at the given index of a multi is struck at: its outcome's
+// current price. It returns an *errs.FailedPrecondition error if the event is not open or has started by
+// the given time, an *errs.InvalidArgument error if the outcome is not one of the event's, or an
+// *errs.Conflict error if its price has shortened by more than the tolerance from the price asked for.
+func EventLegPrice(i int, leg *betting.Selection, event *sports.Event, now time.Time, tolerance float64) (float64, error) {
+	id := strconv.FormatInt(event.Id, 10)
+
+	if event.Status != sports.Event_OPEN {
+		return 0, &errs.FailedPrecondition{Resource: "event", ID: id, Reason: fmt.Sprintf("%s events are not taking bets", event.Status)}
+	}
+
+	if start, err := ptypes.Timestamp(event.AdvertisedStartTime); err != nil || !now.Before(start) {
+		return 0, &errs.FailedPrecondition{Resource: "event", ID: id, Reason: "the event has started"}
+	}
+
+	var outcome *sports.Outcome
+
+	for _, o := range event.Outcomes {
+		if o.Id == leg.OutcomeId {
+			outcome = o
+		}
+	}
+
+	if outcome == nil {
+		return 0, errs.Invalid(fmt.Sprintf("legs[%d].outcome_id", i), "%d is not an outcome of the event", leg.OutcomeId)
+	}
+
+	if shortened(leg.Price, outcome.Price, tolerance) {
+		return 0, &errs.Conflict{
+			Resource: "price",
+			ID:       fmt.Sprintf("%d/%d", leg.EventId, leg.OutcomeId),
+			Reason:   fmt.Sprintf("the price of leg %d has shortened from %.2f to %.2f", i+1, leg.Price, outcome.Price),
+		}
+	}
+
+	return outcome.Price, nil
+}
+
+// NewMulti returns the multi asked for, with each leg struck at the given price, which CheckLegs and the
+// price checks of its legs must have accepted. The ID and struck time are left for the caller to set.
+func NewMulti(in *betting.PlaceMultiRequest, prices []float64) *betting.Multi {
+	multi := &betting.Multi{
+		CustomerId:     in.CustomerId,
+		IdempotencyKey: in.IdempotencyKey,
+		Stake:          in.Stake,
+		Price:          1,
+		Status:         betting.Bet_ACCEPTED,
+	}
+
+	for i, leg := range in.Legs {
+		multi.Legs = append(multi.Legs, &betting.Leg{
+			Number:       int64(i + 1),
+			RaceId:       leg.RaceId,
+			RunnerNumber: leg.RunnerNumber,
+			EventId:      leg.EventId,
+			OutcomeId:    leg.OutcomeId,
+			Price:        prices[i],
+			Status:       betting.Leg_PENDING,
+		})
+
+		multi.Price *= prices[i]
+	}
+
+	// The combined price is rounded only to shed float error, as what the multi pays is worked out from
+	// the prices its legs are settled at.
+	multi.Price = math.Round(multi.Price*1e6) / 1e6
+
+	return multi
+}
+
+// MultiMatches reports whether a multi already placed with an idempotency key is the one now asked for
+// with it. As with single bets, the prices asked for are not compared.
+func MultiMatches(multi *betting.Multi, in *betting.PlaceMultiRequest) bool {
+	if multi.CustomerId != in.CustomerId || multi.Stake != in.Stake || len(multi.Legs) != len(in.Legs) {
+		return false
+	}
+
+	for i, leg := range multi.Legs {
+		asked := in.Legs[i]
+		if leg.RaceId != asked.RaceId || leg.RunnerNumber != asked.RunnerNumber || leg.EventId != asked.EventId || leg.OutcomeId != asked.OutcomeId {
+			return false
+		}
+	}
+
+	return true
+}
+
+// MultiHoldReference returns the reference of the hold on a customer's funds for the stake of the multi a
+// request places, which is the same for every request with its idempotency key.
+func MultiHoldReference(in *betting.PlaceMultiRequest) string {
+	return "multis/" + in.IdempotencyKey
+}
@@ -0,0 +1,180 @@
+// Package cashout works out what fixed-odds bets can be cashed out for before their race jumps, and checks
+// that a quote still holds when it is executed.
+//
+// A bet is worth what its prices would be struck at now to return the same: its stake at the price it was
+// struck at, over the runner's current price. The price it was struck at is first reduced by the
+// deductions of runners scratched since, as its winnings would be on settlement. An each-way bet is worth
+// its win and place together. The house keeps a margin of that value.
+package cashout
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+
+	"git.neds.sh/matty/entain/betting/proto/betting"
+	"git.neds.sh/matty/entain/common/errs"
+	"git.neds.sh/matty/entain/racing/proto/racing"
+	"git.neds.sh/matty/entain/racing/scratchings"
+	"git.neds.sh/matty/entain/racing/suspensions"
+)
+
+const (
+	// DefaultMargin is the share of a bet's value the house keeps when it is cashed out, unless the service
+	// is given another.
+	DefaultMargin = 0.05
+
+	// DefaultTTL is how long a quote can be executed for, unless the service is given another.
+	DefaultTTL = 15 * time.Second
+
+	// DefaultTolerance is how far, relatively, a bet's value may fall from its quote before executing the
+	// quote is rejected, unless the service is given another.
+	DefaultTolerance = 0.02
+)
+
+// Terms are the terms bets are cashed out on.
+type Terms struct {
+	// Margin is the share of a bet's value the house keeps.
+	Margin float64
+	// TTL is how long a quote can be executed for.
+	TTL time.Duration
+	// Tolerance is how far, relatively, a bet's value may fall from its quote before executing it is rejected.
+	Tolerance float64
+}
+
+// Quote returns a quote of what a bet can be cashed out for, made at the given time on its race and the
+// race's market. It returns an *errs.FailedPrecondition error if the bet cannot be cashed out: if it is
+// settled, its race is not open, as once it has jumped or while it is suspended, trading on the race is
+// suspended, or its runner is scratched or unpriced. The race must have its runners, scratchings and
+// suspensions, and the market its runners' current prices as decimal odds. The quote's ID is left for the
+// caller to set.
+func Quote(bet *betting.Bet, race *racing.Race, market *racing.Market, terms Terms, now time.Time) (*betting.CashOutQuote, error) {
+	id := strconv.FormatInt(bet.Id, 10)
+
+	if bet.Status != betting.Bet_ACCEPTED {
+		return nil, &errs.FailedPrecondition{Resource: "bet", ID: id, Reason: fmt.Sprintf("%s bets cannot be cashed out", bet.Status)}
+	}
+
+	raceID := strconv.FormatInt(race.Id, 10)
+
+	if race.State != racing.Race_OPEN {
+		return nil, &errs.FailedPrecondition{Resource: "race", ID: raceID, Reason: fmt.Sprintf("bets on %s races cannot be cashed out", race.State)}
+	}
+
+	if err := suspensions.CheckRace(race); err != nil {
+		return nil, err
+	}
+
+	prices := runnerPrices(market, bet.RunnerNumber)
+	if prices == nil || prices.Scratched {
+		return nil, &errs.FailedPrecondition{Resource: "race", ID: raceID, Reason: fmt.Sprintf("runner %d is scratched", bet.RunnerNumber)}
+	}
+
+	struck, err := ptypes.Timestamp(bet.StruckTime)
+	if err != nil {
+		return nil, err
+	}
+
+	winDeduction, placeDeduction := scratchings.Deductions(race.Scratchings, struck)
+
+	type part struct {
+		name    string
+		struck  float64
+		current float64
+		quoted  *float64
+	}
+
+	quote := &betting.CashOutQuote{BetId: bet.Id}
+
+	// Deductions only come off winnings, so the stake is left whole. Place bets have no win price, and win
+	// bets no place price.
+	var parts []part
+
+	if bet.WinPrice > 0 {
+		parts = append(parts, part{"win", deducted(bet.WinPrice, winDeduction), prices.Win.GetDecimal(), &quote.WinPrice})
+	}
+
+	if bet.PlacePrice > 0 {
+		parts = append(parts, part{"place", deducted(bet.PlacePrice, placeDeduction), prices.Place.GetDecimal(), &quote.PlacePrice})
+	}
+
+	value := 0.0
+
+	for _, part := range parts {
+		if part.current == 0 {
+			return nil, &errs.FailedPrecondition{Resource: "race", ID: raceID, Reason: fmt.Sprintf("runner %d has no %s price", bet.RunnerNumber, part.name)}
+		}
+
+		value += float64(bet.Stake) * part.struck / part.current
+		*part.quoted = part.current
+	}
+
+	// Amounts are rounded down to the cent, with a little slack for float error.
+	quote.Amount = int64(math.Floor(value*(1-terms.Margin) + 1e-6))
+
+	if quote.QuoteTime, err = ptypes.TimestampProto(now); err != nil {
+		return nil, err
+	}
+
+	if quote.ExpireTime, err = ptypes.TimestampProto(now.Add(terms.TTL)); err != nil {
+		return nil, err
+	}
+
+	return quote, nil
+}
+
+// Check returns an error if a quote can no longer be executed at the given time, given a fresh quote of
+// the bet made then: an *errs.FailedPrecondition if it has expired, or an *errs.Conflict if the bet's value
+// has fallen by more than the tolerance from what was quoted, so that the customer can be shown the new
+// quote instead.
+func Check(quote, fresh *betting.CashOutQuote, terms Terms, now time.Time) error {
+	id := strconv.FormatInt(quote.Id, 10)
+
+	expire, err := ptypes.Timestamp(quote.ExpireTime)
+	if err != nil {
+		return err
+	}
+
+	if !now.Before(expire) {
+		return &errs.FailedPrecondition{Resource: "quote", ID: id, Reason: "the quote has expired"}
+	}
+
+	if float64(fresh.Amount) < float64(quote.Amount)*(1-terms.Tolerance) {
+		return &errs.Conflict{
+			Resource: "quote",
+			ID:       id,
+			Reason:   fmt.Sprintf("the cash out value has fallen from %d to %d", quote.Amount, fresh.Amount),
+		}
+	}
+
+	return nil
+}
+
+// Settlement returns the settlement of a bet cashing it out for the amount of a quote. Its bet, adjustment
+// and time are left for the caller to set.
+func Settlement(quote *betting.CashOutQuote) *betting.Settlement {
+	return &betting.Settlement{
+		Status: betting.Bet_CASHED_OUT,
+		Payout: quote.Amount,
+		Reason: fmt.Sprintf("cashed out for quote %d", quote.Id),
+	}
+}
+
+// deducted returns a price with a deduction, as a percentage, taken off its winnings.
+func deducted(price, deduction float64) float64 {
+	return 1 + (price-1)*(1-deduction/100)
+}
+
+// runnerPrices returns the prices of a runner in a market, or nil if it is not in the race.
+func runnerPrices(market *racing.Market, number int64) *racing.RunnerPrices {
+	for _, runner := range market.Runners {
+		if runner.RunnerNumber == number {
+			return runner
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,227 @@
+package cashout
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"git.neds.sh/matty/entain/betting/proto/betting"
+	"git.neds.sh/matty/entain/common/errs"
+	"git.neds.sh/matty/entain/racing/proto/racing"
+)
+
+// struck is when the bets of the tests were struck.
+var struck = time.Date(2026, 11, 3, 14, 0, 0, 0, time.UTC)
+
+// now is when the bets of the tests are quoted, ten minutes after they were struck.
+var now = struck.Add(10 * time.Minute)
+
+// terms are the terms the bets of the tests are cashed out on.
+var terms = Terms{Margin: 0.05, TTL: 15 * time.Second, Tolerance: 0.02}
+
+// at returns the given time as a timestamp.
+func at(t time.Time) *timestamppb.Timestamp {
+	ts, _ := ptypes.TimestampProto(t)
+	return ts
+}
+
+// open returns an open race with the given scratchings.
+func open(scratchings ...*racing.Scratching) *racing.Race {
+	return &racing.Race{Id: 42, State: racing.Race_OPEN, Scratchings: scratchings}
+}
+
+// scratching returns the scratching of a runner at the given time, with the given deductions.
+func scratching(runnerNumber int64, scratched time.Time, win, place float64) *racing.Scratching {
+	return &racing.Scratching{RaceId: 42, RunnerNumber: runnerNumber, ScratchTime: at(scratched), WinDeduction: win, PlaceDeduction: place}
+}
+
+// market returns a market pricing runner 4 at the given decimal odds, with none for a price of 0.
+func market(win, place float64) *racing.Market {
+	prices := &racing.RunnerPrices{RunnerNumber: 4}
+	if win > 0 {
+		prices.Win = &racing.Odds{Decimal: win}
+	}
+
+	if place > 0 {
+		prices.Place = &racing.Odds{Decimal: place}
+	}
+
+	return &racing.Market{RaceId: 42, Runners: []*racing.RunnerPrices{prices}}
+}
+
+// bet returns an accepted bet with a stake of 1000 cents on runner 4, at the given prices.
+func bet(betType betting.Bet_Type, win, place float64) *betting.Bet {
+	return &betting.Bet{
+		Id:           1,
+		RaceId:       42,
+		RunnerNumber: 4,
+		Type:         betType,
+		Stake:        1000,
+		WinPrice:     win,
+		PlacePrice:   place,
+		Status:       betting.Bet_ACCEPTED,
+		StruckTime:   at(struck),
+	}
+}
+
+// quoted returns the quote of bet 1 made now for the given amount at the given prices.
+func quoted(amount int64, win, place float64) *betting.CashOutQuote {
+	return &betting.CashOutQuote{
+		BetId:      1,
+		Amount:     amount,
+		WinPrice:   win,
+		PlacePrice: place,
+		QuoteTime:  at(now),
+		ExpireTime: at(now.Add(terms.TTL)),
+	}
+}
+
+func TestQuote(t *testing.T) {
+	settled := bet(betting.Bet_WIN, 3.5, 0)
+	settled.Status = betting.Bet_WON
+
+	suspended := open()
+	suspended.Suspensions = []*racing.Suspension{{Id: 1, Scope: racing.Suspension_RACE, RaceId: 42, Reason: "liability"}}
+
+	closed := open()
+	closed.State = racing.Race_CLOSED
+
+	scratchedMarket := market(5, 2)
+	scratchedMarket.Runners[0].Scratched = true
+
+	tests := []struct {
+		name    string
+		bet     *betting.Bet
+		race    *racing.Race
+		market  *racing.Market
+		want    *betting.CashOutQuote
+		wantErr error
+	}{
+		{
+			name:   "win drifted",
+			bet:    bet(betting.Bet_WIN, 3.5, 0),
+			race:   open(),
+			market: market(5, 2),
+			want:   quoted(665, 5, 0),
+		},
+		{
+			name:   "win shortened",
+			bet:    bet(betting.Bet_WIN, 3.5, 0),
+			race:   open(),
+			market: market(2.5, 1.5),
+			want:   quoted(1330, 2.5, 0),
+		},
+		{
+			name:   "place unchanged",
+			bet:    bet(betting.Bet_PLACE, 0, 1.6),
+			race:   open(),
+			market: market(5, 1.6),
+			want:   quoted(950, 0, 1.6),
+		},
+		{
+			name:   "each way",
+			bet:    bet(betting.Bet_EACH_WAY, 3.5, 1.6),
+			race:   open(),
+			market: market(5, 2),
+			want:   quoted(1425, 5, 2),
+		},
+		{
+			name:   "scratching after the bet was struck",
+			bet:    bet(betting.Bet_EACH_WAY, 3.5, 1.6),
+			race:   open(scratching(9, struck.Add(time.Minute), 20, 10)),
+			market: market(5, 2),
+			want:   quoted(1301, 5, 2),
+		},
+		{
+			name:   "scratching before the bet was struck",
+			bet:    bet(betting.Bet_WIN, 3.5, 0),
+			race:   open(scratching(9, struck.Add(-time.Minute), 20, 10)),
+			market: market(5, 2),
+			want:   quoted(665, 5, 0),
+		},
+		{
+			name:    "settled bet",
+			bet:     settled,
+			race:    open(),
+			market:  market(5, 2),
+			wantErr: &errs.FailedPrecondition{},
+		},
+		{
+			name:    "closed race",
+			bet:     bet(betting.Bet_WIN, 3.5, 0),
+			race:    closed,
+			market:  market(5, 2),
+			wantErr: &errs.FailedPrecondition{},
+		},
+		{
+			name:    "suspended race",
+			bet:     bet(betting.Bet_WIN, 3.5, 0),
+			race:    suspended,
+			market:  market(5, 2),
+			wantErr: &errs.FailedPrecondition{},
+		},
+		{
+			name:    "scratched runner",
+			bet:     bet(betting.Bet_WIN, 3.5, 0),
+			race:    open(),
+			market:  scratchedMarket,
+			wantErr: &errs.FailedPrecondition{},
+		},
+		{
+			name:    "each way without a place price",
+			bet:     bet(betting.Bet_EACH_WAY, 3.5, 1.6),
+			race:    open(),
+			market:  market(5, 0),
+			wantErr: &errs.FailedPrecondition{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Quote(tt.bet, tt.race, tt.market, terms, now)
+			if reflect.TypeOf(err) != reflect.TypeOf(tt.wantErr) {
+				t.Fatalf("Quote() error = %v, want %T", err, tt.wantErr)
+			}
+
+			if !proto.Equal(got, tt.want) {
+				t.Errorf("Quote() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheck(t *testing.T) {
+	expire := now.Add(terms.TTL)
+
+	tests := []struct {
+		name    string
+		fresh   int64
+		at      time.Time
+		wantErr error
+	}{
+		{name: "unchanged", fresh: 1000, at: now},
+		{name: "risen", fresh: 1200, at: now},
+		{name: "fallen within tolerance", fresh: 990, at: now},
+		{name: "fallen by the tolerance", fresh: 980, at: now},
+		{name: "fallen beyond tolerance", fresh: 979, at: now, wantErr: &errs.Conflict{}},
+		{name: "just before expiry", fresh: 1000, at: expire.Add(-time.Nanosecond)},
+		{name: "at expiry", fresh: 1000, at: expire, wantErr: &errs.FailedPrecondition{}},
+		{name: "after expiry", fresh: 1000, at: expire.Add(time.Second), wantErr: &errs.FailedPrecondition{}},
+		{name: "expired and fallen", fresh: 500, at: expire, wantErr: &errs.FailedPrecondition{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			quote := quoted(1000, 5, 0)
+			fresh := quoted(tt.fresh, 6, 0)
+
+			if err := Check(quote, fresh, terms, tt.at); reflect.TypeOf(err) != reflect.TypeOf(tt.wantErr) {
+				t.Errorf("Check() = %v, want %T", err, tt.wantErr)
+			}
+		})
+	}
+}
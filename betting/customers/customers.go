@@ -0,0 +1,92 @@
+// Package customers checks that requests made on behalf of a customer only act on what is theirs.
+//
+// The api gateway authenticates the customer of every request to the betting routes, and says who they
+// are in the CustomerMetadata of the request it makes. Requests without it come from other services,
+// which are trusted to act on behalf of any customer. The gateway sends the metadata empty for requests it
+// did not authenticate, so a route it forwards without authenticating is refused rather than trusted.
+package customers
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"git.neds.sh/matty/entain/common/errs"
+)
+
+// CustomerMetadata is the gRPC metadata the api gateway says who a request was authenticated for in.
+const CustomerMetadata = "authenticated-customer-id"
+
+// customerIDField is the field of requests naming the customer they are made for.
+const customerIDField protoreflect.Name = "customer_id"
+
+// Customer returns the ID of the customer a request was made on behalf of, or false if it came from
+// another service. It returns an *errs.PermissionDenied if the gateway did not authenticate the request.
+func Customer(ctx context.Context) (string, bool, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	values := md.Get(CustomerMetadata)
+	switch {
+	case len(values) == 0:
+		return "", false, nil
+	case len(values) > 1 || values[0] == "":
+		return "", false, &errs.PermissionDenied{Resource: "customer", Reason: "the request was not authenticated for a customer"}
+	}
+
+	return values[0], true, nil
+}
+
+// Check returns an *errs.PermissionDenied unless a request may act on a resource of the given customer,
+// such as a bet.
+func Check(ctx context.Context, resource, id, customerID string) error {
+	caller, ok, err := Customer(ctx)
+	if err != nil || !ok {
+		return err
+	}
+
+	if caller != customerID {
+		return &errs.PermissionDenied{Resource: resource, ID: id, Reason: "it is not the customer's own"}
+	}
+
+	return nil
+}
+
+// UnaryServerInterceptor fills in the customer_id of requests made on behalf of a customer who left it
+// out, and rejects those naming another customer, before they are validated.
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if msg, ok := req.(proto.Message); ok {
+			if err := claim(ctx, msg.ProtoReflect()); err != nil {
+				return nil, err
+			}
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// claim sets the customer_id of a request to the customer it was made on behalf of.
+func claim(ctx context.Context, msg protoreflect.Message) error {
+	field := msg.Descriptor().Fields().ByName(customerIDField)
+	if field == nil || field.Kind() != protoreflect.StringKind || field.IsList() {
+		return nil
+	}
+
+	caller, ok, err := Customer(ctx)
+	if err != nil || !ok {
+		return err
+	}
+
+	switch customerID := msg.Get(field).String(); customerID {
+	case "":
+		msg.Set(field, protoreflect.ValueOfString(caller))
+	case caller:
+	default:
+		return &errs.PermissionDenied{Resource: "customer", ID: customerID, Reason: "the request was authenticated for another customer"}
+	}
+
+	return nil
+}
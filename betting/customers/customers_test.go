@@ -0,0 +1,84 @@
+package customers
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc/metadata"
+
+	"git.neds.sh/matty/entain/betting/proto/betting"
+	"git.neds.sh/matty/entain/common/errs"
+)
+
+// incoming returns a context of a request with the given values of the CustomerMetadata.
+func incoming(values ...string) context.Context {
+	md := metadata.MD{}
+	for _, value := range values {
+		md.Append(CustomerMetadata, value)
+	}
+
+	return metadata.NewIncomingContext(context.Background(), md)
+}
+
+func TestClaim(t *testing.T) {
+	tests := []struct {
+		name       string
+		ctx        context.Context
+		customerID string
+		want       string
+		denied     bool
+	}{
+		{name: "left out", ctx: incoming("c-1"), customerID: "", want: "c-1"},
+		{name: "own", ctx: incoming("c-1"), customerID: "c-1", want: "c-1"},
+		{name: "another customer's", ctx: incoming("c-1"), customerID: "c-2", denied: true},
+		{name: "not authenticated", ctx: incoming(""), customerID: "c-1", denied: true},
+		{name: "authenticated twice", ctx: incoming("c-1", "c-2"), customerID: "c-1", denied: true},
+		{name: "from another service", ctx: context.Background(), customerID: "c-2", want: "c-2"},
+		{name: "from another service left out", ctx: context.Background(), customerID: "", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := &betting.PlaceBetRequest{CustomerId: tt.customerID}
+
+			err := claim(tt.ctx, req.ProtoReflect())
+			if _, denied := err.(*errs.PermissionDenied); denied != tt.denied {
+				t.Fatalf("claim() = %v, want denied %t", err, tt.denied)
+			}
+
+			if !tt.denied && req.CustomerId != tt.want {
+				t.Errorf("customer_id = %q, want %q", req.CustomerId, tt.want)
+			}
+		})
+	}
+}
+
+func TestClaimWithoutCustomer(t *testing.T) {
+	req := &betting.GetBetRequest{Id: 1}
+
+	if err := claim(incoming("c-1"), req.ProtoReflect()); err != nil {
+		t.Errorf("claim() = %v, want nil for a request naming no customer", err)
+	}
+}
+
+func TestCheck(t *testing.T) {
+	tests := []struct {
+		name   string
+		ctx    context.Context
+		denied bool
+	}{
+		{name: "own", ctx: incoming("c-1")},
+		{name: "another customer's", ctx: incoming("c-2"), denied: true},
+		{name: "not authenticated", ctx: incoming(""), denied: true},
+		{name: "from another service", ctx: context.Background()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Check(tt.ctx, "bet", "1", "c-1")
+			if _, denied := err.(*errs.PermissionDenied); denied != tt.denied || (err != nil && !denied) {
+				t.Errorf("Check() = %v, want denied %t", err, tt.denied)
+			}
+		})
+	}
+}
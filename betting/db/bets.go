@@ -0,0 +1,212 @@
+package db
+
+import (
+	"database/sql"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	_ "github.com/mattn/go-sqlite3"
+
+	"git.neds.sh/matty/entain/betting/proto/betting"
+	"git.neds.sh/matty/entain/common/errs"
+)
+
+// BetsRepo provides repository access to bets.
+type BetsRepo interface {
+	// Init will initialise our bets repository, creating its tables.
+	Init() error
+
+	// Get will return the bet with the given ID, or an *errs.NotFound error.
+	Get(id int64) (*betting.Bet, error)
+
+	// GetByKey will return the bet a customer placed with an idempotency key, or nil if there is none.
+	GetByKey(customerID, idempotencyKey string) (*betting.Bet, error)
+
+	// Insert will store a new bet, assigning it an ID and struck time, and return it as it was stored. If the
+	// customer has already placed a bet with its idempotency key, that bet is returned instead, unchanged.
+	Insert(bet *betting.Bet) (*betting.Bet, error)
+
+	// RaceBets will return every bet on a race, in the order they were placed.
+	RaceBets(raceID int64) ([]*betting.Bet, error)
+
+	// RacesToSettle will return the races with bets or legs of multis that are yet to be settled, or whose
+	// bets or multis were settled after the given time, and so may need settling again on an amended result.
+	RacesToSettle(settledSince time.Time) ([]int64, error)
+
+	// Settle will settle a bet, recording the settlement in its audit trail along with the adjustment it
+	// makes to the bet's payout, and return the settlement as it was stored. It returns an *errs.Conflict
+	// error if the bet is no longer as given, and does not otherwise check the settlement.
+	Settle(bet *betting.Bet, settlement *betting.Settlement) (*betting.Settlement, error)
+
+	// ListSettlements will return every settlement of a bet, oldest first, or an *errs.NotFound error.
+	ListSettlements(betID int64) ([]*betting.Settlement, error)
+
+	// UnpostedSettlements will return the settlements whose adjustments are yet to be posted to the
+	// customer's balance, oldest first.
+	UnpostedSettlements() ([]*betting.Settlement, error)
+
+	// MarkPosted will record that a settlement's adjustment has been posted to the customer's balance.
+	MarkPosted(settlementID int64) error
+
+	// GetMulti will return the multi with the given ID, with its legs, or an *errs.NotFound error.
+	GetMulti(id int64) (*betting.Multi, error)
+
+	// GetMultiByKey will return the multi a customer placed with an idempotency key, or nil if there is none.
+	GetMultiByKey(customerID, idempotencyKey string) (*betting.Multi, error)
+
+	// InsertMulti will store a new multi with its legs, assigning it an ID and struck time, and return it as
+	// it was stored. If the customer has already placed a multi with its idempotency key, that multi is
+	// returned instead, unchanged.
+	InsertMulti(multi *betting.Multi) (*betting.Multi, error)
+
+	// RaceMultis will return every multi with a leg on a race, in the order they were placed.
+	RaceMultis(raceID int64) ([]*betting.Multi, error)
+
+	// EventMultis will return every multi with a leg on a sports event, in the order they were placed.
+	EventMultis(eventID int64) ([]*betting.Multi, error)
+
+	// EventsToSettle will return the sports events with legs of multis that are yet to be settled, or whose
+	// multis are unsettled or were settled after the given time.
+	EventsToSettle(settledSince time.Time) ([]int64, error)
+
+	// SettleLeg will settle a leg of a multi as given by settled, and return the leg as it was stored. It
+	// returns an *errs.Conflict error if the leg is no longer as given.
+	SettleLeg(multiID int64, leg, settled *betting.Leg) (*betting.Leg, error)
+
+	// SettleMulti will settle a multi as Settle does a bet, recording the settlement in its audit trail.
+	SettleMulti(multi *betting.Multi, settlement *betting.Settlement) (*betting.Settlement, error)
+
+	// ListMultiSettlements will return every settlement of a multi, oldest first, or an *errs.NotFound error.
+	ListMultiSettlements(multiID int64) ([]*betting.Settlement, error)
+
+	// GetQuote will return the cash-out quote with the given ID, or an *errs.NotFound error.
+	GetQuote(id int64) (*betting.CashOutQuote, error)
+
+	// InsertQuote will store a new cash-out quote, assigning it an ID, and return it as it was stored.
+	InsertQuote(quote *betting.CashOutQuote) (*betting.CashOutQuote, error)
+
+	// CashOut will settle a bet as Settle does, cashing it out for a quote, and record the settlement
+	// against the quote. It returns an *errs.Conflict error if the bet is no longer as given or the quote
+	// has already been executed.
+	CashOut(bet *betting.Bet, quote *betting.CashOutQuote, settlement *betting.Settlement) (*betting.Settlement, error)
+}
+
+type betsRepo struct {
+	db   *sql.DB
+	init sync.Once
+}
+
+// NewBetsRepo creates a new bets repository.
+func NewBetsRepo(db *sql.DB) BetsRepo {
+	return &betsRepo{db: db}
+}
+
+// Init prepares the bets repository, migrating its database.
+func (r *betsRepo) Init() error {
+	var err error
+
+	r.init.Do(func() {
+		err = r.migrate()
+	})
+
+	return err
+}
+
+func (r *betsRepo) Get(id int64) (*betting.Bet, error) {
+	bets, err := r.query(betsGet, id)
+	if err != nil {
+		return nil, wrapError(err)
+	}
+
+	if len(bets) == 0 {
+		return nil, &errs.NotFound{Resource: "bet", ID: strconv.FormatInt(id, 10)}
+	}
+
+	return bets[0], nil
+}
+
+func (r *betsRepo) GetByKey(customerID, idempotencyKey string) (*betting.Bet, error) {
+	bets, err := r.query(betsGetByKey, customerID, idempotencyKey)
+	if err != nil || len(bets) == 0 {
+		return nil, wrapError(err)
+	}
+
+	return bets[0], nil
+}
+
+func (r *betsRepo) Insert(bet *betting.Bet) (*betting.Bet, error) {
+	now := time.Now().UTC().Truncate(time.Millisecond)
+
+	_, err := r.db.Exec(getBetQueries()[betsInsert],
+		bet.CustomerId,
+		bet.IdempotencyKey,
+		bet.RaceId,
+		bet.RunnerNumber,
+		bet.Type.String(),
+		bet.Stake,
+		bet.TotalStake,
+		bet.WinPrice,
+		bet.PlacePrice,
+		bet.Status.String(),
+		now.Format(time.RFC3339Nano),
+		bet.HoldId,
+	)
+	if err != nil {
+		return nil, wrapError(err)
+	}
+
+	// Reading the bet back by its key returns whichever bet holds it, this one or one placed before.
+	return r.GetByKey(bet.CustomerId, bet.IdempotencyKey)
+}
+
+// query runs one of the bet queries, returning the bets it selects.
+func (r *betsRepo) query(name string, args ...interface{}) ([]*betting.Bet, error) {
+	rows, err := r.db.Query(getBetQueries()[name], args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanBets(rows)
+}
+
+// scanBets reads bets of betColumns from rows, closing them.
+func scanBets(rows *sql.Rows) ([]*betting.Bet, error) {
+	defer rows.Close()
+
+	var bets []*betting.Bet
+
+	for rows.Next() {
+		var (
+			bet        betting.Bet
+			betType    string
+			status     string
+			struckTime time.Time
+			settleTime sql.NullTime
+		)
+
+		if err := rows.Scan(&bet.Id, &bet.CustomerId, &bet.IdempotencyKey, &bet.RaceId, &bet.RunnerNumber, &betType,
+			&bet.Stake, &bet.TotalStake, &bet.WinPrice, &bet.PlacePrice, &status, &struckTime, &bet.Payout, &settleTime, &bet.HoldId); err != nil {
+			return nil, err
+		}
+
+		bet.Type = betting.Bet_Type(betting.Bet_Type_value[betType])
+		bet.Status = betting.Bet_Status(betting.Bet_Status_value[status])
+
+		var err error
+		if bet.StruckTime, err = ptypes.TimestampProto(struckTime); err != nil {
+			return nil, err
+		}
+
+		if settleTime.Valid {
+			if bet.SettleTime, err = ptypes.TimestampProto(settleTime.Time); err != nil {
+				return nil, err
+			}
+		}
+
+		bets = append(bets, &bet)
+	}
+
+	return bets, rows.Err()
+}
@@ -0,0 +1,126 @@
+package db
+
+import (
+	"database/sql"
+	"strconv"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+
+	"git.neds.sh/matty/entain/betting/proto/betting"
+	"git.neds.sh/matty/entain/common/errs"
+)
+
+func (r *betsRepo) GetQuote(id int64) (*betting.CashOutQuote, error) {
+	rows, err := r.db.Query(getBetQueries()[quotesGet], id)
+	if err != nil {
+		return nil, wrapError(err)
+	}
+
+	quotes, err := scanQuotes(rows)
+	if err != nil {
+		return nil, wrapError(err)
+	}
+
+	if len(quotes) == 0 {
+		return nil, &errs.NotFound{Resource: "quote", ID: strconv.FormatInt(id, 10)}
+	}
+
+	return quotes[0], nil
+}
+
+func (r *betsRepo) InsertQuote(quote *betting.CashOutQuote) (*betting.CashOutQuote, error) {
+	quoteTime, err := ptypes.Timestamp(quote.QuoteTime)
+	if err != nil {
+		return nil, err
+	}
+
+	expireTime, err := ptypes.Timestamp(quote.ExpireTime)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := r.db.Exec(getBetQueries()[quotesInsert],
+		quote.BetId,
+		quote.Amount,
+		quote.WinPrice,
+		quote.PlacePrice,
+		quoteTime.UTC().Truncate(time.Millisecond).Format(time.RFC3339Nano),
+		expireTime.UTC().Truncate(time.Millisecond).Format(time.RFC3339Nano),
+	)
+	if err != nil {
+		return nil, wrapError(err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, wrapError(err)
+	}
+
+	return r.GetQuote(id)
+}
+
+func (r *betsRepo) CashOut(bet *betting.Bet, quote *betting.CashOutQuote, settlement *betting.Settlement) (*betting.Settlement, error) {
+	settled, err := r.cashOut(bet, quote, settlement)
+
+	return settled, wrapError(err)
+}
+
+func (r *betsRepo) cashOut(bet *betting.Bet, quote *betting.CashOutQuote, settlement *betting.Settlement) (*betting.Settlement, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	settled, err := settleBet(tx, bet, settlement, time.Now().UTC().Truncate(time.Millisecond))
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := tx.Exec(getBetQueries()[quotesExecute], settled.Id, quote.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	if affected, err := result.RowsAffected(); err != nil {
+		return nil, err
+	} else if affected == 0 {
+		return nil, &errs.Conflict{Resource: "quote", ID: strconv.FormatInt(quote.Id, 10), Reason: "the quote has already been executed"}
+	}
+
+	return settled, tx.Commit()
+}
+
+// scanQuotes reads cash-out quotes of quoteColumns from rows, closing them.
+func scanQuotes(rows *sql.Rows) ([]*betting.CashOutQuote, error) {
+	defer rows.Close()
+
+	var quotes []*betting.CashOutQuote
+
+	for rows.Next() {
+		var (
+			quote      betting.CashOutQuote
+			quoteTime  time.Time
+			expireTime time.Time
+		)
+
+		if err := rows.Scan(&quote.Id, &quote.BetId, &quote.Amount, &quote.WinPrice, &quote.PlacePrice, &quoteTime, &expireTime,
+			&quote.SettlementId); err != nil {
+			return nil, err
+		}
+
+		var err error
+		if quote.QuoteTime, err = ptypes.TimestampProto(quoteTime); err != nil {
+			return nil, err
+		}
+
+		if quote.ExpireTime, err = ptypes.TimestampProto(expireTime); err != nil {
+			return nil, err
+		}
+
+		quotes = append(quotes, &quote)
+	}
+
+	return quotes, rows.Err()
+}
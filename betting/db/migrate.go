@@ -0,0 +1,176 @@
+package db
+
+import "fmt"
+
+// migrations create the tables of the betting database, and bring databases created by earlier versions
+// of the betting service up to date. Each must be safe to run against a database it has already been
+// applied to.
+var migrations = []func(r *betsRepo) error{
+	func(r *betsRepo) error {
+		// Each customer can only use an idempotency key once, whatever bet they use it for.
+		_, err := r.db.Exec(`CREATE TABLE IF NOT EXISTS bets (
+			id INTEGER PRIMARY KEY,
+			customer_id TEXT NOT NULL,
+			idempotency_key TEXT NOT NULL,
+			race_id INTEGER NOT NULL,
+			runner_number INTEGER NOT NULL,
+			type TEXT NOT NULL,
+			stake INTEGER NOT NULL,
+			total_stake INTEGER NOT NULL,
+			win_price REAL NOT NULL,
+			place_price REAL NOT NULL,
+			status TEXT NOT NULL,
+			struck_time DATETIME NOT NULL,
+			UNIQUE (customer_id, idempotency_key)
+		)`)
+		if err != nil {
+			return err
+		}
+
+		_, err = r.db.Exec(`CREATE INDEX IF NOT EXISTS bets_race_id ON bets (race_id)`)
+
+		return err
+	},
+	func(r *betsRepo) error {
+		if err := r.addColumn("bets", "payout", "INTEGER NOT NULL DEFAULT 0"); err != nil {
+			return err
+		}
+
+		if err := r.addColumn("bets", "settle_time", "DATETIME"); err != nil {
+			return err
+		}
+
+		// Every settlement of a bet is kept, so that re-settling it on an amended result never loses what it replaced.
+		_, err := r.db.Exec(`CREATE TABLE IF NOT EXISTS settlements (
+			id INTEGER PRIMARY KEY,
+			bet_id INTEGER NOT NULL,
+			status TEXT NOT NULL,
+			payout INTEGER NOT NULL,
+			adjustment INTEGER NOT NULL,
+			result_version INTEGER NOT NULL,
+			reason TEXT NOT NULL,
+			settle_time DATETIME NOT NULL
+		)`)
+		if err != nil {
+			return err
+		}
+
+		_, err = r.db.Exec(`CREATE INDEX IF NOT EXISTS settlements_bet_id ON settlements (bet_id)`)
+
+		return err
+	},
+	func(r *betsRepo) error {
+		if err := r.addColumn("bets", "hold_id", "INTEGER NOT NULL DEFAULT 0"); err != nil {
+			return err
+		}
+
+		// Settlements made before returns were paid to customers' balances have nothing to post, so count as
+		// posted. New settlements are inserted unposted.
+		return r.addColumn("settlements", "posted", "INTEGER NOT NULL DEFAULT 1")
+	},
+	func(r *betsRepo) error {
+		// Multis share the idempotency keys of single bets, but not their table.
+		_, err := r.db.Exec(`CREATE TABLE IF NOT EXISTS multis (
+			id INTEGER PRIMARY KEY,
+			customer_id TEXT NOT NULL,
+			idempotency_key TEXT NOT NULL,
+			stake INTEGER NOT NULL,
+			price REAL NOT NULL,
+			status TEXT NOT NULL,
+			struck_time DATETIME NOT NULL,
+			payout INTEGER NOT NULL DEFAULT 0,
+			settle_time DATETIME,
+			hold_id INTEGER NOT NULL,
+			UNIQUE (customer_id, idempotency_key)
+		)`)
+		if err != nil {
+			return err
+		}
+
+		// A leg is either on a race or on a sports event, with the other's columns 0.
+		_, err = r.db.Exec(`CREATE TABLE IF NOT EXISTS legs (
+			multi_id INTEGER NOT NULL,
+			number INTEGER NOT NULL,
+			race_id INTEGER NOT NULL,
+			runner_number INTEGER NOT NULL,
+			event_id INTEGER NOT NULL,
+			outcome_id INTEGER NOT NULL,
+			price REAL NOT NULL,
+			status TEXT NOT NULL,
+			settled_price REAL NOT NULL DEFAULT 0,
+			result_version INTEGER NOT NULL DEFAULT 0,
+			reason TEXT NOT NULL DEFAULT '',
+			settle_time DATETIME,
+			PRIMARY KEY (multi_id, number)
+		)`)
+		if err != nil {
+			return err
+		}
+
+		for _, index := range []string{
+			`CREATE INDEX IF NOT EXISTS legs_race_id ON legs (race_id)`,
+			`CREATE INDEX IF NOT EXISTS legs_event_id ON legs (event_id)`,
+		} {
+			if _, err := r.db.Exec(index); err != nil {
+				return err
+			}
+		}
+
+		// Settlements are of either a bet or a multi, with the other's ID 0.
+		return r.addColumn("settlements", "multi_id", "INTEGER NOT NULL DEFAULT 0")
+	},
+	func(r *betsRepo) error {
+		// Quotes are kept once executed, along with the settlement that cashed their bet out.
+		_, err := r.db.Exec(`CREATE TABLE IF NOT EXISTS cash_out_quotes (
+			id INTEGER PRIMARY KEY,
+			bet_id INTEGER NOT NULL,
+			amount INTEGER NOT NULL,
+			win_price REAL NOT NULL,
+			place_price REAL NOT NULL,
+			quote_time DATETIME NOT NULL,
+			expire_time DATETIME NOT NULL,
+			settlement_id INTEGER NOT NULL DEFAULT 0
+		)`)
+
+		return err
+	},
+}
+
+// migrate applies every migration in order.
+func (r *betsRepo) migrate() error {
+	for i, migration := range migrations {
+		if err := migration(r); err != nil {
+			return fmt.Errorf("applying migration %d: %w", i+1, err)
+		}
+	}
+
+	return nil
+}
+
+// addColumn adds a column to a table, unless the table already has it.
+func (r *betsRepo) addColumn(table, column, definition string) error {
+	rows, err := r.db.Query(`SELECT name FROM pragma_table_info(?)`, table)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return err
+		}
+
+		if name == column {
+			return nil
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	_, err = r.db.Exec(fmt.Sprintf(`ALTER TABLE %s ADD COLUMN %s %s`, table, column, definition))
+
+	return err
+}
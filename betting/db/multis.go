@@ -0,0 +1,307 @@
+package db
+
+import (
+	"database/sql"
+	"strconv"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+
+	"git.neds.sh/matty/entain/betting/proto/betting"
+	"git.neds.sh/matty/entain/common/errs"
+)
+
+func (r *betsRepo) GetMulti(id int64) (*betting.Multi, error) {
+	multis, err := r.queryMultis(multisGet, id)
+	if err != nil {
+		return nil, wrapError(err)
+	}
+
+	if len(multis) == 0 {
+		return nil, &errs.NotFound{Resource: "multi", ID: strconv.FormatInt(id, 10)}
+	}
+
+	return multis[0], nil
+}
+
+func (r *betsRepo) GetMultiByKey(customerID, idempotencyKey string) (*betting.Multi, error) {
+	multis, err := r.queryMultis(multisGetByKey, customerID, idempotencyKey)
+	if err != nil || len(multis) == 0 {
+		return nil, wrapError(err)
+	}
+
+	return multis[0], nil
+}
+
+func (r *betsRepo) InsertMulti(multi *betting.Multi) (*betting.Multi, error) {
+	if err := r.insertMulti(multi); err != nil {
+		return nil, wrapError(err)
+	}
+
+	// Reading the multi back by its key returns whichever multi holds it, this one or one placed before.
+	return r.GetMultiByKey(multi.CustomerId, multi.IdempotencyKey)
+}
+
+func (r *betsRepo) insertMulti(multi *betting.Multi) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	queries := getBetQueries()
+	now := time.Now().UTC().Truncate(time.Millisecond)
+
+	result, err := tx.Exec(queries[multisInsert],
+		multi.CustomerId,
+		multi.IdempotencyKey,
+		multi.Stake,
+		multi.Price,
+		multi.Status.String(),
+		now.Format(time.RFC3339Nano),
+		multi.HoldId,
+	)
+	if err != nil {
+		return err
+	}
+
+	// A multi already placed with the key has its legs already.
+	if affected, err := result.RowsAffected(); err != nil || affected == 0 {
+		return err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+
+	for _, leg := range multi.Legs {
+		if _, err := tx.Exec(queries[legsInsert], id, leg.Number, leg.RaceId, leg.RunnerNumber, leg.EventId, leg.OutcomeId,
+			leg.Price, leg.Status.String()); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (r *betsRepo) RaceMultis(raceID int64) ([]*betting.Multi, error) {
+	multis, err := r.queryMultis(multisOfRace, raceID)
+
+	return multis, wrapError(err)
+}
+
+func (r *betsRepo) EventMultis(eventID int64) ([]*betting.Multi, error) {
+	multis, err := r.queryMultis(multisOfEvent, eventID)
+
+	return multis, wrapError(err)
+}
+
+func (r *betsRepo) EventsToSettle(settledSince time.Time) ([]int64, error) {
+	eventIDs, err := r.queryIDs(legsEventsToSettle, settledSince.UTC().Format(time.RFC3339Nano))
+
+	return eventIDs, wrapError(err)
+}
+
+func (r *betsRepo) SettleLeg(multiID int64, leg, settled *betting.Leg) (*betting.Leg, error) {
+	now := time.Now().UTC().Truncate(time.Millisecond)
+
+	result, err := r.db.Exec(getBetQueries()[legsSettle],
+		settled.Status.String(),
+		settled.SettledPrice,
+		settled.ResultVersion,
+		settled.Reason,
+		now.Format(time.RFC3339Nano),
+		multiID,
+		leg.Number,
+		leg.Status.String(),
+		leg.SettledPrice,
+		leg.ResultVersion,
+	)
+	if err != nil {
+		return nil, wrapError(err)
+	}
+
+	if affected, err := result.RowsAffected(); err != nil {
+		return nil, wrapError(err)
+	} else if affected == 0 {
+		return nil, &errs.Conflict{
+			Resource: "leg",
+			ID:       strconv.FormatInt(multiID, 10) + "/" + strconv.FormatInt(leg.Number, 10),
+			Reason:   "the leg has changed since it was read",
+		}
+	}
+
+	stored := proto.Clone(settled).(*betting.Leg)
+	if stored.SettleTime, err = ptypes.TimestampProto(now); err != nil {
+		return nil, err
+	}
+
+	return stored, nil
+}
+
+func (r *betsRepo) SettleMulti(multi *betting.Multi, settlement *betting.Settlement) (*betting.Settlement, error) {
+	settled, err := r.settleMulti(multi, settlement)
+
+	return settled, wrapError(err)
+}
+
+func (r *betsRepo) settleMulti(multi *betting.Multi, settlement *betting.Settlement) (*betting.Settlement, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	now := time.Now().UTC().Truncate(time.Millisecond)
+
+	result, err := tx.Exec(getBetQueries()[multisSettle], settlement.Status.String(), settlement.Payout, now.Format(time.RFC3339Nano),
+		multi.Id, multi.Status.String(), multi.Payout)
+	if err != nil {
+		return nil, err
+	}
+
+	if affected, err := result.RowsAffected(); err != nil {
+		return nil, err
+	} else if affected == 0 {
+		return nil, &errs.Conflict{Resource: "multi", ID: strconv.FormatInt(multi.Id, 10), Reason: "the multi has changed since it was read"}
+	}
+
+	settled := proto.Clone(settlement).(*betting.Settlement)
+	settled.MultiId = multi.Id
+	settled.Adjustment = settlement.Payout - multi.Payout
+
+	if err := insertSettlement(tx, settled, now); err != nil {
+		return nil, err
+	}
+
+	return settled, tx.Commit()
+}
+
+func (r *betsRepo) ListMultiSettlements(multiID int64) ([]*betting.Settlement, error) {
+	settlements, err := r.listMultiSettlements(multiID)
+
+	return settlements, wrapError(err)
+}
+
+func (r *betsRepo) listMultiSettlements(multiID int64) ([]*betting.Settlement, error) {
+	// A multi without settlements is told apart from one that does not exist.
+	if _, err := r.GetMulti(multiID); err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.Query(getBetQueries()[settlementsOfMulti], multiID)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanSettlements(rows)
+}
+
+// queryMultis runs one of the multi queries, returning the multis it selects with their legs.
+func (r *betsRepo) queryMultis(name string, args ...interface{}) ([]*betting.Multi, error) {
+	rows, err := r.db.Query(getBetQueries()[name], args...)
+	if err != nil {
+		return nil, err
+	}
+
+	multis, err := scanMultis(rows)
+	if err != nil || len(multis) == 0 {
+		return multis, err
+	}
+
+	return multis, r.withLegs(multis)
+}
+
+// withLegs reads the legs of multis into them.
+func (r *betsRepo) withLegs(multis []*betting.Multi) error {
+	byID := make(map[int64]*betting.Multi, len(multis))
+	minID, maxID := multis[0].Id, multis[0].Id
+
+	for _, multi := range multis {
+		byID[multi.Id] = multi
+
+		if multi.Id < minID {
+			minID = multi.Id
+		}
+
+		if multi.Id > maxID {
+			maxID = multi.Id
+		}
+	}
+
+	rows, err := r.db.Query(getBetQueries()[legsList], minID, maxID)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			multiID    int64
+			leg        betting.Leg
+			status     string
+			settleTime sql.NullTime
+		)
+
+		if err := rows.Scan(&multiID, &leg.Number, &leg.RaceId, &leg.RunnerNumber, &leg.EventId, &leg.OutcomeId, &leg.Price,
+			&status, &leg.SettledPrice, &leg.ResultVersion, &leg.Reason, &settleTime); err != nil {
+			return err
+		}
+
+		leg.Status = betting.Leg_Status(betting.Leg_Status_value[status])
+
+		if settleTime.Valid {
+			var err error
+			if leg.SettleTime, err = ptypes.TimestampProto(settleTime.Time); err != nil {
+				return err
+			}
+		}
+
+		// Multis between those selected that were not themselves selected are skipped.
+		if multi, ok := byID[multiID]; ok {
+			multi.Legs = append(multi.Legs, &leg)
+		}
+	}
+
+	return rows.Err()
+}
+
+// scanMultis reads multis of multiColumns from rows, closing them.
+func scanMultis(rows *sql.Rows) ([]*betting.Multi, error) {
+	defer rows.Close()
+
+	var multis []*betting.Multi
+
+	for rows.Next() {
+		var (
+			multi      betting.Multi
+			status     string
+			struckTime time.Time
+			settleTime sql.NullTime
+		)
+
+		if err := rows.Scan(&multi.Id, &multi.CustomerId, &multi.IdempotencyKey, &multi.Stake, &multi.Price, &status,
+			&struckTime, &multi.Payout, &settleTime, &multi.HoldId); err != nil {
+			return nil, err
+		}
+
+		multi.Status = betting.Bet_Status(betting.Bet_Status_value[status])
+
+		var err error
+		if multi.StruckTime, err = ptypes.TimestampProto(struckTime); err != nil {
+			return nil, err
+		}
+
+		if settleTime.Valid {
+			if multi.SettleTime, err = ptypes.TimestampProto(settleTime.Time); err != nil {
+				return nil, err
+			}
+		}
+
+		multis = append(multis, &multi)
+	}
+
+	return multis, rows.Err()
+}
@@ -0,0 +1,184 @@
+package db
+
+const (
+	betsGet      = "get"
+	betsGetByKey = "get_by_key"
+	betsInsert   = "insert"
+	betsOfRace   = "of_race"
+	betsSettle   = "settle"
+	betsToSettle = "to_settle"
+
+	settlementsList     = "settlements_list"
+	settlementsOfMulti  = "settlements_of_multi"
+	settlementsInsert   = "settlements_insert"
+	settlementsUnposted = "settlements_unposted"
+	settlementsPost     = "settlements_post"
+
+	multisGet      = "multis_get"
+	multisGetByKey = "multis_get_by_key"
+	multisInsert   = "multis_insert"
+	multisOfRace   = "multis_of_race"
+	multisOfEvent  = "multis_of_event"
+	multisSettle   = "multis_settle"
+
+	legsList           = "legs_list"
+	legsInsert         = "legs_insert"
+	legsSettle         = "legs_settle"
+	legsEventsToSettle = "legs_events_to_settle"
+
+	quotesGet     = "quotes_get"
+	quotesInsert  = "quotes_insert"
+	quotesExecute = "quotes_execute"
+)
+
+func getBetQueries() map[string]string {
+	return map[string]string{
+		betsGet: `
+			SELECT ` + betColumns + `
+			FROM bets
+			WHERE id = ?
+		`,
+		betsGetByKey: `
+			SELECT ` + betColumns + `
+			FROM bets
+			WHERE customer_id = ? AND idempotency_key = ?
+		`,
+		// A bet already placed with the key is left as it is, for the caller to read back.
+		betsInsert: `
+			INSERT INTO bets(customer_id, idempotency_key, race_id, runner_number, type, stake, total_stake, win_price, place_price, status, struck_time, hold_id)
+			VALUES (?,?,?,?,?,?,?,?,?,?,?,?)
+			ON CONFLICT(customer_id, idempotency_key) DO NOTHING
+		`,
+		betsOfRace: `
+			SELECT ` + betColumns + `
+			FROM bets
+			WHERE race_id = ?
+			ORDER BY id
+		`,
+		// The bet is only settled if it is still as it was when the settlement was worked out.
+		betsSettle: `
+			UPDATE bets SET status = ?, payout = ?, settle_time = ?
+			WHERE id = ? AND status = ? AND payout = ?
+		`,
+		// The races of the legs of multis are settled alongside those of single bets. The legs of a multi
+		// already lost are still settled, to show how they went. Cashed out bets are never settled on their race.
+		betsToSettle: `
+			SELECT race_id
+			FROM bets
+			WHERE status = 'ACCEPTED' OR (status != 'CASHED_OUT' AND settle_time > ?)
+			UNION
+			SELECT legs.race_id
+			FROM legs JOIN multis ON multis.id = legs.multi_id
+			WHERE legs.race_id != 0 AND (legs.status = 'PENDING' OR multis.status = 'ACCEPTED' OR multis.settle_time > ?)
+			ORDER BY race_id
+		`,
+		settlementsList: `
+			SELECT ` + settlementColumns + `
+			FROM settlements
+			WHERE bet_id = ?
+			ORDER BY id
+		`,
+		settlementsOfMulti: `
+			SELECT ` + settlementColumns + `
+			FROM settlements
+			WHERE multi_id = ?
+			ORDER BY id
+		`,
+		settlementsInsert: `
+			INSERT INTO settlements(bet_id, multi_id, status, payout, adjustment, result_version, reason, settle_time, posted)
+			VALUES (?,?,?,?,?,?,?,?,0)
+		`,
+		settlementsUnposted: `
+			SELECT ` + settlementColumns + `
+			FROM settlements
+			WHERE posted = 0
+			ORDER BY id
+		`,
+		settlementsPost: `
+			UPDATE settlements SET posted = 1
+			WHERE id = ?
+		`,
+		multisGet: `
+			SELECT ` + multiColumns + `
+			FROM multis
+			WHERE id = ?
+		`,
+		multisGetByKey: `
+			SELECT ` + multiColumns + `
+			FROM multis
+			WHERE customer_id = ? AND idempotency_key = ?
+		`,
+		// A multi already placed with the key is left as it is, for the caller to read back.
+		multisInsert: `
+			INSERT INTO multis(customer_id, idempotency_key, stake, price, status, struck_time, hold_id)
+			VALUES (?,?,?,?,?,?,?)
+			ON CONFLICT(customer_id, idempotency_key) DO NOTHING
+		`,
+		multisOfRace: `
+			SELECT ` + multiColumns + `
+			FROM multis
+			WHERE id IN (SELECT multi_id FROM legs WHERE race_id = ?)
+			ORDER BY id
+		`,
+		multisOfEvent: `
+			SELECT ` + multiColumns + `
+			FROM multis
+			WHERE id IN (SELECT multi_id FROM legs WHERE event_id = ?)
+			ORDER BY id
+		`,
+		// The multi is only settled if it is still as it was when the settlement was worked out.
+		multisSettle: `
+			UPDATE multis SET status = ?, payout = ?, settle_time = ?
+			WHERE id = ? AND status = ? AND payout = ?
+		`,
+		// The legs of several multis are read at once, by the range of their IDs.
+		legsList: `
+			SELECT multi_id, ` + legColumns + `
+			FROM legs
+			WHERE multi_id BETWEEN ? AND ?
+			ORDER BY multi_id, number
+		`,
+		legsInsert: `
+			INSERT INTO legs(multi_id, number, race_id, runner_number, event_id, outcome_id, price, status)
+			VALUES (?,?,?,?,?,?,?,?)
+		`,
+		// The leg is only settled if it is still as it was when its settlement was worked out.
+		legsSettle: `
+			UPDATE legs SET status = ?, settled_price = ?, result_version = ?, reason = ?, settle_time = ?
+			WHERE multi_id = ? AND number = ? AND status = ? AND settled_price = ? AND result_version = ?
+		`,
+		legsEventsToSettle: `
+			SELECT DISTINCT legs.event_id
+			FROM legs JOIN multis ON multis.id = legs.multi_id
+			WHERE legs.event_id != 0 AND (legs.status = 'PENDING' OR multis.status = 'ACCEPTED' OR multis.settle_time > ?)
+			ORDER BY legs.event_id
+		`,
+		quotesGet: `
+			SELECT ` + quoteColumns + `
+			FROM cash_out_quotes
+			WHERE id = ?
+		`,
+		quotesInsert: `
+			INSERT INTO cash_out_quotes(bet_id, amount, win_price, place_price, quote_time, expire_time)
+			VALUES (?,?,?,?,?,?)
+		`,
+		// A quote is only executed once.
+		quotesExecute: `
+			UPDATE cash_out_quotes SET settlement_id = ?
+			WHERE id = ? AND settlement_id = 0
+		`,
+	}
+}
+
+const (
+	// betColumns are the columns of a bet, in the order scanBets reads them.
+	betColumns = `id, customer_id, idempotency_key, race_id, runner_number, type, stake, total_stake, win_price, place_price, status, struck_time, payout, settle_time, hold_id`
+	// settlementColumns are the columns of a settlement, in the order scanSettlements reads them.
+	settlementColumns = `id, bet_id, multi_id, status, payout, adjustment, result_version, reason, settle_time`
+	// multiColumns are the columns of a multi, in the order scanMultis reads them.
+	multiColumns = `id, customer_id, idempotency_key, stake, price, status, struck_time, payout, settle_time, hold_id`
+	// legColumns are the columns of a leg, in the order scanLegs reads them.
+	legColumns = `number, race_id, runner_number, event_id, outcome_id, price, status, settled_price, result_version, reason, settle_time`
+	// quoteColumns are the columns of a cash-out quote, in the order scanQuotes reads them.
+	quoteColumns = `id, bet_id, amount, win_price, place_price, quote_time, expire_time, settlement_id`
+)
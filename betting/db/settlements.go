@@ -0,0 +1,186 @@
+package db
+
+import (
+	"database/sql"
+	"strconv"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+
+	"git.neds.sh/matty/entain/betting/proto/betting"
+	"git.neds.sh/matty/entain/common/errs"
+)
+
+func (r *betsRepo) RaceBets(raceID int64) ([]*betting.Bet, error) {
+	bets, err := r.query(betsOfRace, raceID)
+
+	return bets, wrapError(err)
+}
+
+func (r *betsRepo) RacesToSettle(settledSince time.Time) ([]int64, error) {
+	raceIDs, err := r.racesToSettle(settledSince)
+
+	return raceIDs, wrapError(err)
+}
+
+func (r *betsRepo) racesToSettle(settledSince time.Time) ([]int64, error) {
+	since := settledSince.UTC().Format(time.RFC3339Nano)
+
+	return r.queryIDs(betsToSettle, since, since)
+}
+
+// queryIDs runs one of the bet queries, returning the IDs it selects.
+func (r *betsRepo) queryIDs(name string, args ...interface{}) ([]int64, error) {
+	rows, err := r.db.Query(getBetQueries()[name], args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []int64
+
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids, rows.Err()
+}
+
+func (r *betsRepo) Settle(bet *betting.Bet, settlement *betting.Settlement) (*betting.Settlement, error) {
+	settled, err := r.settle(bet, settlement)
+
+	return settled, wrapError(err)
+}
+
+func (r *betsRepo) settle(bet *betting.Bet, settlement *betting.Settlement) (*betting.Settlement, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	settled, err := settleBet(tx, bet, settlement, time.Now().UTC().Truncate(time.Millisecond))
+	if err != nil {
+		return nil, err
+	}
+
+	return settled, tx.Commit()
+}
+
+// settleBet settles a bet in a transaction, at the given time, as settle does.
+func settleBet(tx *sql.Tx, bet *betting.Bet, settlement *betting.Settlement, now time.Time) (*betting.Settlement, error) {
+	result, err := tx.Exec(getBetQueries()[betsSettle], settlement.Status.String(), settlement.Payout, now.Format(time.RFC3339Nano),
+		bet.Id, bet.Status.String(), bet.Payout)
+	if err != nil {
+		return nil, err
+	}
+
+	if affected, err := result.RowsAffected(); err != nil {
+		return nil, err
+	} else if affected == 0 {
+		return nil, &errs.Conflict{Resource: "bet", ID: strconv.FormatInt(bet.Id, 10), Reason: "the bet has changed since it was read"}
+	}
+
+	settled := proto.Clone(settlement).(*betting.Settlement)
+	settled.BetId = bet.Id
+	settled.Adjustment = settlement.Payout - bet.Payout
+
+	if err := insertSettlement(tx, settled, now); err != nil {
+		return nil, err
+	}
+
+	return settled, nil
+}
+
+// insertSettlement adds a settlement to the audit trail of its bet or multi, made at the given time,
+// assigning it its ID and time.
+func insertSettlement(tx *sql.Tx, settlement *betting.Settlement, at time.Time) error {
+	var err error
+	if settlement.SettleTime, err = ptypes.TimestampProto(at); err != nil {
+		return err
+	}
+
+	result, err := tx.Exec(getBetQueries()[settlementsInsert], settlement.BetId, settlement.MultiId, settlement.Status.String(),
+		settlement.Payout, settlement.Adjustment, settlement.ResultVersion, settlement.Reason, at.Format(time.RFC3339Nano))
+	if err != nil {
+		return err
+	}
+
+	settlement.Id, err = result.LastInsertId()
+
+	return err
+}
+
+func (r *betsRepo) ListSettlements(betID int64) ([]*betting.Settlement, error) {
+	settlements, err := r.listSettlements(betID)
+
+	return settlements, wrapError(err)
+}
+
+func (r *betsRepo) listSettlements(betID int64) ([]*betting.Settlement, error) {
+	// A bet without settlements is told apart from one that does not exist.
+	if _, err := r.Get(betID); err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.Query(getBetQueries()[settlementsList], betID)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanSettlements(rows)
+}
+
+func (r *betsRepo) UnpostedSettlements() ([]*betting.Settlement, error) {
+	rows, err := r.db.Query(getBetQueries()[settlementsUnposted])
+	if err != nil {
+		return nil, wrapError(err)
+	}
+
+	settlements, err := scanSettlements(rows)
+
+	return settlements, wrapError(err)
+}
+
+func (r *betsRepo) MarkPosted(settlementID int64) error {
+	_, err := r.db.Exec(getBetQueries()[settlementsPost], settlementID)
+
+	return wrapError(err)
+}
+
+// scanSettlements reads settlements of settlementColumns from rows, closing them.
+func scanSettlements(rows *sql.Rows) ([]*betting.Settlement, error) {
+	defer rows.Close()
+
+	var settlements []*betting.Settlement
+
+	for rows.Next() {
+		var (
+			settlement betting.Settlement
+			status     string
+			at         time.Time
+		)
+
+		if err := rows.Scan(&settlement.Id, &settlement.BetId, &settlement.MultiId, &status, &settlement.Payout, &settlement.Adjustment,
+			&settlement.ResultVersion, &settlement.Reason, &at); err != nil {
+			return nil, err
+		}
+
+		settlement.Status = betting.Bet_Status(betting.Bet_Status_value[status])
+
+		var err error
+		if settlement.SettleTime, err = ptypes.TimestampProto(at); err != nil {
+			return nil, err
+		}
+
+		settlements = append(settlements, &settlement)
+	}
+
+	return settlements, rows.Err()
+}
@@ -0,0 +1,216 @@
+// Package exposure works out what the house stands to lose on races from the fixed-odds bets open on them,
+// and keeps traders up to date with it as bets are placed and cashed out.
+//
+// The liability on a runner is what the house loses if it wins: what the bets on it pay, on their win and
+// their place, less everything staked on the race. Trading on a race is suspended once the liability on one
+// of its runners breaches the threshold, so that it takes no more bets until traders have looked at it or,
+// if the monitor is given one, the suspension's duration is up.
+package exposure
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+
+	"git.neds.sh/matty/entain/betting/db"
+	"git.neds.sh/matty/entain/betting/proto/betting"
+	"git.neds.sh/matty/entain/betting/proto/risk"
+	"git.neds.sh/matty/entain/racing/client"
+	"git.neds.sh/matty/entain/racing/proto/racing"
+)
+
+const (
+	// DefaultThreshold is the liability on a runner, in cents, beyond which its race is suspended, unless
+	// the service is given another.
+	DefaultThreshold = 5000000
+
+	// Actor is who races are suspended by in the racing service's audit trail.
+	Actor = "risk"
+
+	// watchBuffer is how many exposures a watcher may fall behind by before it is disconnected.
+	watchBuffer = 256
+)
+
+// Expose returns the exposure on a race from its bets, worked out at the given time. Only the bets open
+// on it, accepted and not yet settled or cashed out, count. Runners whose liability is beyond the
+// threshold are marked as breaching it, unless it is 0.
+func Expose(raceID int64, bets []*betting.Bet, threshold int64, now time.Time) (*risk.Exposure, error) {
+	exposure := &risk.Exposure{RaceId: raceID, Threshold: threshold}
+
+	var err error
+	if exposure.UpdateTime, err = ptypes.TimestampProto(now); err != nil {
+		return nil, err
+	}
+
+	runners := make(map[int64]*risk.RunnerExposure)
+
+	for _, bet := range bets {
+		if bet.RaceId != raceID || bet.Status != betting.Bet_ACCEPTED {
+			continue
+		}
+
+		runner, ok := runners[bet.RunnerNumber]
+		if !ok {
+			runner = &risk.RunnerExposure{RunnerNumber: bet.RunnerNumber}
+			runners[bet.RunnerNumber] = runner
+			exposure.Runners = append(exposure.Runners, runner)
+		}
+
+		runner.Bets++
+		runner.Stakes += bet.TotalStake
+		runner.WinPayout += payout(bet.Stake, bet.WinPrice)
+		runner.PlacePayout += payout(bet.Stake, bet.PlacePrice)
+
+		exposure.Bets++
+		exposure.Stakes += bet.TotalStake
+	}
+
+	sort.Slice(exposure.Runners, func(i, j int) bool {
+		return exposure.Runners[i].RunnerNumber < exposure.Runners[j].RunnerNumber
+	})
+
+	for _, runner := range exposure.Runners {
+		runner.Liability = runner.WinPayout + runner.PlacePayout - exposure.Stakes
+		runner.Breached = threshold > 0 && runner.Liability > threshold
+
+		if runner.Liability > exposure.Liability {
+			exposure.Liability = runner.Liability
+		}
+	}
+
+	return exposure, nil
+}
+
+// Breached returns the first runner of an exposure whose liability breaches its threshold, or nil if none does.
+func Breached(exposure *risk.Exposure) *risk.RunnerExposure {
+	for _, runner := range exposure.Runners {
+		if runner.Breached {
+			return runner
+		}
+	}
+
+	return nil
+}
+
+// Monitor works out the exposure on races as their bets change, sends it to those watching, and suspends
+// trading on races in the racing service once the liability on one of their runners breaches the threshold.
+type Monitor struct {
+	betsRepo   db.BetsRepo
+	racing     client.Racing
+	threshold  int64
+	suspendFor time.Duration
+
+	mu       sync.Mutex
+	watchers map[chan *risk.Exposure]struct{}
+}
+
+// NewMonitor returns a Monitor of the bets of the repository, suspending trading on races of the racing
+// service once the liability on a runner is beyond the threshold, or never if it is 0. Suspensions last for
+// suspendFor, or until traders resume trading if it is 0.
+func NewMonitor(betsRepo db.BetsRepo, racing client.Racing, threshold int64, suspendFor time.Duration) *Monitor {
+	return &Monitor{
+		betsRepo:   betsRepo,
+		racing:     racing,
+		threshold:  threshold,
+		suspendFor: suspendFor,
+		watchers:   make(map[chan *risk.Exposure]struct{}),
+	}
+}
+
+// Exposure returns the exposure on a race as it is now.
+func (m *Monitor) Exposure(raceID int64) (*risk.Exposure, error) {
+	bets, err := m.betsRepo.RaceBets(raceID)
+	if err != nil {
+		return nil, err
+	}
+
+	return Expose(raceID, bets, m.threshold, time.Now())
+}
+
+// Update works out the exposure on a race again once its bets have changed, sends it to everyone watching,
+// and suspends trading on the race if the liability on one of its runners breaches the threshold, unless
+// trading on it is already suspended.
+func (m *Monitor) Update(ctx context.Context, raceID int64) error {
+	exposure, err := m.Exposure(raceID)
+	if err != nil {
+		return err
+	}
+
+	m.publish(exposure)
+
+	runner := Breached(exposure)
+	if runner == nil {
+		return nil
+	}
+
+	race, err := m.racing.GetRace(ctx, raceID)
+	if err != nil || race.State != racing.Race_OPEN || len(race.Suspensions) > 0 {
+		return err
+	}
+
+	in := &racing.SuspendTradingRequest{
+		Scope:  racing.Suspension_RACE,
+		RaceId: raceID,
+		Actor:  Actor,
+		Reason: fmt.Sprintf("the liability of %d on runner %d is beyond the threshold of %d", runner.Liability, runner.RunnerNumber, m.threshold),
+	}
+
+	if m.suspendFor > 0 {
+		in.Duration = ptypes.DurationProto(m.suspendFor)
+	}
+
+	suspension, err := m.racing.SuspendTrading(ctx, in)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("suspended trading on race %d in suspension %d: %s\n", raceID, suspension.Id, in.Reason)
+
+	return nil
+}
+
+// Subscribe returns a channel of the exposures worked out from now on, and a function to stop them. The
+// channel is closed if the watcher falls too far behind, as it can then no longer be told about every change.
+func (m *Monitor) Subscribe() (<-chan *risk.Exposure, func()) {
+	exposures := make(chan *risk.Exposure, watchBuffer)
+
+	m.mu.Lock()
+	m.watchers[exposures] = struct{}{}
+	m.mu.Unlock()
+
+	return exposures, func() {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+
+		if _, ok := m.watchers[exposures]; ok {
+			delete(m.watchers, exposures)
+			close(exposures)
+		}
+	}
+}
+
+// publish sends an exposure to every watcher, without waiting for any of them.
+func (m *Monitor) publish(exposure *risk.Exposure) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for watcher := range m.watchers {
+		select {
+		case watcher <- exposure:
+		default:
+			delete(m.watchers, watcher)
+			close(watcher)
+		}
+	}
+}
+
+// payout returns what a stake pays at a price, in cents, rounded down, or 0 for a bet without the price.
+func payout(stake int64, price float64) int64 {
+	return int64(math.Floor(float64(stake)*price + 1e-6))
+}
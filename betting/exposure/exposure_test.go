@@ -0,0 +1,226 @@
+package exposure
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+
+	"git.neds.sh/matty/entain/betting/db"
+	"git.neds.sh/matty/entain/betting/proto/betting"
+	"git.neds.sh/matty/entain/betting/proto/risk"
+	"git.neds.sh/matty/entain/racing/client"
+	"git.neds.sh/matty/entain/racing/proto/racing"
+)
+
+// now is when the exposures of the tests are worked out.
+var now = time.Date(2026, 11, 3, 14, 0, 0, 0, time.UTC)
+
+// betsRepo is a BetsRepo holding the bets of the tests.
+type betsRepo struct {
+	db.BetsRepo
+
+	bets []*betting.Bet
+}
+
+func (r *betsRepo) RaceBets(raceID int64) ([]*betting.Bet, error) {
+	var bets []*betting.Bet
+	for _, bet := range r.bets {
+		if bet.RaceId == raceID {
+			bets = append(bets, bet)
+		}
+	}
+
+	return bets, nil
+}
+
+// bet returns an accepted bet with a stake of 1000 cents on a runner of race 42, at the given prices.
+func bet(betType betting.Bet_Type, runnerNumber int64, win, place float64) *betting.Bet {
+	total := int64(1000)
+	if betType == betting.Bet_EACH_WAY {
+		total = 2000
+	}
+
+	return &betting.Bet{
+		RaceId:       42,
+		RunnerNumber: runnerNumber,
+		Type:         betType,
+		Stake:        1000,
+		TotalStake:   total,
+		WinPrice:     win,
+		PlacePrice:   place,
+		Status:       betting.Bet_ACCEPTED,
+	}
+}
+
+// book returns the bets of the tests: open bets on runners 1, 2 and 3 of race 42, which stand to lose the
+// most on runner 2, along with a settled bet and a bet on another race that do not count.
+func book() []*betting.Bet {
+	settled := bet(betting.Bet_WIN, 1, 2, 0)
+	settled.Status = betting.Bet_WON
+
+	elsewhere := bet(betting.Bet_WIN, 2, 10, 0)
+	elsewhere.RaceId = 43
+
+	return []*betting.Bet{
+		bet(betting.Bet_WIN, 1, 3, 0),
+		bet(betting.Bet_EACH_WAY, 2, 5, 2),
+		bet(betting.Bet_PLACE, 3, 0, 1.5),
+		settled,
+		elsewhere,
+	}
+}
+
+func TestExpose(t *testing.T) {
+	updateTime, _ := ptypes.TimestampProto(now)
+
+	// exposed returns the exposure on race 42 of the bets of book, with runner 2 breaching the threshold if set.
+	exposed := func(threshold int64, breached bool) *risk.Exposure {
+		return &risk.Exposure{
+			RaceId:    42,
+			Bets:      3,
+			Stakes:    4000,
+			Liability: 3000,
+			Threshold: threshold,
+			Runners: []*risk.RunnerExposure{
+				{RunnerNumber: 1, Bets: 1, Stakes: 1000, WinPayout: 3000, Liability: -1000},
+				{RunnerNumber: 2, Bets: 1, Stakes: 2000, WinPayout: 5000, PlacePayout: 2000, Liability: 3000, Breached: breached},
+				{RunnerNumber: 3, Bets: 1, Stakes: 1000, PlacePayout: 1500, Liability: -2500},
+			},
+			UpdateTime: updateTime,
+		}
+	}
+
+	tests := []struct {
+		name      string
+		raceID    int64
+		bets      []*betting.Bet
+		threshold int64
+		want      *risk.Exposure
+		// wantBreached is the runner Breached returns, or 0 for none.
+		wantBreached int64
+	}{
+		{
+			name:      "within the threshold",
+			raceID:    42,
+			bets:      book(),
+			threshold: 5000,
+			want:      exposed(5000, false),
+		},
+		{
+			name:      "at the threshold",
+			raceID:    42,
+			bets:      book(),
+			threshold: 3000,
+			want:      exposed(3000, false),
+		},
+		{
+			name:         "beyond the threshold",
+			raceID:       42,
+			bets:         book(),
+			threshold:    2999,
+			want:         exposed(2999, true),
+			wantBreached: 2,
+		},
+		{
+			name:      "without a threshold",
+			raceID:    42,
+			bets:      book(),
+			threshold: 0,
+			want:      exposed(0, false),
+		},
+		{
+			name:      "no open bets",
+			raceID:    44,
+			bets:      book(),
+			threshold: 1,
+			want:      &risk.Exposure{RaceId: 44, Threshold: 1, UpdateTime: updateTime},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Expose(tt.raceID, tt.bets, tt.threshold, now)
+			if err != nil {
+				t.Fatalf("Expose() = %v", err)
+			}
+
+			if !proto.Equal(got, tt.want) {
+				t.Errorf("Expose() = %v, want %v", got, tt.want)
+			}
+
+			if runner := Breached(got); runner.GetRunnerNumber() != tt.wantBreached {
+				t.Errorf("Breached() = %v, want runner %d", runner, tt.wantBreached)
+			}
+		})
+	}
+}
+
+func TestMonitorUpdate(t *testing.T) {
+	// suspension is one already made on race 42 by a trader.
+	suspension := &racing.Suspension{Id: 1, Scope: racing.Suspension_RACE, RaceId: 42, Actor: "trader", Reason: "protest"}
+
+	tests := []struct {
+		name            string
+		state           racing.Race_State
+		suspensions     []*racing.Suspension
+		threshold       int64
+		suspendFor      time.Duration
+		wantSuspensions int
+		wantResume      bool
+	}{
+		{name: "breached", state: racing.Race_OPEN, threshold: 2000, wantSuspensions: 1},
+		{name: "breached for a while", state: racing.Race_OPEN, threshold: 2000, suspendFor: time.Minute, wantSuspensions: 1, wantResume: true},
+		{name: "within the threshold", state: racing.Race_OPEN, threshold: 5000},
+		{name: "without a threshold", state: racing.Race_OPEN},
+		{name: "already suspended", state: racing.Race_OPEN, suspensions: []*racing.Suspension{suspension}, threshold: 2000, wantSuspensions: 1},
+		{name: "not open", state: racing.Race_CLOSED, threshold: 2000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := client.NewFake(&racing.Race{Id: 42, MeetingId: 1, Name: "Race", State: tt.state, Suspensions: tt.suspensions})
+			m := NewMonitor(&betsRepo{bets: book()}, r, tt.threshold, tt.suspendFor)
+
+			exposures, stop := m.Subscribe()
+			defer stop()
+
+			if err := m.Update(context.Background(), 42); err != nil {
+				t.Fatalf("Update() = %v", err)
+			}
+
+			if exposure := <-exposures; exposure.Liability != 3000 {
+				t.Errorf("published liability = %d, want 3000", exposure.Liability)
+			}
+
+			race, err := r.GetRace(context.Background(), 42)
+			if err != nil {
+				t.Fatalf("GetRace() = %v", err)
+			}
+
+			if race.State != tt.state {
+				t.Errorf("state = %s, want suspending trading to leave it %s", race.State, tt.state)
+			}
+
+			if len(race.Suspensions) != tt.wantSuspensions {
+				t.Fatalf("suspensions = %v, want %d", race.Suspensions, tt.wantSuspensions)
+			}
+
+			if tt.wantSuspensions == 0 || len(tt.suspensions) > 0 {
+				return
+			}
+
+			made := race.Suspensions[0]
+			if made.Scope != racing.Suspension_RACE || made.Actor != Actor || !strings.Contains(made.Reason, "runner 2") {
+				t.Errorf("suspension = %v, want race 42 suspended by %s for runner 2", made, Actor)
+			}
+
+			if resumes := made.ResumeTime != nil; resumes != tt.wantResume {
+				t.Errorf("resume time = %v, want one %t", made.ResumeTime, tt.wantResume)
+			}
+		})
+	}
+}
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"flag"
+	"log"
+	"net"
+
+	"git.neds.sh/matty/entain/betting/bets"
+	"git.neds.sh/matty/entain/betting/cashout"
+	"git.neds.sh/matty/entain/betting/customers"
+	"git.neds.sh/matty/entain/betting/db"
+	"git.neds.sh/matty/entain/betting/exposure"
+	"git.neds.sh/matty/entain/betting/proto/accounts"
+	"git.neds.sh/matty/entain/betting/proto/betting"
+	"git.neds.sh/matty/entain/betting/proto/risk"
+	"git.neds.sh/matty/entain/betting/proto/sports"
+	"git.neds.sh/matty/entain/betting/service"
+	"git.neds.sh/matty/entain/betting/settlement"
+	"git.neds.sh/matty/entain/common/errs"
+	"git.neds.sh/matty/entain/common/validate"
+	"git.neds.sh/matty/entain/racing/client"
+	"google.golang.org/grpc"
+)
+
+// domain identifies the betting service in the ErrorInfo of its errors.
+const domain = "betting.entain.com"
+
+var (
+	grpcEndpoint     = flag.String("grpc-endpoint", "localhost:9100", "gRPC server endpoint")
+	racingEndpoint   = flag.String("racing-endpoint", client.DefaultEndpoint, "gRPC endpoint of the racing service")
+	accountsEndpoint = flag.String("accounts-endpoint", "localhost:9200", "gRPC endpoint of the accounts service, which stakes are paid from and returns paid to")
+	sportsEndpoint   = flag.String("sports-endpoint", "localhost:9300", "gRPC endpoint of the sports service, whose events multis may have legs on")
+	dbPath           = flag.String("db-path", "./db/betting.db", "path to the betting SQLite database")
+
+	priceTolerance = flag.Float64("price-tolerance", bets.DefaultTolerance, "how far, relatively, a price may shorten from the one a bet asks for before it is rejected")
+
+	cashOutMargin    = flag.Float64("cash-out-margin", cashout.DefaultMargin, "share of a bet's value the house keeps when it is cashed out")
+	cashOutTTL       = flag.Duration("cash-out-ttl", cashout.DefaultTTL, "how long a cash-out quote can be executed for")
+	cashOutTolerance = flag.Float64("cash-out-tolerance", cashout.DefaultTolerance, "how far, relatively, a bet's value may fall from its cash-out quote before executing it is rejected")
+
+	liabilityThreshold  = flag.Int64("liability-threshold", exposure.DefaultThreshold, "liability on a runner, in cents, beyond which trading on its race is suspended, or 0 to never suspend races")
+	liabilitySuspension = flag.Duration("liability-suspension", 0, "how long trading on races breaching the liability threshold is suspended for, or 0 until traders resume it")
+
+	settle          = flag.Bool("settle", true, "settle bets as their races are decided")
+	settleInterval  = flag.Duration("settle-interval", settlement.DefaultInterval, "how often to look for races to settle that were missed, and sports events to settle, or 0 to only look on starting and reconnecting")
+	amendmentWindow = flag.Duration("amendment-window", settlement.DefaultAmendmentWindow, "how long after settling a race to keep looking for amended results that were missed")
+)
+
+func main() {
+	flag.Parse()
+
+	if err := run(); err != nil {
+		log.Fatalf("failed running grpc server: %s\n", err)
+	}
+}
+
+func run() error {
+	conn, err := net.Listen("tcp", *grpcEndpoint)
+	if err != nil {
+		return err
+	}
+
+	bettingDB, err := sql.Open("sqlite3", *dbPath)
+	if err != nil {
+		return err
+	}
+
+	betsRepo := db.NewBetsRepo(bettingDB)
+	if err := betsRepo.Init(); err != nil {
+		return err
+	}
+
+	racingClient, err := client.New(context.Background(), client.WithEndpoint(*racingEndpoint))
+	if err != nil {
+		return err
+	}
+	defer racingClient.Close()
+
+	accountsConn, err := grpc.Dial(*accountsEndpoint, grpc.WithInsecure())
+	if err != nil {
+		return err
+	}
+	defer accountsConn.Close()
+
+	accountsClient := accounts.NewAccountsClient(accountsConn)
+
+	sportsConn, err := grpc.Dial(*sportsEndpoint, grpc.WithInsecure())
+	if err != nil {
+		return err
+	}
+	defer sportsConn.Close()
+
+	sportsClient := sports.NewSportsClient(sportsConn)
+
+	if *settle {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		worker := settlement.NewWorker(betsRepo, racingClient, accountsClient, sportsClient, *settleInterval, *amendmentWindow)
+
+		go func() {
+			if err := worker.Run(ctx); err != nil && ctx.Err() == nil {
+				log.Printf("settlement stopped: %s\n", err)
+			}
+		}()
+	}
+
+	// Errors are converted to statuses outermost, so that they cover validation failures too.
+	grpcServer := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(errs.UnaryServerInterceptor(domain), customers.UnaryServerInterceptor(), validate.UnaryServerInterceptor()),
+		grpc.ChainStreamInterceptor(errs.StreamServerInterceptor(domain), validate.StreamServerInterceptor()),
+	)
+
+	monitor := exposure.NewMonitor(betsRepo, racingClient, *liabilityThreshold, *liabilitySuspension)
+
+	betting.RegisterBettingServer(
+		grpcServer,
+		service.NewBettingService(betsRepo, racingClient, accountsClient, sportsClient, *priceTolerance, cashout.Terms{
+			Margin:    *cashOutMargin,
+			TTL:       *cashOutTTL,
+			Tolerance: *cashOutTolerance,
+		}, monitor),
+	)
+
+	risk.RegisterRiskServer(
+		grpcServer,
+		service.NewRiskService(monitor),
+	)
+
+	log.Printf("gRPC server listening on: %s\n", *grpcEndpoint)
+
+	return grpcServer.Serve(conn)
+}
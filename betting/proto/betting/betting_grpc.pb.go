@@ -0,0 +1,399 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+
+package betting
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// BettingClient is the client API for Betting service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type BettingClient interface {
+	// PlaceBet will place a fixed-odds bet on a runner of an open race, striking it at the runner's current
+	// prices as long as they have not shortened by more than the service's tolerance from those the customer
+	// was offered. Placing a bet again with the same idempotency key returns the bet already placed.
+	PlaceBet(ctx context.Context, in *PlaceBetRequest, opts ...grpc.CallOption) (*Bet, error)
+	// GetBet will return a single bet by ID. Through the api gateway, only the customer who placed it may.
+	GetBet(ctx context.Context, in *GetBetRequest, opts ...grpc.CallOption) (*Bet, error)
+	// ListBetSettlements will return every settlement of a bet, oldest first: the first once its race is
+	// final or abandoned, and another each time an amended result changes what it pays. Through the api
+	// gateway, only the customer who placed it may.
+	ListBetSettlements(ctx context.Context, in *ListBetSettlementsRequest, opts ...grpc.CallOption) (*ListBetSettlementsResponse, error)
+	// QuoteCashOut will quote what an accepted bet can be cashed out for before its race jumps: its value at
+	// the runner's current prices, less the service's margin. The quote can be executed until it expires.
+	// Through the api gateway, only the customer who placed the bet may.
+	QuoteCashOut(ctx context.Context, in *QuoteCashOutRequest, opts ...grpc.CallOption) (*CashOutQuote, error)
+	// ExecuteCashOut will cash out a bet for the amount of a quote, settling it at once, as long as the quote
+	// has not expired, the race is still open, and the bet's value at the runner's current prices has not
+	// fallen by more than the service's tolerance from the quote. Cashed out bets are not settled on their
+	// race. Executing a quote again returns the bet it cashed out. Through the api gateway, only the customer
+	// who placed the bet may.
+	ExecuteCashOut(ctx context.Context, in *ExecuteCashOutRequest, opts ...grpc.CallOption) (*Bet, error)
+	// PlaceMulti will place a multi: one bet on every one of its legs coming in, each a runner winning a race
+	// or an outcome of a sports event, at the product of their prices. Each leg is struck at its current
+	// price as long as it has not shortened by more than the service's tolerance from the price offered.
+	// Placing a multi again with the same idempotency key returns the multi already placed.
+	PlaceMulti(ctx context.Context, in *PlaceMultiRequest, opts ...grpc.CallOption) (*Multi, error)
+	// GetMulti will return a single multi by ID, with its legs. Through the api gateway, only the customer who
+	// placed it may.
+	GetMulti(ctx context.Context, in *GetMultiRequest, opts ...grpc.CallOption) (*Multi, error)
+	// ListMultiSettlements will return every settlement of a multi, oldest first: the first once a leg loses
+	// or every leg is settled, and another each time an amended result changes what it pays. Through the api
+	// gateway, only the customer who placed it may.
+	ListMultiSettlements(ctx context.Context, in *ListMultiSettlementsRequest, opts ...grpc.CallOption) (*ListMultiSettlementsResponse, error)
+}
+
+type bettingClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewBettingClient(cc grpc.ClientConnInterface) BettingClient {
+	return &bettingClient{cc}
+}
+
+func (c *bettingClient) PlaceBet(ctx context.Context, in *PlaceBetRequest, opts ...grpc.CallOption) (*Bet, error) {
+	out := new(Bet)
+	err := c.cc.Invoke(ctx, "/betting.Betting/PlaceBet", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bettingClient) GetBet(ctx context.Context, in *GetBetRequest, opts ...grpc.CallOption) (*Bet, error) {
+	out := new(Bet)
+	err := c.cc.Invoke(ctx, "/betting.Betting/GetBet", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bettingClient) ListBetSettlements(ctx context.Context, in *ListBetSettlementsRequest, opts ...grpc.CallOption) (*ListBetSettlementsResponse, error) {
+	out := new(ListBetSettlementsResponse)
+	err := c.cc.Invoke(ctx, "/betting.Betting/ListBetSettlements", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bettingClient) QuoteCashOut(ctx context.Context, in *QuoteCashOutRequest, opts ...grpc.CallOption) (*CashOutQuote, error) {
+	out := new(CashOutQuote)
+	err := c.cc.Invoke(ctx, "/betting.Betting/QuoteCashOut", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bettingClient) ExecuteCashOut(ctx context.Context, in *ExecuteCashOutRequest, opts ...grpc.CallOption) (*Bet, error) {
+	out := new(Bet)
+	err := c.cc.Invoke(ctx, "/betting.Betting/ExecuteCashOut", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bettingClient) PlaceMulti(ctx context.Context, in *PlaceMultiRequest, opts ...grpc.CallOption) (*Multi, error) {
+	out := new(Multi)
+	err := c.cc.Invoke(ctx, "/betting.Betting/PlaceMulti", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bettingClient) GetMulti(ctx context.Context, in *GetMultiRequest, opts ...grpc.CallOption) (*Multi, error) {
+	out := new(Multi)
+	err := c.cc.Invoke(ctx, "/betting.Betting/GetMulti", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bettingClient) ListMultiSettlements(ctx context.Context, in *ListMultiSettlementsRequest, opts ...grpc.CallOption) (*ListMultiSettlementsResponse, error) {
+	out := new(ListMultiSettlementsResponse)
+	err := c.cc.Invoke(ctx, "/betting.Betting/ListMultiSettlements", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// BettingServer is the server API for Betting service.
+// All implementations should embed UnimplementedBettingServer
+// for forward compatibility
+type BettingServer interface {
+	// PlaceBet will place a fixed-odds bet on a runner of an open race, striking it at the runner's current
+	// prices as long as they have not shortened by more than the service's tolerance from those the customer
+	// was offered. Placing a bet again with the same idempotency key returns the bet already placed.
+	PlaceBet(context.Context, *PlaceBetRequest) (*Bet, error)
+	// GetBet will return a single bet by ID. Through the api gateway, only the customer who placed it may.
+	GetBet(context.Context, *GetBetRequest) (*Bet, error)
+	// ListBetSettlements will return every settlement of a bet, oldest first: the first once its race is
+	// final or abandoned, and another each time an amended result changes what it pays. Through the api
+	// gateway, only the customer who placed it may.
+	ListBetSettlements(context.Context, *ListBetSettlementsRequest) (*ListBetSettlementsResponse, error)
+	// QuoteCashOut will quote what an accepted bet can be cashed out for before its race jumps: its value at
+	// the runner's current prices, less the service's margin. The quote can be executed until it expires.
+	// Through the api gateway, only the customer who placed the bet may.
+	QuoteCashOut(context.Context, *QuoteCashOutRequest) (*CashOutQuote, error)
+	// ExecuteCashOut will cash out a bet for the amount of a quote, settling it at once, as long as the quote
+	// has not expired, the race is still open, and the bet's value at the runner's current prices has not
+	// fallen by more than the service's tolerance from the quote. Cashed out bets are not settled on their
+	// race. Executing a quote again returns the bet it cashed out. Through the api gateway, only the customer
+	// who placed the bet may.
+	ExecuteCashOut(context.Context, *ExecuteCashOutRequest) (*Bet, error)
+	// PlaceMulti will place a multi: one bet on every one of its legs coming in, each a runner winning a race
+	// or an outcome of a sports event, at the product of their prices. Each leg is struck at its current
+	// price as long as it has not shortened by more than the service's tolerance from the price offered.
+	// Placing a multi again with the same idempotency key returns the multi already placed.
+	PlaceMulti(context.Context, *PlaceMultiRequest) (*Multi, error)
+	// GetMulti will return a single multi by ID, with its legs. Through the api gateway, only the customer who
+	// placed it may.
+	GetMulti(context.Context, *GetMultiRequest) (*Multi, error)
+	// ListMultiSettlements will return every settlement of a multi, oldest first: the first once a leg loses
+	// or every leg is settled, and another each time an amended result changes what it pays. Through the api
+	// gateway, only the customer who placed it may.
+	ListMultiSettlements(context.Context, *ListMultiSettlementsRequest) (*ListMultiSettlementsResponse, error)
+}
+
+// UnimplementedBettingServer should be embedded to have forward compatible implementations.
+type UnimplementedBettingServer struct {
+}
+
+func (UnimplementedBettingServer) PlaceBet(context.Context, *PlaceBetRequest) (*Bet, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PlaceBet not implemented")
+}
+func (UnimplementedBettingServer) GetBet(context.Context, *GetBetRequest) (*Bet, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBet not implemented")
+}
+func (UnimplementedBettingServer) ListBetSettlements(context.Context, *ListBetSettlementsRequest) (*ListBetSettlementsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListBetSettlements not implemented")
+}
+func (UnimplementedBettingServer) QuoteCashOut(context.Context, *QuoteCashOutRequest) (*CashOutQuote, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method QuoteCashOut not implemented")
+}
+func (UnimplementedBettingServer) ExecuteCashOut(context.Context, *ExecuteCashOutRequest) (*Bet, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExecuteCashOut not implemented")
+}
+func (UnimplementedBettingServer) PlaceMulti(context.Context, *PlaceMultiRequest) (*Multi, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PlaceMulti not implemented")
+}
+func (UnimplementedBettingServer) GetMulti(context.Context, *GetMultiRequest) (*Multi, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMulti not implemented")
+}
+func (UnimplementedBettingServer) ListMultiSettlements(context.Context, *ListMultiSettlementsRequest) (*ListMultiSettlementsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListMultiSettlements not implemented")
+}
+
+// UnsafeBettingServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to BettingServer will
+// result in compilation errors.
+type UnsafeBettingServer interface {
+	mustEmbedUnimplementedBettingServer()
+}
+
+func RegisterBettingServer(s grpc.ServiceRegistrar, srv BettingServer) {
+	s.RegisterService(&Betting_ServiceDesc, srv)
+}
+
+func _Betting_PlaceBet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PlaceBetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BettingServer).PlaceBet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/betting.Betting/PlaceBet",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BettingServer).PlaceBet(ctx, req.(*PlaceBetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Betting_GetBet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BettingServer).GetBet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/betting.Betting/GetBet",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BettingServer).GetBet(ctx, req.(*GetBetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Betting_ListBetSettlements_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListBetSettlementsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BettingServer).ListBetSettlements(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/betting.Betting/ListBetSettlements",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BettingServer).ListBetSettlements(ctx, req.(*ListBetSettlementsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Betting_QuoteCashOut_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QuoteCashOutRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BettingServer).QuoteCashOut(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/betting.Betting/QuoteCashOut",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BettingServer).QuoteCashOut(ctx, req.(*QuoteCashOutRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Betting_ExecuteCashOut_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExecuteCashOutRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BettingServer).ExecuteCashOut(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/betting.Betting/ExecuteCashOut",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BettingServer).ExecuteCashOut(ctx, req.(*ExecuteCashOutRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Betting_PlaceMulti_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PlaceMultiRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BettingServer).PlaceMulti(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/betting.Betting/PlaceMulti",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BettingServer).PlaceMulti(ctx, req.(*PlaceMultiRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Betting_GetMulti_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMultiRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BettingServer).GetMulti(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/betting.Betting/GetMulti",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BettingServer).GetMulti(ctx, req.(*GetMultiRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Betting_ListMultiSettlements_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListMultiSettlementsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BettingServer).ListMultiSettlements(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/betting.Betting/ListMultiSettlements",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BettingServer).ListMultiSettlements(ctx, req.(*ListMultiSettlementsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Betting_ServiceDesc is the grpc.ServiceDesc for Betting service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Betting_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "betting.Betting",
+	HandlerType: (*BettingServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "PlaceBet",
+			Handler:    _Betting_PlaceBet_Handler,
+		},
+		{
+			MethodName: "GetBet",
+			Handler:    _Betting_GetBet_Handler,
+		},
+		{
+			MethodName: "ListBetSettlements",
+			Handler:    _Betting_ListBetSettlements_Handler,
+		},
+		{
+			MethodName: "QuoteCashOut",
+			Handler:    _Betting_QuoteCashOut_Handler,
+		},
+		{
+			MethodName: "ExecuteCashOut",
+			Handler:    _Betting_ExecuteCashOut_Handler,
+		},
+		{
+			MethodName: "PlaceMulti",
+			Handler:    _Betting_PlaceMulti_Handler,
+		},
+		{
+			MethodName: "GetMulti",
+			Handler:    _Betting_GetMulti_Handler,
+		},
+		{
+			MethodName: "ListMultiSettlements",
+			Handler:    _Betting_ListMultiSettlements_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "betting/betting.proto",
+}
@@ -0,0 +1,509 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.25.0-devel
+// 	protoc        v3.13.0
+// source: risk/risk.proto
+
+package risk
+
+import (
+	_ "git.neds.sh/matty/entain/common/proto/validate"
+	timestamp "github.com/golang/protobuf/ptypes/timestamp"
+	_ "github.com/grpc-ecosystem/grpc-gateway/v2/protoc-gen-openapiv2/options"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Request for GetExposure call.
+type GetExposureRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// RaceID is the race whose exposure to return.
+	RaceId int64 `protobuf:"varint,1,opt,name=race_id,json=raceId,proto3" json:"race_id,omitempty"`
+}
+
+func (x *GetExposureRequest) Reset() {
+	*x = GetExposureRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_risk_risk_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetExposureRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetExposureRequest) ProtoMessage() {}
+
+func (x *GetExposureRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_risk_risk_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetExposureRequest.ProtoReflect.Descriptor instead.
+func (*GetExposureRequest) Descriptor() ([]byte, []int) {
+	return file_risk_risk_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *GetExposureRequest) GetRaceId() int64 {
+	if x != nil {
+		return x.RaceId
+	}
+	return 0
+}
+
+// Request for WatchExposure call.
+type WatchExposureRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// RaceIDs limits the changes streamed to those of the given races, or every race if none are given.
+	RaceIds []int64 `protobuf:"varint,1,rep,packed,name=race_ids,json=raceIds,proto3" json:"race_ids,omitempty"`
+}
+
+func (x *WatchExposureRequest) Reset() {
+	*x = WatchExposureRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_risk_risk_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WatchExposureRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchExposureRequest) ProtoMessage() {}
+
+func (x *WatchExposureRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_risk_risk_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchExposureRequest.ProtoReflect.Descriptor instead.
+func (*WatchExposureRequest) Descriptor() ([]byte, []int) {
+	return file_risk_risk_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *WatchExposureRequest) GetRaceIds() []int64 {
+	if x != nil {
+		return x.RaceIds
+	}
+	return nil
+}
+
+// The exposure of the house on a race, from the fixed-odds bets open on it: those accepted, and not yet
+// settled or cashed out. Multis are not counted.
+type Exposure struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// RaceID is the race.
+	RaceId int64 `protobuf:"varint,1,opt,name=race_id,json=raceId,proto3" json:"race_id,omitempty"`
+	// Bets is how many bets are open on the race.
+	Bets int64 `protobuf:"varint,2,opt,name=bets,proto3" json:"bets,omitempty"`
+	// Stakes is the total staked on the race by those bets, in cents.
+	Stakes int64 `protobuf:"varint,3,opt,name=stakes,proto3" json:"stakes,omitempty"`
+	// Liability is the most the house stands to lose on the race, in cents: the largest liability of its
+	// runners, or 0 if it stands to win whichever runner wins.
+	Liability int64 `protobuf:"varint,4,opt,name=liability,proto3" json:"liability,omitempty"`
+	// Threshold is the liability on a runner beyond which the race is suspended, in cents, or 0 if races are
+	// never suspended for their liability.
+	Threshold int64 `protobuf:"varint,5,opt,name=threshold,proto3" json:"threshold,omitempty"`
+	// Runners are the runners bets are open on, by runner number.
+	Runners []*RunnerExposure `protobuf:"bytes,6,rep,name=runners,proto3" json:"runners,omitempty"`
+	// UpdateTime is when the exposure was worked out.
+	UpdateTime *timestamp.Timestamp `protobuf:"bytes,7,opt,name=update_time,json=updateTime,proto3" json:"update_time,omitempty"`
+}
+
+func (x *Exposure) Reset() {
+	*x = Exposure{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_risk_risk_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Exposure) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Exposure) ProtoMessage() {}
+
+func (x *Exposure) ProtoReflect() protoreflect.Message {
+	mi := &file_risk_risk_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Exposure.ProtoReflect.Descriptor instead.
+func (*Exposure) Descriptor() ([]byte, []int) {
+	return file_risk_risk_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Exposure) GetRaceId() int64 {
+	if x != nil {
+		return x.RaceId
+	}
+	return 0
+}
+
+func (x *Exposure) GetBets() int64 {
+	if x != nil {
+		return x.Bets
+	}
+	return 0
+}
+
+func (x *Exposure) GetStakes() int64 {
+	if x != nil {
+		return x.Stakes
+	}
+	return 0
+}
+
+func (x *Exposure) GetLiability() int64 {
+	if x != nil {
+		return x.Liability
+	}
+	return 0
+}
+
+func (x *Exposure) GetThreshold() int64 {
+	if x != nil {
+		return x.Threshold
+	}
+	return 0
+}
+
+func (x *Exposure) GetRunners() []*RunnerExposure {
+	if x != nil {
+		return x.Runners
+	}
+	return nil
+}
+
+func (x *Exposure) GetUpdateTime() *timestamp.Timestamp {
+	if x != nil {
+		return x.UpdateTime
+	}
+	return nil
+}
+
+// The exposure of the house on one runner of a race.
+type RunnerExposure struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// RunnerNumber is the runner.
+	RunnerNumber int64 `protobuf:"varint,1,opt,name=runner_number,json=runnerNumber,proto3" json:"runner_number,omitempty"`
+	// Bets is how many bets are open on the runner.
+	Bets int64 `protobuf:"varint,2,opt,name=bets,proto3" json:"bets,omitempty"`
+	// Stakes is the total staked on the runner, in cents, counting both the win and the place of each-way bets.
+	Stakes int64 `protobuf:"varint,3,opt,name=stakes,proto3" json:"stakes,omitempty"`
+	// WinPayout is what the bets on the runner pay if it wins, on their win, in cents, including their stakes.
+	WinPayout int64 `protobuf:"varint,4,opt,name=win_payout,json=winPayout,proto3" json:"win_payout,omitempty"`
+	// PlacePayout is what the bets on the runner pay if it places, on their place, in cents, including their
+	// stakes.
+	PlacePayout int64 `protobuf:"varint,5,opt,name=place_payout,json=placePayout,proto3" json:"place_payout,omitempty"`
+	// Liability is what the house loses if the runner wins, in cents: what the bets on it pay, less everything
+	// staked on the race. It is negative when the house would still come out ahead. The place bets on the
+	// other runners that place are not counted, as which of them will place is not known.
+	Liability int64 `protobuf:"varint,6,opt,name=liability,proto3" json:"liability,omitempty"`
+	// Breached reports whether the liability is beyond the threshold.
+	Breached bool `protobuf:"varint,7,opt,name=breached,proto3" json:"breached,omitempty"`
+}
+
+func (x *RunnerExposure) Reset() {
+	*x = RunnerExposure{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_risk_risk_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RunnerExposure) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RunnerExposure) ProtoMessage() {}
+
+func (x *RunnerExposure) ProtoReflect() protoreflect.Message {
+	mi := &file_risk_risk_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RunnerExposure.ProtoReflect.Descriptor instead.
+func (*RunnerExposure) Descriptor() ([]byte, []int) {
+	return file_risk_risk_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *RunnerExposure) GetRunnerNumber() int64 {
+	if x != nil {
+		return x.RunnerNumber
+	}
+	return 0
+}
+
+func (x *RunnerExposure) GetBets() int64 {
+	if x != nil {
+		return x.Bets
+	}
+	return 0
+}
+
+func (x *RunnerExposure) GetStakes() int64 {
+	if x != nil {
+		return x.Stakes
+	}
+	return 0
+}
+
+func (x *RunnerExposure) GetWinPayout() int64 {
+	if x != nil {
+		return x.WinPayout
+	}
+	return 0
+}
+
+func (x *RunnerExposure) GetPlacePayout() int64 {
+	if x != nil {
+		return x.PlacePayout
+	}
+	return 0
+}
+
+func (x *RunnerExposure) GetLiability() int64 {
+	if x != nil {
+		return x.Liability
+	}
+	return 0
+}
+
+func (x *RunnerExposure) GetBreached() bool {
+	if x != nil {
+		return x.Breached
+	}
+	return false
+}
+
+var File_risk_risk_proto protoreflect.FileDescriptor
+
+var file_risk_risk_proto_rawDesc = []byte{
+	0x0a, 0x0f, 0x72, 0x69, 0x73, 0x6b, 0x2f, 0x72, 0x69, 0x73, 0x6b, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x12, 0x04, 0x72, 0x69, 0x73, 0x6b, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
+	0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63,
+	0x2d, 0x67, 0x65, 0x6e, 0x2d, 0x6f, 0x70, 0x65, 0x6e, 0x61, 0x70, 0x69, 0x76, 0x32, 0x2f, 0x6f,
+	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2f, 0x61, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x17, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61,
+	0x74, 0x65, 0x2f, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x22, 0x37, 0x0a, 0x12, 0x47, 0x65, 0x74, 0x45, 0x78, 0x70, 0x6f, 0x73, 0x75, 0x72, 0x65,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x21, 0x0a, 0x07, 0x72, 0x61, 0x63, 0x65, 0x5f,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x42, 0x08, 0xd2, 0xf5, 0x18, 0x04, 0x12, 0x02,
+	0x08, 0x00, 0x52, 0x06, 0x72, 0x61, 0x63, 0x65, 0x49, 0x64, 0x22, 0x43, 0x0a, 0x14, 0x57, 0x61,
+	0x74, 0x63, 0x68, 0x45, 0x78, 0x70, 0x6f, 0x73, 0x75, 0x72, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x2b, 0x0a, 0x08, 0x72, 0x61, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x03, 0x42, 0x10, 0xd2, 0xf5, 0x18, 0x0c, 0x22, 0x0a, 0x10, 0x64, 0x18, 0x01,
+	0x22, 0x04, 0x12, 0x02, 0x08, 0x00, 0x52, 0x07, 0x72, 0x61, 0x63, 0x65, 0x49, 0x64, 0x73, 0x22,
+	0xa4, 0x02, 0x0a, 0x08, 0x45, 0x78, 0x70, 0x6f, 0x73, 0x75, 0x72, 0x65, 0x12, 0x17, 0x0a, 0x07,
+	0x72, 0x61, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x72,
+	0x61, 0x63, 0x65, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x62, 0x65, 0x74, 0x73, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x04, 0x62, 0x65, 0x74, 0x73, 0x12, 0x24, 0x0a, 0x06, 0x73, 0x74, 0x61,
+	0x6b, 0x65, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x42, 0x0c, 0x92, 0x41, 0x09, 0x4a, 0x07,
+	0x22, 0x34, 0x32, 0x30, 0x30, 0x30, 0x22, 0x52, 0x06, 0x73, 0x74, 0x61, 0x6b, 0x65, 0x73, 0x12,
+	0x2a, 0x0a, 0x09, 0x6c, 0x69, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x03, 0x42, 0x0c, 0x92, 0x41, 0x09, 0x4a, 0x07, 0x22, 0x31, 0x38, 0x35, 0x30, 0x30, 0x22,
+	0x52, 0x09, 0x6c, 0x69, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x12, 0x2c, 0x0a, 0x09, 0x74,
+	0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x42, 0x0e,
+	0x92, 0x41, 0x0b, 0x4a, 0x09, 0x22, 0x35, 0x30, 0x30, 0x30, 0x30, 0x30, 0x30, 0x22, 0x52, 0x09,
+	0x74, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x12, 0x2e, 0x0a, 0x07, 0x72, 0x75, 0x6e,
+	0x6e, 0x65, 0x72, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x72, 0x69, 0x73,
+	0x6b, 0x2e, 0x52, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x45, 0x78, 0x70, 0x6f, 0x73, 0x75, 0x72, 0x65,
+	0x52, 0x07, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x73, 0x12, 0x3b, 0x0a, 0x0b, 0x75, 0x70, 0x64,
+	0x61, 0x74, 0x65, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0a, 0x75, 0x70, 0x64, 0x61,
+	0x74, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x22, 0x93, 0x02, 0x0a, 0x0e, 0x52, 0x75, 0x6e, 0x6e, 0x65,
+	0x72, 0x45, 0x78, 0x70, 0x6f, 0x73, 0x75, 0x72, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x72, 0x75, 0x6e,
+	0x6e, 0x65, 0x72, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x0c, 0x72, 0x75, 0x6e, 0x6e, 0x65, 0x72, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x12,
+	0x0a, 0x04, 0x62, 0x65, 0x74, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x62, 0x65,
+	0x74, 0x73, 0x12, 0x24, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x6b, 0x65, 0x73, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x03, 0x42, 0x0c, 0x92, 0x41, 0x09, 0x4a, 0x07, 0x22, 0x31, 0x32, 0x30, 0x30, 0x30, 0x22,
+	0x52, 0x06, 0x73, 0x74, 0x61, 0x6b, 0x65, 0x73, 0x12, 0x2b, 0x0a, 0x0a, 0x77, 0x69, 0x6e, 0x5f,
+	0x70, 0x61, 0x79, 0x6f, 0x75, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x42, 0x0c, 0x92, 0x41,
+	0x09, 0x4a, 0x07, 0x22, 0x34, 0x32, 0x30, 0x30, 0x30, 0x22, 0x52, 0x09, 0x77, 0x69, 0x6e, 0x50,
+	0x61, 0x79, 0x6f, 0x75, 0x74, 0x12, 0x2e, 0x0a, 0x0c, 0x70, 0x6c, 0x61, 0x63, 0x65, 0x5f, 0x70,
+	0x61, 0x79, 0x6f, 0x75, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x42, 0x0b, 0x92, 0x41, 0x08,
+	0x4a, 0x06, 0x22, 0x39, 0x36, 0x30, 0x30, 0x22, 0x52, 0x0b, 0x70, 0x6c, 0x61, 0x63, 0x65, 0x50,
+	0x61, 0x79, 0x6f, 0x75, 0x74, 0x12, 0x29, 0x0a, 0x09, 0x6c, 0x69, 0x61, 0x62, 0x69, 0x6c, 0x69,
+	0x74, 0x79, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x42, 0x0b, 0x92, 0x41, 0x08, 0x4a, 0x06, 0x22,
+	0x39, 0x36, 0x30, 0x30, 0x22, 0x52, 0x09, 0x6c, 0x69, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79,
+	0x12, 0x1a, 0x0a, 0x08, 0x62, 0x72, 0x65, 0x61, 0x63, 0x68, 0x65, 0x64, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x08, 0x62, 0x72, 0x65, 0x61, 0x63, 0x68, 0x65, 0x64, 0x32, 0x82, 0x01, 0x0a,
+	0x04, 0x52, 0x69, 0x73, 0x6b, 0x12, 0x39, 0x0a, 0x0b, 0x47, 0x65, 0x74, 0x45, 0x78, 0x70, 0x6f,
+	0x73, 0x75, 0x72, 0x65, 0x12, 0x18, 0x2e, 0x72, 0x69, 0x73, 0x6b, 0x2e, 0x47, 0x65, 0x74, 0x45,
+	0x78, 0x70, 0x6f, 0x73, 0x75, 0x72, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0e,
+	0x2e, 0x72, 0x69, 0x73, 0x6b, 0x2e, 0x45, 0x78, 0x70, 0x6f, 0x73, 0x75, 0x72, 0x65, 0x22, 0x00,
+	0x12, 0x3f, 0x0a, 0x0d, 0x57, 0x61, 0x74, 0x63, 0x68, 0x45, 0x78, 0x70, 0x6f, 0x73, 0x75, 0x72,
+	0x65, 0x12, 0x1a, 0x2e, 0x72, 0x69, 0x73, 0x6b, 0x2e, 0x57, 0x61, 0x74, 0x63, 0x68, 0x45, 0x78,
+	0x70, 0x6f, 0x73, 0x75, 0x72, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0e, 0x2e,
+	0x72, 0x69, 0x73, 0x6b, 0x2e, 0x45, 0x78, 0x70, 0x6f, 0x73, 0x75, 0x72, 0x65, 0x22, 0x00, 0x30,
+	0x01, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_risk_risk_proto_rawDescOnce sync.Once
+	file_risk_risk_proto_rawDescData = file_risk_risk_proto_rawDesc
+)
+
+func file_risk_risk_proto_rawDescGZIP() []byte {
+	file_risk_risk_proto_rawDescOnce.Do(func() {
+		file_risk_risk_proto_rawDescData = protoimpl.X.CompressGZIP(file_risk_risk_proto_rawDescData)
+	})
+	return file_risk_risk_proto_rawDescData
+}
+
+var file_risk_risk_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_risk_risk_proto_goTypes = []interface{}{
+	(*GetExposureRequest)(nil),   // 0: risk.GetExposureRequest
+	(*WatchExposureRequest)(nil), // 1: risk.WatchExposureRequest
+	(*Exposure)(nil),             // 2: risk.Exposure
+	(*RunnerExposure)(nil),       // 3: risk.RunnerExposure
+	(*timestamp.Timestamp)(nil),  // 4: google.protobuf.Timestamp
+}
+var file_risk_risk_proto_depIdxs = []int32{
+	3, // 0: risk.Exposure.runners:type_name -> risk.RunnerExposure
+	4, // 1: risk.Exposure.update_time:type_name -> google.protobuf.Timestamp
+	0, // 2: risk.Risk.GetExposure:input_type -> risk.GetExposureRequest
+	1, // 3: risk.Risk.WatchExposure:input_type -> risk.WatchExposureRequest
+	2, // 4: risk.Risk.GetExposure:output_type -> risk.Exposure
+	2, // 5: risk.Risk.WatchExposure:output_type -> risk.Exposure
+	4, // [4:6] is the sub-list for method output_type
+	2, // [2:4] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_risk_risk_proto_init() }
+func file_risk_risk_proto_init() {
+	if File_risk_risk_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_risk_risk_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetExposureRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_risk_risk_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WatchExposureRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_risk_risk_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Exposure); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_risk_risk_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RunnerExposure); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_risk_risk_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   4,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_risk_risk_proto_goTypes,
+		DependencyIndexes: file_risk_risk_proto_depIdxs,
+		MessageInfos:      file_risk_risk_proto_msgTypes,
+	}.Build()
+	File_risk_risk_proto = out.File
+	file_risk_risk_proto_rawDesc = nil
+	file_risk_risk_proto_goTypes = nil
+	file_risk_risk_proto_depIdxs = nil
+}
@@ -0,0 +1,169 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+
+package risk
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// RiskClient is the client API for Risk service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type RiskClient interface {
+	// GetExposure will return the exposure of the house on a race from the bets open on it.
+	GetExposure(ctx context.Context, in *GetExposureRequest, opts ...grpc.CallOption) (*Exposure, error)
+	// WatchExposure will stream the exposure on races each time bets placed or cashed out change it, from
+	// now on, until the client goes away. The exposure on each race asked for is sent first as it is now.
+	WatchExposure(ctx context.Context, in *WatchExposureRequest, opts ...grpc.CallOption) (Risk_WatchExposureClient, error)
+}
+
+type riskClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewRiskClient(cc grpc.ClientConnInterface) RiskClient {
+	return &riskClient{cc}
+}
+
+func (c *riskClient) GetExposure(ctx context.Context, in *GetExposureRequest, opts ...grpc.CallOption) (*Exposure, error) {
+	out := new(Exposure)
+	err := c.cc.Invoke(ctx, "/risk.Risk/GetExposure", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *riskClient) WatchExposure(ctx context.Context, in *WatchExposureRequest, opts ...grpc.CallOption) (Risk_WatchExposureClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Risk_ServiceDesc.Streams[0], "/risk.Risk/WatchExposure", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &riskWatchExposureClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Risk_WatchExposureClient interface {
+	Recv() (*Exposure, error)
+	grpc.ClientStream
+}
+
+type riskWatchExposureClient struct {
+	grpc.ClientStream
+}
+
+func (x *riskWatchExposureClient) Recv() (*Exposure, error) {
+	m := new(Exposure)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// RiskServer is the server API for Risk service.
+// All implementations should embed UnimplementedRiskServer
+// for forward compatibility
+type RiskServer interface {
+	// GetExposure will return the exposure of the house on a race from the bets open on it.
+	GetExposure(context.Context, *GetExposureRequest) (*Exposure, error)
+	// WatchExposure will stream the exposure on races each time bets placed or cashed out change it, from
+	// now on, until the client goes away. The exposure on each race asked for is sent first as it is now.
+	WatchExposure(*WatchExposureRequest, Risk_WatchExposureServer) error
+}
+
+// UnimplementedRiskServer should be embedded to have forward compatible implementations.
+type UnimplementedRiskServer struct {
+}
+
+func (UnimplementedRiskServer) GetExposure(context.Context, *GetExposureRequest) (*Exposure, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetExposure not implemented")
+}
+func (UnimplementedRiskServer) WatchExposure(*WatchExposureRequest, Risk_WatchExposureServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchExposure not implemented")
+}
+
+// UnsafeRiskServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to RiskServer will
+// result in compilation errors.
+type UnsafeRiskServer interface {
+	mustEmbedUnimplementedRiskServer()
+}
+
+func RegisterRiskServer(s grpc.ServiceRegistrar, srv RiskServer) {
+	s.RegisterService(&Risk_ServiceDesc, srv)
+}
+
+func _Risk_GetExposure_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetExposureRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RiskServer).GetExposure(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/risk.Risk/GetExposure",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RiskServer).GetExposure(ctx, req.(*GetExposureRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Risk_WatchExposure_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchExposureRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(RiskServer).WatchExposure(m, &riskWatchExposureServer{stream})
+}
+
+type Risk_WatchExposureServer interface {
+	Send(*Exposure) error
+	grpc.ServerStream
+}
+
+type riskWatchExposureServer struct {
+	grpc.ServerStream
+}
+
+func (x *riskWatchExposureServer) Send(m *Exposure) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// Risk_ServiceDesc is the grpc.ServiceDesc for Risk service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Risk_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "risk.Risk",
+	HandlerType: (*RiskServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetExposure",
+			Handler:    _Risk_GetExposure_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchExposure",
+			Handler:       _Risk_WatchExposure_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "risk/risk.proto",
+}
@@ -0,0 +1,403 @@
+package service
+
+import (
+	"log"
+	"strconv"
+	"time"
+
+	"git.neds.sh/matty/entain/betting/bets"
+	"git.neds.sh/matty/entain/betting/cashout"
+	"git.neds.sh/matty/entain/betting/customers"
+	"git.neds.sh/matty/entain/betting/db"
+	"git.neds.sh/matty/entain/betting/exposure"
+	"git.neds.sh/matty/entain/betting/proto/accounts"
+	"git.neds.sh/matty/entain/betting/proto/betting"
+	"git.neds.sh/matty/entain/betting/proto/sports"
+	"git.neds.sh/matty/entain/betting/settlement"
+	"git.neds.sh/matty/entain/common/errs"
+	"git.neds.sh/matty/entain/racing/client"
+	"git.neds.sh/matty/entain/racing/proto/racing"
+	"golang.org/x/net/context"
+)
+
+type Betting interface {
+	// PlaceBet will place a fixed-odds bet on a runner.
+	PlaceBet(ctx context.Context, in *betting.PlaceBetRequest) (*betting.Bet, error)
+
+	// GetBet will return a single bet by ID.
+	GetBet(ctx context.Context, in *betting.GetBetRequest) (*betting.Bet, error)
+
+	// ListBetSettlements will return the settlements of a bet.
+	ListBetSettlements(ctx context.Context, in *betting.ListBetSettlementsRequest) (*betting.ListBetSettlementsResponse, error)
+
+	// QuoteCashOut will quote what a bet can be cashed out for.
+	QuoteCashOut(ctx context.Context, in *betting.QuoteCashOutRequest) (*betting.CashOutQuote, error)
+
+	// ExecuteCashOut will cash out a bet for a quote.
+	ExecuteCashOut(ctx context.Context, in *betting.ExecuteCashOutRequest) (*betting.Bet, error)
+
+	// PlaceMulti will place a multi across races and sports events.
+	PlaceMulti(ctx context.Context, in *betting.PlaceMultiRequest) (*betting.Multi, error)
+
+	// GetMulti will return a single multi by ID.
+	GetMulti(ctx context.Context, in *betting.GetMultiRequest) (*betting.Multi, error)
+
+	// ListMultiSettlements will return the settlements of a multi.
+	ListMultiSettlements(ctx context.Context, in *betting.ListMultiSettlementsRequest) (*betting.ListMultiSettlementsResponse, error)
+}
+
+// bettingService implements the Betting interface.
+type bettingService struct {
+	betsRepo  db.BetsRepo
+	racing    client.Racing
+	accounts  accounts.AccountsClient
+	sports    sports.SportsClient
+	tolerance float64
+	cashOut   cashout.Terms
+	monitor   *exposure.Monitor
+}
+
+// NewBettingService instantiates and returns a new bettingService, which checks bets against the races
+// and markets of the racing service and the events of the sports service, accepting prices that have
+// shortened by up to the given tolerance, pays their stakes from the customer's balance in the accounts
+// service, and cashes bets out on the given terms. The monitor is told of the races whose bets change.
+func NewBettingService(betsRepo db.BetsRepo, racing client.Racing, accounts accounts.AccountsClient, sports sports.SportsClient, tolerance float64, cashOut cashout.Terms, monitor *exposure.Monitor) Betting {
+	return &bettingService{
+		betsRepo:  betsRepo,
+		racing:    racing,
+		accounts:  accounts,
+		sports:    sports,
+		tolerance: tolerance,
+		cashOut:   cashOut,
+		monitor:   monitor,
+	}
+}
+
+func (s *bettingService) PlaceBet(ctx context.Context, in *betting.PlaceBetRequest) (*betting.Bet, error) {
+	// A retried request returns the bet it placed, without checking it again against a race that may
+	// since have closed.
+	placed, err := s.betsRepo.GetByKey(in.CustomerId, in.IdempotencyKey)
+	if err != nil {
+		return nil, err
+	}
+
+	if placed != nil {
+		return s.paid(ctx, placed, in)
+	}
+
+	race, err := s.racing.GetRace(ctx, in.RaceId)
+	if err != nil {
+		return nil, err
+	}
+
+	market, err := s.racing.GetMarket(ctx, in.RaceId, racing.OddsFormat_DECIMAL, false)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := bets.Check(in, race, market, s.tolerance); err != nil {
+		return nil, err
+	}
+
+	// The stake is held before the bet is stored, so that a customer cannot bet more than they have. The
+	// hold's reference is the idempotency key, so a retried request holds the stake only once.
+	bet := bets.New(in, market)
+
+	hold, err := s.accounts.Reserve(ctx, &accounts.ReserveRequest{
+		CustomerId: in.CustomerId,
+		Amount:     bet.TotalStake,
+		Reference:  bets.HoldReference(in),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// A hold released by an earlier request that failed to store its bet cannot pay for one now.
+	if hold.Status == accounts.Hold_RELEASED {
+		return nil, errs.Invalid("idempotency_key", "was already used for a bet that could not be placed")
+	}
+
+	bet.HoldId = hold.Id
+
+	// Another request with the same key may have got here first, in which case its bet is the one stored.
+	if placed, err = s.betsRepo.Insert(bet); err != nil {
+		s.release(ctx, hold)
+		return nil, err
+	}
+
+	if placed, err = s.paid(ctx, placed, in); err != nil {
+		return nil, err
+	}
+
+	s.exposed(ctx, placed.RaceId)
+
+	return placed, nil
+}
+
+func (s *bettingService) GetBet(ctx context.Context, in *betting.GetBetRequest) (*betting.Bet, error) {
+	return s.ownBet(ctx, in.Id)
+}
+
+func (s *bettingService) ListBetSettlements(ctx context.Context, in *betting.ListBetSettlementsRequest) (*betting.ListBetSettlementsResponse, error) {
+	if _, err := s.ownBet(ctx, in.BetId); err != nil {
+		return nil, err
+	}
+
+	settlements, err := s.betsRepo.ListSettlements(in.BetId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &betting.ListBetSettlementsResponse{Settlements: settlements}, nil
+}
+
+// ownBet returns a bet, if the customer a request was made on behalf of placed it.
+func (s *bettingService) ownBet(ctx context.Context, id int64) (*betting.Bet, error) {
+	bet, err := s.betsRepo.Get(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := customers.Check(ctx, "bet", strconv.FormatInt(id, 10), bet.CustomerId); err != nil {
+		return nil, err
+	}
+
+	return bet, nil
+}
+
+func (s *bettingService) QuoteCashOut(ctx context.Context, in *betting.QuoteCashOutRequest) (*betting.CashOutQuote, error) {
+	bet, err := s.ownBet(ctx, in.BetId)
+	if err != nil {
+		return nil, err
+	}
+
+	quote, err := s.quote(ctx, bet, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	return s.betsRepo.InsertQuote(quote)
+}
+
+func (s *bettingService) ExecuteCashOut(ctx context.Context, in *betting.ExecuteCashOutRequest) (*betting.Bet, error) {
+	bet, err := s.ownBet(ctx, in.BetId)
+	if err != nil {
+		return nil, err
+	}
+
+	quote, err := s.betsRepo.GetQuote(in.QuoteId)
+	if err != nil {
+		return nil, err
+	}
+
+	if quote.BetId != in.BetId {
+		return nil, errs.Invalid("quote_id", "%d is not a quote of bet %d", in.QuoteId, in.BetId)
+	}
+
+	// A retried request returns the bet its quote cashed out, without checking the quote again.
+	if quote.SettlementId != 0 {
+		return bet, nil
+	}
+
+	// The bet is priced again as it is cashed out, so that a quote made before the race jumped, or the
+	// runner's price firmed, cannot be executed after.
+	now := time.Now()
+
+	fresh, err := s.quote(ctx, bet, now)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := cashout.Check(quote, fresh, s.cashOut, now); err != nil {
+		return nil, err
+	}
+
+	settled, err := s.betsRepo.CashOut(bet, quote, cashout.Settlement(quote))
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("cashed out bet %d for quote %d, paying %d\n", bet.Id, quote.Id, settled.Payout)
+
+	// A cash out that fails to post is posted by the next poll of the settlement worker instead.
+	if err := settlement.Post(ctx, s.betsRepo, s.accounts, bet.CustomerId, bet.HoldId, settled); err != nil {
+		log.Printf("failed posting settlement %d: %s\n", settled.Id, err)
+	}
+
+	s.exposed(ctx, bet.RaceId)
+
+	return s.betsRepo.Get(bet.Id)
+}
+
+func (s *bettingService) PlaceMulti(ctx context.Context, in *betting.PlaceMultiRequest) (*betting.Multi, error) {
+	// As with single bets, a retried request returns the multi it placed without checking it again.
+	placed, err := s.betsRepo.GetMultiByKey(in.CustomerId, in.IdempotencyKey)
+	if err != nil {
+		return nil, err
+	}
+
+	if placed != nil {
+		return s.paidMulti(ctx, placed, in)
+	}
+
+	if err := bets.CheckLegs(in); err != nil {
+		return nil, err
+	}
+
+	prices := make([]float64, len(in.Legs))
+
+	for i, leg := range in.Legs {
+		if prices[i], err = s.legPrice(ctx, i, leg); err != nil {
+			return nil, err
+		}
+	}
+
+	multi := bets.NewMulti(in, prices)
+
+	hold, err := s.accounts.Reserve(ctx, &accounts.ReserveRequest{
+		CustomerId: in.CustomerId,
+		Amount:     multi.Stake,
+		Reference:  bets.MultiHoldReference(in),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if hold.Status == accounts.Hold_RELEASED {
+		return nil, errs.Invalid("idempotency_key", "was already used for a multi that could not be placed")
+	}
+
+	multi.HoldId = hold.Id
+
+	if placed, err = s.betsRepo.InsertMulti(multi); err != nil {
+		s.release(ctx, hold)
+		return nil, err
+	}
+
+	return s.paidMulti(ctx, placed, in)
+}
+
+func (s *bettingService) GetMulti(ctx context.Context, in *betting.GetMultiRequest) (*betting.Multi, error) {
+	return s.ownMulti(ctx, in.Id)
+}
+
+func (s *bettingService) ListMultiSettlements(ctx context.Context, in *betting.ListMultiSettlementsRequest) (*betting.ListMultiSettlementsResponse, error) {
+	if _, err := s.ownMulti(ctx, in.MultiId); err != nil {
+		return nil, err
+	}
+
+	settlements, err := s.betsRepo.ListMultiSettlements(in.MultiId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &betting.ListMultiSettlementsResponse{Settlements: settlements}, nil
+}
+
+// ownMulti returns a multi, if the customer a request was made on behalf of placed it.
+func (s *bettingService) ownMulti(ctx context.Context, id int64) (*betting.Multi, error) {
+	multi, err := s.betsRepo.GetMulti(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := customers.Check(ctx, "multi", strconv.FormatInt(id, 10), multi.CustomerId); err != nil {
+		return nil, err
+	}
+
+	return multi, nil
+}
+
+// exposed tells the monitor that the bets on a race have changed. The bets stand whether or not it can
+// work out the race's exposure again, or suspend the race.
+func (s *bettingService) exposed(ctx context.Context, raceID int64) {
+	if err := s.monitor.Update(ctx, raceID); err != nil {
+		log.Printf("failed updating the exposure on race %d: %s\n", raceID, err)
+	}
+}
+
+// quote quotes a cash out of a bet at the given time, on its race and market as they are now.
+func (s *bettingService) quote(ctx context.Context, bet *betting.Bet, now time.Time) (*betting.CashOutQuote, error) {
+	race, err := s.racing.GetRace(ctx, bet.RaceId)
+	if err != nil {
+		return nil, err
+	}
+
+	market, err := s.racing.GetMarket(ctx, bet.RaceId, racing.OddsFormat_DECIMAL, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return cashout.Quote(bet, race, market, s.cashOut, now)
+}
+
+// legPrice returns the price the leg at the given index of a multi is struck at, checked against its race
+// in the racing service or its event in the sports service.
+func (s *bettingService) legPrice(ctx context.Context, i int, leg *betting.Selection) (float64, error) {
+	if leg.EventId != 0 {
+		event, err := s.sports.GetEvent(ctx, &sports.GetEventRequest{Id: leg.EventId})
+		if err != nil {
+			return 0, err
+		}
+
+		return bets.EventLegPrice(i, leg, event, time.Now(), s.tolerance)
+	}
+
+	race, err := s.racing.GetRace(ctx, leg.RaceId)
+	if err != nil {
+		return 0, err
+	}
+
+	market, err := s.racing.GetMarket(ctx, leg.RaceId, racing.OddsFormat_DECIMAL, false)
+	if err != nil {
+		return 0, err
+	}
+
+	return bets.RaceLegPrice(i, leg, race, market, s.tolerance)
+}
+
+// paidMulti returns a multi placed with the idempotency key of a request once its stake has been paid, as
+// paid does a bet, or an *errs.InvalidArgument error if the key was used for a different multi.
+func (s *bettingService) paidMulti(ctx context.Context, placed *betting.Multi, in *betting.PlaceMultiRequest) (*betting.Multi, error) {
+	if !bets.MultiMatches(placed, in) {
+		return nil, errs.Invalid("idempotency_key", "was already used for multi %d, which differs from this one", placed.Id)
+	}
+
+	if _, err := s.accounts.Commit(ctx, &accounts.CommitRequest{HoldId: placed.HoldId}); err != nil {
+		return nil, err
+	}
+
+	return placed, nil
+}
+
+// matching returns a bet placed with the idempotency key of a request, or an *errs.InvalidArgument error
+// if the key was used for a different bet.
+func matching(placed *betting.Bet, in *betting.PlaceBetRequest) (*betting.Bet, error) {
+	if !bets.Matches(placed, in) {
+		return nil, errs.Invalid("idempotency_key", "was already used for bet %d, which differs from this one", placed.Id)
+	}
+
+	return placed, nil
+}
+
+// paid returns a bet placed with the idempotency key of a request once its stake has been paid from the
+// hold on the customer's funds, which may not have been when a request placing it failed part way.
+func (s *bettingService) paid(ctx context.Context, placed *betting.Bet, in *betting.PlaceBetRequest) (*betting.Bet, error) {
+	placed, err := matching(placed, in)
+	if err != nil || placed.HoldId == 0 {
+		return placed, err
+	}
+
+	if _, err := s.accounts.Commit(ctx, &accounts.CommitRequest{HoldId: placed.HoldId}); err != nil {
+		return nil, err
+	}
+
+	return placed, nil
+}
+
+// release returns the funds held for a bet or multi that could not be stored. Funds it fails to return stay held.
+func (s *bettingService) release(ctx context.Context, hold *accounts.Hold) {
+	if _, err := s.accounts.Release(ctx, &accounts.ReleaseRequest{HoldId: hold.Id}); err != nil {
+		log.Printf("failed releasing hold %d: %s\n", hold.Id, err)
+	}
+}
@@ -0,0 +1,72 @@
+package service
+
+import (
+	"errors"
+
+	"git.neds.sh/matty/entain/betting/exposure"
+	"git.neds.sh/matty/entain/betting/proto/risk"
+	"git.neds.sh/matty/entain/common/errs"
+	"golang.org/x/net/context"
+)
+
+type Risk interface {
+	// GetExposure will return the exposure of the house on a race.
+	GetExposure(ctx context.Context, in *risk.GetExposureRequest) (*risk.Exposure, error)
+
+	// WatchExposure will stream the exposure on races as their bets change.
+	WatchExposure(in *risk.WatchExposureRequest, stream risk.Risk_WatchExposureServer) error
+}
+
+// riskService implements the Risk interface.
+type riskService struct {
+	monitor *exposure.Monitor
+}
+
+// NewRiskService instantiates and returns a new riskService, serving the exposure the monitor works out.
+func NewRiskService(monitor *exposure.Monitor) Risk {
+	return &riskService{monitor: monitor}
+}
+
+func (s *riskService) GetExposure(ctx context.Context, in *risk.GetExposureRequest) (*risk.Exposure, error) {
+	return s.monitor.Exposure(in.RaceId)
+}
+
+func (s *riskService) WatchExposure(in *risk.WatchExposureRequest, stream risk.Risk_WatchExposureServer) error {
+	// Changes are subscribed to before the exposure as it is now is sent, so that none are missed between.
+	exposures, stop := s.monitor.Subscribe()
+	defer stop()
+
+	watched := make(map[int64]bool, len(in.RaceIds))
+
+	for _, raceID := range in.RaceIds {
+		watched[raceID] = true
+
+		current, err := s.monitor.Exposure(raceID)
+		if err != nil {
+			return err
+		}
+
+		if err := stream.Send(current); err != nil {
+			return err
+		}
+	}
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		case changed, ok := <-exposures:
+			if !ok {
+				return &errs.Unavailable{Err: errors.New("watcher fell too far behind")}
+			}
+
+			if len(watched) > 0 && !watched[changed.RaceId] {
+				continue
+			}
+
+			if err := stream.Send(changed); err != nil {
+				return err
+			}
+		}
+	}
+}
@@ -0,0 +1,159 @@
+package settlement
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"git.neds.sh/matty/entain/betting/proto/betting"
+	"git.neds.sh/matty/entain/betting/proto/sports"
+	"git.neds.sh/matty/entain/racing/proto/racing"
+	"git.neds.sh/matty/entain/racing/scratchings"
+)
+
+// SettleRaceLeg returns a racing leg of a multi struck at the given time as its race settles it, or nil if
+// the race is not yet decided. The leg is settled as a win bet on its runner would be: at its price if the
+// runner wins, less the deductions of runners scratched after it was struck, and on a share of it for a
+// dead heat. The race must have its runners, scratchings and result.
+func SettleRaceLeg(leg *betting.Leg, race *racing.Race, struck time.Time) *betting.Leg {
+	switch race.State {
+	case racing.Race_ABANDONED:
+		return settledLeg(leg, betting.Leg_VOID, 1, 0, "the race was abandoned")
+	case racing.Race_FINAL:
+		if race.Result == nil {
+			return nil
+		}
+	default:
+		return nil
+	}
+
+	if scratchings.Scratched(race)[leg.RunnerNumber] {
+		return settledLeg(leg, betting.Leg_VOID, 1, race.Result.Version, fmt.Sprintf("runner %d was scratched", leg.RunnerNumber))
+	}
+
+	winDeduction, _ := scratchings.Deductions(race.Scratchings, struck)
+	share := Share(race.Result.Placings, leg.RunnerNumber, 1)
+	reason := fmt.Sprintf("runner %d: %s", leg.RunnerNumber, describe("win", share, winDeduction))
+
+	if share == 0 {
+		return settledLeg(leg, betting.Leg_LOST, 0, race.Result.Version, reason)
+	}
+
+	// What the leg returns on each cent carried onto it is what a win bet of one cent would.
+	return settledLeg(leg, betting.Leg_WON, returns(1, leg.Price, share, winDeduction), race.Result.Version, reason)
+}
+
+// SettleEventLeg returns a sports leg of a multi as its event settles it, or nil if the event is not yet
+// decided. The leg is settled at its price if its outcome won, and is void if the outcome is or the event
+// was abandoned.
+func SettleEventLeg(leg *betting.Leg, event *sports.Event) *betting.Leg {
+	switch event.Status {
+	case sports.Event_ABANDONED:
+		return settledLeg(leg, betting.Leg_VOID, 1, 0, "the event was abandoned")
+	case sports.Event_FINAL:
+	default:
+		return nil
+	}
+
+	for _, outcome := range event.Outcomes {
+		if outcome.Id != leg.OutcomeId {
+			continue
+		}
+
+		switch outcome.Result {
+		case sports.Outcome_WON:
+			return settledLeg(leg, betting.Leg_WON, leg.Price, event.ResultVersion, fmt.Sprintf("outcome %d won", leg.OutcomeId))
+		case sports.Outcome_VOID:
+			return settledLeg(leg, betting.Leg_VOID, 1, event.ResultVersion, fmt.Sprintf("outcome %d was void", leg.OutcomeId))
+		default:
+			return settledLeg(leg, betting.Leg_LOST, 0, event.ResultVersion, fmt.Sprintf("outcome %d lost", leg.OutcomeId))
+		}
+	}
+
+	return nil
+}
+
+// LegChanges reports whether a settlement of a leg changes what it was last settled as.
+func LegChanges(leg, settled *betting.Leg) bool {
+	return leg.Status != settled.Status || leg.SettledPrice != settled.SettledPrice || leg.ResultVersion != settled.ResultVersion
+}
+
+// SettleMulti returns the settlement of a multi that its legs call for, or nil if it is not yet decided. A
+// multi loses as soon as any leg loses, and is otherwise decided once every leg is settled, paying its
+// stake at the product of the prices its legs were settled at. Void legs are settled at a price of 1, so
+// the multi is only void, refunding its stake, if all of them are. The settlement's multi, adjustment and
+// time are left for the caller to set.
+func SettleMulti(multi *betting.Multi) *betting.Settlement {
+	var (
+		won, void, pending []string
+		price              = 1.0
+	)
+
+	for _, leg := range multi.Legs {
+		number := fmt.Sprint(leg.Number)
+
+		switch leg.Status {
+		case betting.Leg_LOST:
+			return &betting.Settlement{Status: betting.Bet_LOST, Reason: fmt.Sprintf("leg %d lost: %s", leg.Number, leg.Reason)}
+		case betting.Leg_WON:
+			won = append(won, number)
+		case betting.Leg_VOID:
+			void = append(void, number)
+		default:
+			pending = append(pending, number)
+		}
+
+		price *= leg.SettledPrice
+	}
+
+	if len(pending) > 0 {
+		return nil
+	}
+
+	if len(won) == 0 {
+		return &betting.Settlement{Status: betting.Bet_VOID, Payout: multi.Stake, Reason: "every leg was void"}
+	}
+
+	reason := fmt.Sprintf("%s won at a combined price of %.2f", legs(won), price)
+	if len(void) > 0 {
+		reason += fmt.Sprintf(", with %s void", legs(void))
+	}
+
+	return &betting.Settlement{
+		Status: betting.Bet_WON,
+		// Payouts are rounded down to the cent, with a little slack for float error.
+		Payout: int64(math.Floor(float64(multi.Stake)*price + 1e-6)),
+		Reason: reason,
+	}
+}
+
+// MultiChanges reports whether a settlement changes what a multi was last settled as.
+func MultiChanges(multi *betting.Multi, settlement *betting.Settlement) bool {
+	return multi.Status != settlement.Status || multi.Payout != settlement.Payout
+}
+
+// settledLeg returns a leg as settled.
+func settledLeg(leg *betting.Leg, status betting.Leg_Status, price float64, resultVersion int64, reason string) *betting.Leg {
+	return &betting.Leg{
+		Number:        leg.Number,
+		RaceId:        leg.RaceId,
+		RunnerNumber:  leg.RunnerNumber,
+		EventId:       leg.EventId,
+		OutcomeId:     leg.OutcomeId,
+		Price:         leg.Price,
+		Status:        status,
+		SettledPrice:  price,
+		ResultVersion: resultVersion,
+		Reason:        reason,
+	}
+}
+
+// legs names the legs with the given numbers, as in "legs 1, 2 and 4".
+func legs(numbers []string) string {
+	if len(numbers) == 1 {
+		return "leg " + numbers[0]
+	}
+
+	return "legs " + strings.Join(numbers[:len(numbers)-1], ", ") + " and " + numbers[len(numbers)-1]
+}
@@ -0,0 +1,188 @@
+// Package settlement works out what fixed-odds bets pay once their race is decided, and keeps them settled
+// as results are amended.
+//
+// Bets are settled once their race is final, on its result, or abandoned, when they are void. A win bet
+// pays if its runner wins, and a place bet if its runner places: in the first three with eight or more
+// starters, or the first two with five to seven. With fewer starters there is no place betting, and
+// place bets are refunded. An each-way bet is settled as its win bet and its place bet.
+//
+// Runners that dead heat share the places they take up, so each is paid on the share of its stake those
+// places make up: half of it when two runners dead heat for first in a win bet, or for third when three
+// places are paid. Winnings, but not the stake, are reduced by the deductions of the runners scratched
+// after the bet was struck, and bets on scratched runners are refunded. Bets cashed out before their race
+// jumped are not settled on it.
+//
+// Multis are settled leg by leg, each as its race or sports event is decided: a racing leg as a win bet on
+// its runner, and a sports leg on the result of its outcome. A multi loses with its first losing leg, and
+// otherwise pays once every leg is settled, with void legs dropping out of its price.
+package settlement
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/golang/protobuf/ptypes"
+
+	"git.neds.sh/matty/entain/betting/proto/betting"
+	"git.neds.sh/matty/entain/racing/proto/racing"
+	"git.neds.sh/matty/entain/racing/scratchings"
+)
+
+// Settle returns the settlement of a bet that its race calls for, or nil if the race is not yet decided or
+// the bet was cashed out, which settled it for good. The race must have its runners, scratchings and
+// result. The settlement's bet, adjustment and time are left for the caller to set.
+func Settle(bet *betting.Bet, race *racing.Race) *betting.Settlement {
+	if bet.Status == betting.Bet_CASHED_OUT {
+		return nil
+	}
+
+	switch race.State {
+	case racing.Race_ABANDONED:
+		return &betting.Settlement{Status: betting.Bet_VOID, Payout: bet.TotalStake, Reason: "the race was abandoned"}
+	case racing.Race_FINAL:
+		if race.Result == nil {
+			return nil
+		}
+	default:
+		return nil
+	}
+
+	if scratchings.Scratched(race)[bet.RunnerNumber] {
+		return &betting.Settlement{
+			Status:        betting.Bet_VOID,
+			Payout:        bet.TotalStake,
+			ResultVersion: race.Result.Version,
+			Reason:        fmt.Sprintf("runner %d was scratched", bet.RunnerNumber),
+		}
+	}
+
+	struck, err := ptypes.Timestamp(bet.StruckTime)
+	if err != nil {
+		return nil
+	}
+
+	winDeduction, placeDeduction := scratchings.Deductions(race.Scratchings, struck)
+	starters := len(race.Runners) - len(race.Scratchings)
+
+	var (
+		payout  float64
+		reasons []string
+	)
+
+	if bet.Type == betting.Bet_WIN || bet.Type == betting.Bet_EACH_WAY {
+		share := Share(race.Result.Placings, bet.RunnerNumber, 1)
+		payout += returns(bet.Stake, bet.WinPrice, share, winDeduction)
+		reasons = append(reasons, describe("win", share, winDeduction))
+	}
+
+	if bet.Type == betting.Bet_PLACE || bet.Type == betting.Bet_EACH_WAY {
+		if places := Places(starters); places == 0 {
+			payout += float64(bet.Stake)
+			reasons = append(reasons, fmt.Sprintf("place refunded, as there were only %d starters", starters))
+		} else {
+			share := Share(race.Result.Placings, bet.RunnerNumber, places)
+			payout += returns(bet.Stake, bet.PlacePrice, share, placeDeduction)
+			reasons = append(reasons, describe("place", share, placeDeduction))
+		}
+	}
+
+	settlement := &betting.Settlement{
+		Status: betting.Bet_LOST,
+		// Payouts are rounded down to the cent, with a little slack for float error.
+		Payout:        int64(math.Floor(payout + 1e-6)),
+		ResultVersion: race.Result.Version,
+		Reason:        strings.Join(reasons, "; "),
+	}
+
+	if settlement.Payout > 0 {
+		settlement.Status = betting.Bet_WON
+	}
+
+	return settlement
+}
+
+// Changes reports whether a settlement changes what a bet was last settled as, so that settling it again
+// on the same result does nothing.
+func Changes(bet *betting.Bet, settlement *betting.Settlement) bool {
+	return bet.Status != settlement.Status || bet.Payout != settlement.Payout
+}
+
+// Places returns how many places are paid in a race with the given number of starters, or 0 if there is
+// no place betting on it.
+func Places(starters int) int {
+	switch {
+	case starters >= 8:
+		return 3
+	case starters >= 5:
+		return 2
+	default:
+		return 0
+	}
+}
+
+// Share returns the share of its stake a bet on a runner is paid on, when the given number of places are
+// paid: 1 if the runner finished in them outright, a fraction of 1 if it dead heated for the last of
+// them, and 0 otherwise.
+func Share(placings []*racing.Placing, runnerNumber int64, places int) float64 {
+	var (
+		position int64
+		tied     int
+	)
+
+	for _, placing := range placings {
+		if placing.RunnerNumber == runnerNumber {
+			position = placing.Position
+		}
+	}
+
+	if position == 0 {
+		return 0
+	}
+
+	for _, placing := range placings {
+		if placing.Position == position {
+			tied++
+		}
+	}
+
+	// The runners tied at a position take up that many places from it, of which only some may be paid.
+	paid := places - int(position) + 1
+	if paid > tied {
+		paid = tied
+	}
+
+	if paid <= 0 {
+		return 0
+	}
+
+	return float64(paid) / float64(tied)
+}
+
+// returns is what a stake at the given price returns, in cents, on the share of it that is paid, with the
+// deduction taken from the winnings.
+func returns(stake int64, price, share, deduction float64) float64 {
+	paid := float64(stake) * share
+
+	return paid + paid*(price-1)*(1-deduction/100)
+}
+
+// describe explains how a win or place was settled.
+func describe(part string, share, deduction float64) string {
+	var reason string
+
+	switch {
+	case share == 0:
+		reason = part + " lost"
+	case share < 1:
+		reason = fmt.Sprintf("%s paid on %g%% of the stake for a dead heat", part, math.Round(share*10000)/100)
+	default:
+		reason = part + " paid"
+	}
+
+	if share > 0 && deduction > 0 {
+		reason += fmt.Sprintf(", less a %g%% deduction", deduction)
+	}
+
+	return reason
+}
@@ -0,0 +1,304 @@
+package settlement
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"git.neds.sh/matty/entain/betting/proto/betting"
+	"git.neds.sh/matty/entain/racing/proto/racing"
+)
+
+// struck is when the bets of the tests were struck.
+var struck = time.Date(2026, 11, 3, 14, 0, 0, 0, time.UTC)
+
+// at returns the time the given number of minutes after bets were struck, or before if negative.
+func at(minutes int) *timestamppb.Timestamp {
+	ts, _ := ptypes.TimestampProto(struck.Add(time.Duration(minutes) * time.Minute))
+	return ts
+}
+
+// final returns a race of n runners, numbered from 1, with a final result of the given placings.
+func final(n int64, placings ...*racing.Placing) *racing.Race {
+	race := &racing.Race{
+		Id:     42,
+		State:  racing.Race_FINAL,
+		Result: &racing.Result{RaceId: 42, Version: 1, Placings: placings, Final: true},
+	}
+
+	for number := int64(1); number <= n; number++ {
+		race.Runners = append(race.Runners, &racing.Runner{Number: number})
+	}
+
+	return race
+}
+
+// placed returns placings of runners in order, each a length or more clear of the next.
+func placed(numbers ...int64) []*racing.Placing {
+	placings := make([]*racing.Placing, len(numbers))
+	for i, number := range numbers {
+		placings[i] = &racing.Placing{Position: int64(i + 1), RunnerNumber: number}
+	}
+
+	return placings
+}
+
+// placing returns a placing of a runner at a position, which others may share in a dead heat.
+func placing(position, runnerNumber int64) *racing.Placing {
+	return &racing.Placing{Position: position, RunnerNumber: runnerNumber}
+}
+
+// scratched returns a race with the given scratchings.
+func scratched(race *racing.Race, scratchings ...*racing.Scratching) *racing.Race {
+	race.Scratchings = scratchings
+	return race
+}
+
+// scratching returns the scratching of a runner the given number of minutes after bets were struck, with
+// the given deductions.
+func scratching(runnerNumber int64, minutes int, win, place float64) *racing.Scratching {
+	return &racing.Scratching{RaceId: 42, RunnerNumber: runnerNumber, ScratchTime: at(minutes), WinDeduction: win, PlaceDeduction: place}
+}
+
+// bet returns an accepted bet with a stake of 1000 cents on a runner, at the given prices.
+func bet(betType betting.Bet_Type, runnerNumber int64, win, place float64) *betting.Bet {
+	total := int64(1000)
+	if betType == betting.Bet_EACH_WAY {
+		total = 2000
+	}
+
+	return &betting.Bet{
+		Id:           1,
+		RaceId:       42,
+		RunnerNumber: runnerNumber,
+		Type:         betType,
+		Stake:        1000,
+		TotalStake:   total,
+		WinPrice:     win,
+		PlacePrice:   place,
+		Status:       betting.Bet_ACCEPTED,
+		StruckTime:   at(0),
+	}
+}
+
+func TestSettle(t *testing.T) {
+	tests := []struct {
+		name string
+		bet  *betting.Bet
+		race *racing.Race
+		want *betting.Settlement
+	}{
+		{
+			name: "win",
+			bet:  bet(betting.Bet_WIN, 4, 3.5, 0),
+			race: final(10, placed(4, 7, 2)...),
+			want: &betting.Settlement{Status: betting.Bet_WON, Payout: 3500, ResultVersion: 1, Reason: "win paid"},
+		},
+		{
+			name: "win lost",
+			bet:  bet(betting.Bet_WIN, 7, 3.5, 0),
+			race: final(10, placed(4, 7, 2)...),
+			want: &betting.Settlement{Status: betting.Bet_LOST, ResultVersion: 1, Reason: "win lost"},
+		},
+		{
+			name: "place third of eight starters",
+			bet:  bet(betting.Bet_PLACE, 2, 0, 1.6),
+			race: final(8, placed(4, 7, 2)...),
+			want: &betting.Settlement{Status: betting.Bet_WON, Payout: 1600, ResultVersion: 1, Reason: "place paid"},
+		},
+		{
+			name: "place third of seven starters",
+			bet:  bet(betting.Bet_PLACE, 2, 0, 1.6),
+			race: final(7, placed(4, 7, 2)...),
+			want: &betting.Settlement{Status: betting.Bet_LOST, ResultVersion: 1, Reason: "place lost"},
+		},
+		{
+			name: "place second of five starters",
+			bet:  bet(betting.Bet_PLACE, 7, 0, 1.6),
+			race: final(5, placed(4, 7, 2)...),
+			want: &betting.Settlement{Status: betting.Bet_WON, Payout: 1600, ResultVersion: 1, Reason: "place paid"},
+		},
+		{
+			name: "place refunded with four starters",
+			bet:  bet(betting.Bet_PLACE, 3, 0, 1.6),
+			race: final(4, placed(4, 1, 2)...),
+			want: &betting.Settlement{Status: betting.Bet_WON, Payout: 1000, ResultVersion: 1, Reason: "place refunded, as there were only 4 starters"},
+		},
+		{
+			name: "each way winner",
+			bet:  bet(betting.Bet_EACH_WAY, 4, 3.5, 1.6),
+			race: final(10, placed(4, 7, 2)...),
+			want: &betting.Settlement{Status: betting.Bet_WON, Payout: 5100, ResultVersion: 1, Reason: "win paid; place paid"},
+		},
+		{
+			name: "each way placed",
+			bet:  bet(betting.Bet_EACH_WAY, 2, 8, 2.5),
+			race: final(10, placed(4, 7, 2)...),
+			want: &betting.Settlement{Status: betting.Bet_WON, Payout: 2500, ResultVersion: 1, Reason: "win lost; place paid"},
+		},
+		{
+			name: "each way third of seven starters",
+			bet:  bet(betting.Bet_EACH_WAY, 2, 8, 2.5),
+			race: final(7, placed(4, 7, 2)...),
+			want: &betting.Settlement{Status: betting.Bet_LOST, ResultVersion: 1, Reason: "win lost; place lost"},
+		},
+		{
+			name: "each way winner with four starters",
+			bet:  bet(betting.Bet_EACH_WAY, 4, 3.5, 1.6),
+			race: final(4, placed(4, 1, 2)...),
+			want: &betting.Settlement{Status: betting.Bet_WON, Payout: 4500, ResultVersion: 1, Reason: "win paid; place refunded, as there were only 4 starters"},
+		},
+		{
+			name: "each way with a scratching reducing the places paid",
+			bet:  bet(betting.Bet_EACH_WAY, 2, 8, 2.5),
+			race: scratched(final(8, placed(4, 7, 2)...), scratching(5, -60, 0, 0)),
+			want: &betting.Settlement{Status: betting.Bet_LOST, ResultVersion: 1, Reason: "win lost; place lost"},
+		},
+		{
+			name: "win dead heat",
+			bet:  bet(betting.Bet_WIN, 7, 5, 0),
+			race: final(10, placing(1, 4), placing(1, 7), placing(3, 2)),
+			want: &betting.Settlement{Status: betting.Bet_WON, Payout: 2500, ResultVersion: 1, Reason: "win paid on 50% of the stake for a dead heat"},
+		},
+		{
+			name: "place dead heat for first",
+			bet:  bet(betting.Bet_PLACE, 7, 0, 2),
+			race: final(10, placing(1, 4), placing(1, 7), placing(3, 2)),
+			want: &betting.Settlement{Status: betting.Bet_WON, Payout: 2000, ResultVersion: 1, Reason: "place paid"},
+		},
+		{
+			name: "place dead heat for the last place",
+			bet:  bet(betting.Bet_PLACE, 2, 0, 2),
+			race: final(10, placing(1, 4), placing(2, 7), placing(3, 2), placing(3, 9)),
+			want: &betting.Settlement{Status: betting.Bet_WON, Payout: 1000, ResultVersion: 1, Reason: "place paid on 50% of the stake for a dead heat"},
+		},
+		{
+			name: "each way triple dead heat for third",
+			bet:  bet(betting.Bet_EACH_WAY, 9, 21, 4),
+			race: final(12, placing(1, 4), placing(2, 7), placing(3, 2), placing(3, 9), placing(3, 11)),
+			want: &betting.Settlement{Status: betting.Bet_WON, Payout: 1333, ResultVersion: 1, Reason: "win lost; place paid on 33.33% of the stake for a dead heat"},
+		},
+		{
+			name: "win less a deduction",
+			bet:  bet(betting.Bet_WIN, 4, 5, 0),
+			race: scratched(final(10, placed(4, 7, 2)...), scratching(1, 30, 25, 15)),
+			want: &betting.Settlement{Status: betting.Bet_WON, Payout: 4000, ResultVersion: 1, Reason: "win paid, less a 25% deduction"},
+		},
+		{
+			name: "each way less deductions",
+			bet:  bet(betting.Bet_EACH_WAY, 4, 5, 2),
+			race: scratched(final(10, placed(4, 7, 2)...), scratching(1, 30, 25, 15)),
+			want: &betting.Settlement{Status: betting.Bet_WON, Payout: 5850, ResultVersion: 1, Reason: "win paid, less a 25% deduction; place paid, less a 15% deduction"},
+		},
+		{
+			name: "deductions add up",
+			bet:  bet(betting.Bet_WIN, 4, 5, 0),
+			race: scratched(final(10, placed(4, 7, 2)...), scratching(1, 30, 25, 15), scratching(3, 45, 10, 5)),
+			want: &betting.Settlement{Status: betting.Bet_WON, Payout: 3600, ResultVersion: 1, Reason: "win paid, less a 35% deduction"},
+		},
+		{
+			name: "deductions up to the most",
+			bet:  bet(betting.Bet_WIN, 4, 5, 0),
+			race: scratched(final(10, placed(4, 7, 2)...), scratching(1, 30, 50, 40), scratching(3, 45, 50, 40)),
+			want: &betting.Settlement{Status: betting.Bet_WON, Payout: 2000, ResultVersion: 1, Reason: "win paid, less a 75% deduction"},
+		},
+		{
+			name: "no deduction for a scratching before the bet",
+			bet:  bet(betting.Bet_WIN, 4, 5, 0),
+			race: scratched(final(10, placed(4, 7, 2)...), scratching(1, -30, 25, 15)),
+			want: &betting.Settlement{Status: betting.Bet_WON, Payout: 5000, ResultVersion: 1, Reason: "win paid"},
+		},
+		{
+			name: "no deduction for a losing bet",
+			bet:  bet(betting.Bet_WIN, 2, 5, 0),
+			race: scratched(final(10, placed(4, 7, 2)...), scratching(1, 30, 25, 15)),
+			want: &betting.Settlement{Status: betting.Bet_LOST, ResultVersion: 1, Reason: "win lost"},
+		},
+		{
+			name: "scratched runner",
+			bet:  bet(betting.Bet_EACH_WAY, 1, 5, 2),
+			race: scratched(final(10, placed(4, 7, 2)...), scratching(1, 30, 25, 15)),
+			want: &betting.Settlement{Status: betting.Bet_VOID, Payout: 2000, ResultVersion: 1, Reason: "runner 1 was scratched"},
+		},
+		{
+			name: "abandoned",
+			bet:  bet(betting.Bet_EACH_WAY, 4, 5, 2),
+			race: &racing.Race{Id: 42, State: racing.Race_ABANDONED},
+			want: &betting.Settlement{Status: betting.Bet_VOID, Payout: 2000, Reason: "the race was abandoned"},
+		},
+		{
+			name: "interim",
+			bet:  bet(betting.Bet_WIN, 4, 5, 0),
+			race: &racing.Race{Id: 42, State: racing.Race_INTERIM, Result: &racing.Result{RaceId: 42, Version: 1, Placings: placed(4, 7, 2)}},
+		},
+		{
+			name: "cashed out",
+			bet:  &betting.Bet{Id: 1, RaceId: 42, RunnerNumber: 4, Type: betting.Bet_WIN, Stake: 1000, TotalStake: 1000, WinPrice: 5, Status: betting.Bet_CASHED_OUT, StruckTime: at(0)},
+			race: final(10, placed(4, 7, 2)...),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Settle(tt.bet, tt.race)
+
+			if !proto.Equal(got, tt.want) {
+				t.Errorf("Settle() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestShare(t *testing.T) {
+	tests := []struct {
+		name     string
+		placings []*racing.Placing
+		runner   int64
+		places   int
+		want     float64
+	}{
+		{name: "winner", placings: placed(4, 7, 2), runner: 4, places: 1, want: 1},
+		{name: "second in the win", placings: placed(4, 7, 2), runner: 7, places: 1, want: 0},
+		{name: "third of three places", placings: placed(4, 7, 2), runner: 2, places: 3, want: 1},
+		{name: "third of two places", placings: placed(4, 7, 2), runner: 2, places: 2, want: 0},
+		{name: "unplaced", placings: placed(4, 7, 2), runner: 9, places: 3, want: 0},
+		{name: "dead heat for the win", placings: []*racing.Placing{placing(1, 4), placing(1, 7)}, runner: 7, places: 1, want: 0.5},
+		{name: "dead heat for first of three places", placings: []*racing.Placing{placing(1, 4), placing(1, 7), placing(3, 2)}, runner: 7, places: 3, want: 1},
+		{name: "dead heat for second of two places", placings: []*racing.Placing{placing(1, 4), placing(2, 7), placing(2, 2)}, runner: 2, places: 2, want: 0.5},
+		{name: "dead heat for second of three places", placings: []*racing.Placing{placing(1, 4), placing(2, 7), placing(2, 2)}, runner: 2, places: 3, want: 1},
+		{name: "triple dead heat for second of three places", placings: []*racing.Placing{placing(1, 4), placing(2, 7), placing(2, 2), placing(2, 9)}, runner: 9, places: 3, want: 2.0 / 3},
+		{name: "triple dead heat for third", placings: []*racing.Placing{placing(1, 4), placing(2, 7), placing(3, 2), placing(3, 9), placing(3, 11)}, runner: 11, places: 3, want: 1.0 / 3},
+		{name: "after a dead heat", placings: []*racing.Placing{placing(1, 4), placing(1, 7), placing(3, 2)}, runner: 2, places: 2, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Share(tt.placings, tt.runner, tt.places); got != tt.want {
+				t.Errorf("Share(%d, %d places) = %v, want %v", tt.runner, tt.places, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPlaces(t *testing.T) {
+	tests := []struct {
+		starters int
+		want     int
+	}{
+		{starters: 2, want: 0},
+		{starters: 4, want: 0},
+		{starters: 5, want: 2},
+		{starters: 7, want: 2},
+		{starters: 8, want: 3},
+		{starters: 24, want: 3},
+	}
+
+	for _, tt := range tests {
+		if got := Places(tt.starters); got != tt.want {
+			t.Errorf("Places(%d) = %d, want %d", tt.starters, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,336 @@
+package settlement
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+
+	"git.neds.sh/matty/entain/betting/db"
+	"git.neds.sh/matty/entain/betting/proto/accounts"
+	"git.neds.sh/matty/entain/betting/proto/betting"
+	"git.neds.sh/matty/entain/betting/proto/sports"
+	"git.neds.sh/matty/entain/racing/client"
+	"git.neds.sh/matty/entain/racing/proto/racing"
+)
+
+const (
+	// DefaultInterval is how often the Worker looks for races to settle that it has not heard about from
+	// the racing service, unless it is given another.
+	DefaultInterval = time.Minute
+
+	// DefaultAmendmentWindow is how long after settling a race the Worker keeps checking it for amended
+	// results it has not heard about, unless it is given another.
+	DefaultAmendmentWindow = 72 * time.Hour
+)
+
+// Worker settles bets as the races they are on are decided, and settles them again when their results are
+// amended. It follows the changes to races made by the racing service, and in case it misses any, looks
+// for races to settle when it starts, whenever it reconnects, and every interval.
+//
+// Settling a race again on a result it has already been settled on changes nothing, so the Worker may
+// settle races as often as it likes, and several Workers may share a database.
+//
+// The legs of multis are settled alongside the bets on their races. Sports events have no change feed, so
+// the legs on them are settled by polling alone.
+//
+// Each settlement's adjustment is posted to the customer's balance in the accounts service, as a return
+// or, when negative, a reversal. Settlements are stored before they are posted, and those that fail to
+// post are posted again by the next poll, under the same reference so that none is posted twice.
+type Worker struct {
+	betsRepo        db.BetsRepo
+	racing          client.Racing
+	accounts        accounts.AccountsClient
+	sports          sports.SportsClient
+	interval        time.Duration
+	amendmentWindow time.Duration
+
+	// mu stops a race being settled from the change feed and a poll at the same time.
+	mu sync.Mutex
+}
+
+// NewWorker returns a Worker settling the bets of the repository on the races of the racing service and
+// the events of the sports service, and posting their settlements to the accounts service. It polls every
+// interval, or only when it starts and reconnects if that is zero, and checks races and events settled
+// within the amendment window.
+func NewWorker(betsRepo db.BetsRepo, racing client.Racing, accounts accounts.AccountsClient, sports sports.SportsClient, interval, amendmentWindow time.Duration) *Worker {
+	return &Worker{
+		betsRepo:        betsRepo,
+		racing:          racing,
+		accounts:        accounts,
+		sports:          sports,
+		interval:        interval,
+		amendmentWindow: amendmentWindow,
+	}
+}
+
+// Run settles bets until the context is done, or the change feed fails for good.
+func (w *Worker) Run(ctx context.Context) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	if err := w.poll(ctx); err != nil {
+		log.Printf("failed settling races: %s\n", err)
+	}
+
+	if w.interval > 0 {
+		go func() {
+			ticker := time.NewTicker(w.interval)
+			defer ticker.Stop()
+
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+					if err := w.poll(ctx); err != nil {
+						log.Printf("failed settling races: %s\n", err)
+					}
+				}
+			}
+		}()
+	}
+
+	return client.Watch(ctx, w.racing, &racing.WatchRacesRequest{}, func(event *racing.RaceEvent) error {
+		if !decides(event) {
+			return nil
+		}
+
+		// A race that fails to settle is settled by the next poll instead, so the feed keeps going.
+		if err := w.SettleRace(ctx, event.Race.Id); err != nil {
+			log.Printf("failed settling race %d: %s\n", event.Race.Id, err)
+		}
+
+		return nil
+	}, func() error {
+		if err := w.poll(ctx); err != nil {
+			log.Printf("failed settling races: %s\n", err)
+		}
+
+		return nil
+	})
+}
+
+// SettleRace settles every bet and leg of a multi on a race that its result, or its abandonment, calls
+// for, and settles again those settled on an earlier version of its result that it changes.
+func (w *Worker) SettleRace(ctx context.Context, raceID int64) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	bets, err := w.betsRepo.RaceBets(raceID)
+	if err != nil {
+		return err
+	}
+
+	multis, err := w.betsRepo.RaceMultis(raceID)
+	if err != nil || len(bets)+len(multis) == 0 {
+		return err
+	}
+
+	race, err := w.racing.GetRace(ctx, raceID)
+	if err != nil {
+		return err
+	}
+
+	for _, bet := range bets {
+		settlement := Settle(bet, race)
+		if settlement == nil || !Changes(bet, settlement) {
+			continue
+		}
+
+		settled, err := w.betsRepo.Settle(bet, settlement)
+		if err != nil {
+			return err
+		}
+
+		log.Printf("settled bet %d as %s, paying %d (%+d): %s\n", bet.Id, settled.Status, settled.Payout, settled.Adjustment, settled.Reason)
+
+		// A settlement that fails to post is posted by the next poll instead.
+		if err := w.post(ctx, bet.CustomerId, bet.HoldId, settled); err != nil {
+			log.Printf("failed posting settlement %d: %s\n", settled.Id, err)
+		}
+	}
+
+	return w.settleMultis(ctx, multis, func(multi *betting.Multi, leg *betting.Leg) (*betting.Leg, error) {
+		if leg.RaceId != raceID {
+			return nil, nil
+		}
+
+		struck, err := ptypes.Timestamp(multi.StruckTime)
+		if err != nil {
+			return nil, err
+		}
+
+		return SettleRaceLeg(leg, race, struck), nil
+	})
+}
+
+// SettleEvent settles every leg of a multi on a sports event that its result, or its abandonment, calls
+// for, and settles again those settled on an earlier version of its result that it changes.
+func (w *Worker) SettleEvent(ctx context.Context, eventID int64) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	multis, err := w.betsRepo.EventMultis(eventID)
+	if err != nil || len(multis) == 0 {
+		return err
+	}
+
+	event, err := w.sports.GetEvent(ctx, &sports.GetEventRequest{Id: eventID})
+	if err != nil {
+		return err
+	}
+
+	return w.settleMultis(ctx, multis, func(multi *betting.Multi, leg *betting.Leg) (*betting.Leg, error) {
+		if leg.EventId != eventID {
+			return nil, nil
+		}
+
+		return SettleEventLeg(leg, event), nil
+	})
+}
+
+// settleMultis settles the legs of multis that settle returns a settlement of, then each multi as its legs
+// call for.
+func (w *Worker) settleMultis(ctx context.Context, multis []*betting.Multi, settle func(multi *betting.Multi, leg *betting.Leg) (*betting.Leg, error)) error {
+	for _, multi := range multis {
+		for i, leg := range multi.Legs {
+			settled, err := settle(multi, leg)
+			if err != nil {
+				return err
+			}
+
+			if settled == nil || !LegChanges(leg, settled) {
+				continue
+			}
+
+			if multi.Legs[i], err = w.betsRepo.SettleLeg(multi.Id, leg, settled); err != nil {
+				return err
+			}
+
+			log.Printf("settled leg %d of multi %d as %s at %.2f: %s\n", leg.Number, multi.Id, settled.Status, settled.SettledPrice, settled.Reason)
+		}
+
+		settlement := SettleMulti(multi)
+		if settlement == nil || !MultiChanges(multi, settlement) {
+			continue
+		}
+
+		settled, err := w.betsRepo.SettleMulti(multi, settlement)
+		if err != nil {
+			return err
+		}
+
+		log.Printf("settled multi %d as %s, paying %d (%+d): %s\n", multi.Id, settled.Status, settled.Payout, settled.Adjustment, settled.Reason)
+
+		// A settlement that fails to post is posted by the next poll instead.
+		if err := w.post(ctx, multi.CustomerId, multi.HoldId, settled); err != nil {
+			log.Printf("failed posting settlement %d: %s\n", settled.Id, err)
+		}
+	}
+
+	return nil
+}
+
+// post pays a settlement's adjustment to, or takes it from, the balance of the customer who placed the bet
+// or multi, as Post does.
+func (w *Worker) post(ctx context.Context, customerID string, holdID int64, settlement *betting.Settlement) error {
+	return Post(ctx, w.betsRepo, w.accounts, customerID, holdID, settlement)
+}
+
+// Post pays a settlement's adjustment to, or takes it from, the balance of the customer who placed the bet
+// or multi, whose stake was paid by the given hold, and records that it has been. Bets placed before stakes
+// were paid from balances have no hold, and nothing to post. The hold is committed even when there is no
+// adjustment to pay, as for a losing bet. Posting a settlement again pays nothing more.
+func Post(ctx context.Context, betsRepo db.BetsRepo, accountsClient accounts.AccountsClient, customerID string, holdID int64, settlement *betting.Settlement) error {
+	if holdID != 0 {
+		// The stake is paid before any return, in case the request placing the bet failed before paying it.
+		if _, err := accountsClient.Commit(ctx, &accounts.CommitRequest{HoldId: holdID}); err != nil {
+			return err
+		}
+
+		if settlement.Adjustment != 0 {
+			if _, err := accountsClient.Settle(ctx, &accounts.SettleRequest{
+				CustomerId: customerID,
+				Amount:     settlement.Adjustment,
+				Reference:  fmt.Sprintf("settlements/%d", settlement.Id),
+			}); err != nil {
+				return err
+			}
+		}
+	}
+
+	return betsRepo.MarkPosted(settlement.Id)
+}
+
+// poll settles every race and event with bets or legs yet to be settled, or settled within the amendment
+// window, then posts the settlements that have yet to be.
+func (w *Worker) poll(ctx context.Context) error {
+	settledSince := time.Now().Add(-w.amendmentWindow)
+
+	raceIDs, err := w.betsRepo.RacesToSettle(settledSince)
+	if err != nil {
+		return err
+	}
+
+	for _, raceID := range raceIDs {
+		if err := w.SettleRace(ctx, raceID); err != nil {
+			// A race that cannot be settled, such as one deleted from the racing service, must not hold up the rest.
+			log.Printf("failed settling race %d: %s\n", raceID, err)
+		}
+	}
+
+	eventIDs, err := w.betsRepo.EventsToSettle(settledSince)
+	if err != nil {
+		return err
+	}
+
+	for _, eventID := range eventIDs {
+		if err := w.SettleEvent(ctx, eventID); err != nil {
+			log.Printf("failed settling event %d: %s\n", eventID, err)
+		}
+	}
+
+	return w.postUnposted(ctx)
+}
+
+// postUnposted posts every settlement that has yet to be, such as those that failed to post when made.
+func (w *Worker) postUnposted(ctx context.Context) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	settlements, err := w.betsRepo.UnpostedSettlements()
+	if err != nil {
+		return err
+	}
+
+	for _, settlement := range settlements {
+		var err error
+
+		if settlement.MultiId != 0 {
+			var multi *betting.Multi
+			if multi, err = w.betsRepo.GetMulti(settlement.MultiId); err == nil {
+				err = w.post(ctx, multi.CustomerId, multi.HoldId, settlement)
+			}
+		} else {
+			var bet *betting.Bet
+			if bet, err = w.betsRepo.Get(settlement.BetId); err == nil {
+				err = w.post(ctx, bet.CustomerId, bet.HoldId, settlement)
+			}
+		}
+
+		if err != nil {
+			log.Printf("failed posting settlement %d: %s\n", settlement.Id, err)
+		}
+	}
+
+	return ctx.Err()
+}
+
+// decides reports whether a race event may decide the bets on the race: a result, or an abandonment.
+func decides(event *racing.RaceEvent) bool {
+	return event.Type == racing.RaceEvent_RESULTED || event.Transition.GetTo() == racing.Race_ABANDONED
+}
@@ -0,0 +1,144 @@
+// Package errs defines the errors shared by the services.
+//
+// Repositories and services return these, rather than gRPC statuses or driver errors, and the
+// interceptors in this package translate them into statuses with google.rpc error details at the edge
+// of the gRPC server. Any other error is reported to clients as an internal error, without its text.
+package errs
+
+import (
+	"fmt"
+	"time"
+)
+
+// NotFound is returned when a resource does not exist.
+type NotFound struct {
+	// Resource is the type of resource, such as "race".
+	Resource string
+	// ID identifies the resource that was looked for.
+	ID string
+}
+
+func (e *NotFound) Error() string {
+	return fmt.Sprintf("%s %s not found", e.Resource, e.ID)
+}
+
+// FieldViolation describes a problem with a single field of a request.
+type FieldViolation struct {
+	// Field is the path to the field, such as "filter.meeting_ids".
+	Field string
+	// Description says what is wrong with the field.
+	Description string
+}
+
+// InvalidArgument is returned when a request is malformed.
+type InvalidArgument struct {
+	Violations []FieldViolation
+}
+
+func (e *InvalidArgument) Error() string {
+	if len(e.Violations) == 0 {
+		return "invalid argument"
+	}
+
+	msg := fmt.Sprintf("invalid %s: %s", e.Violations[0].Field, e.Violations[0].Description)
+	if len(e.Violations) > 1 {
+		msg += fmt.Sprintf(" (and %d more)", len(e.Violations)-1)
+	}
+
+	return msg
+}
+
+// Invalid returns an InvalidArgument error for a single field.
+func Invalid(field, format string, args ...interface{}) *InvalidArgument {
+	return &InvalidArgument{Violations: []FieldViolation{{Field: field, Description: fmt.Sprintf(format, args...)}}}
+}
+
+// InvalidFilter is returned when a filter expression cannot be parsed or type checked.
+type InvalidFilter struct {
+	// Field is the request field holding the filter, such as "filter_expression".
+	Field string
+	// Pos is the 1-based position of the problem within the filter.
+	Pos int
+	// Description says what is wrong with the filter.
+	Description string
+}
+
+func (e *InvalidFilter) Error() string {
+	return fmt.Sprintf("invalid %s at position %d: %s", e.Field, e.Pos, e.Description)
+}
+
+// Conflict is returned when a change cannot be made because of the current state of a resource.
+type Conflict struct {
+	// Resource is the type of resource, such as "race".
+	Resource string
+	// ID identifies the resource in conflict.
+	ID string
+	// Reason says why the change cannot be made.
+	Reason string
+}
+
+func (e *Conflict) Error() string {
+	return fmt.Sprintf("%s %s conflict: %s", e.Resource, e.ID, e.Reason)
+}
+
+// FailedPrecondition is returned when a change is not allowed from the current state of a resource,
+// and will not be until that state is changed by other means. Unlike a Conflict, retrying will not help.
+type FailedPrecondition struct {
+	// Resource is the type of resource, such as "race".
+	Resource string
+	// ID identifies the resource.
+	ID string
+	// Reason says why the change is not allowed.
+	Reason string
+}
+
+func (e *FailedPrecondition) Error() string {
+	return fmt.Sprintf("%s %s: %s", e.Resource, e.ID, e.Reason)
+}
+
+// PermissionDenied is returned when a request is made on behalf of a customer who may not act on a resource,
+// such as another customer's bet.
+type PermissionDenied struct {
+	// Resource is the type of resource, such as "bet".
+	Resource string
+	// ID identifies the resource, if the request named one.
+	ID string
+	// Reason says why the customer may not act on it.
+	Reason string
+}
+
+func (e *PermissionDenied) Error() string {
+	if e.ID == "" {
+		return fmt.Sprintf("%s: permission denied: %s", e.Resource, e.Reason)
+	}
+
+	return fmt.Sprintf("%s %s: permission denied: %s", e.Resource, e.ID, e.Reason)
+}
+
+// Unimplemented is returned when a call is not supported by the service as it was built or configured.
+// Retrying will not help.
+type Unimplemented struct {
+	// Reason says why the call is not supported.
+	Reason string
+}
+
+func (e *Unimplemented) Error() string {
+	return fmt.Sprintf("unimplemented: %s", e.Reason)
+}
+
+// Unavailable is returned when a dependency, such as the database, cannot currently serve the request.
+// The request may succeed if it is retried.
+type Unavailable struct {
+	// RetryAfter suggests how long to wait before retrying, if known.
+	RetryAfter time.Duration
+	// Err is the underlying cause, which is never shown to clients.
+	Err error
+}
+
+func (e *Unavailable) Error() string {
+	return fmt.Sprintf("unavailable: %s", e.Err)
+}
+
+func (e *Unavailable) Unwrap() error {
+	return e.Err
+}
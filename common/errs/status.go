@@ -0,0 +1,165 @@
+package errs
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Status converts an error into the gRPC status sent to clients, naming the domain of the service that
+// returned it, such as "racing.entain.com", in its ErrorInfo.
+func Status(err error, domain string) *status.Status {
+	if st, ok := status.FromError(err); ok {
+		return st
+	}
+
+	var (
+		notFound        *NotFound
+		invalidArgument *InvalidArgument
+		invalidFilter   *InvalidFilter
+		conflict        *Conflict
+		precondition    *FailedPrecondition
+		denied          *PermissionDenied
+		unimplemented   *Unimplemented
+		unavailable     *Unavailable
+	)
+
+	switch {
+	case errors.As(err, &notFound):
+		return withDetails(status.New(codes.NotFound, notFound.Error()),
+			&errdetails.ErrorInfo{
+				Reason:   reason(notFound.Resource, "NOT_FOUND"),
+				Domain:   domain,
+				Metadata: map[string]string{"id": notFound.ID},
+			},
+			&errdetails.ResourceInfo{
+				ResourceType: notFound.Resource,
+				ResourceName: notFound.ID,
+			},
+		)
+	case errors.As(err, &invalidArgument):
+		badRequest := &errdetails.BadRequest{}
+		for _, violation := range invalidArgument.Violations {
+			badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+				Field:       violation.Field,
+				Description: violation.Description,
+			})
+		}
+
+		return withDetails(status.New(codes.InvalidArgument, invalidArgument.Error()),
+			&errdetails.ErrorInfo{Reason: "INVALID_ARGUMENT", Domain: domain},
+			badRequest,
+		)
+	case errors.As(err, &invalidFilter):
+		return withDetails(status.New(codes.InvalidArgument, invalidFilter.Error()),
+			&errdetails.ErrorInfo{
+				Reason:   "INVALID_FILTER",
+				Domain:   domain,
+				Metadata: map[string]string{"position": strconv.Itoa(invalidFilter.Pos)},
+			},
+			&errdetails.BadRequest{FieldViolations: []*errdetails.BadRequest_FieldViolation{{
+				Field:       invalidFilter.Field,
+				Description: invalidFilter.Error(),
+			}}},
+		)
+	case errors.As(err, &conflict):
+		return withDetails(status.New(codes.Aborted, conflict.Error()),
+			&errdetails.ErrorInfo{
+				Reason:   reason(conflict.Resource, "CONFLICT"),
+				Domain:   domain,
+				Metadata: map[string]string{"id": conflict.ID},
+			},
+		)
+	case errors.As(err, &precondition):
+		return withDetails(status.New(codes.FailedPrecondition, precondition.Error()),
+			&errdetails.ErrorInfo{
+				Reason:   reason(precondition.Resource, "FAILED_PRECONDITION"),
+				Domain:   domain,
+				Metadata: map[string]string{"id": precondition.ID},
+			},
+			&errdetails.PreconditionFailure{Violations: []*errdetails.PreconditionFailure_Violation{{
+				Type:        "STATE",
+				Subject:     precondition.Resource + "/" + precondition.ID,
+				Description: precondition.Reason,
+			}}},
+		)
+	case errors.As(err, &denied):
+		return withDetails(status.New(codes.PermissionDenied, denied.Error()),
+			&errdetails.ErrorInfo{
+				Reason:   reason(denied.Resource, "PERMISSION_DENIED"),
+				Domain:   domain,
+				Metadata: map[string]string{"id": denied.ID},
+			},
+		)
+	case errors.As(err, &unimplemented):
+		return withDetails(status.New(codes.Unimplemented, unimplemented.Error()),
+			&errdetails.ErrorInfo{Reason: "UNIMPLEMENTED", Domain: domain},
+		)
+	case errors.As(err, &unavailable):
+		log.Printf("unavailable: %s\n", unavailable.Err)
+
+		st := status.New(codes.Unavailable, "the service is temporarily unavailable, please retry")
+		if unavailable.RetryAfter > 0 {
+			return withDetails(st, &errdetails.RetryInfo{RetryDelay: ptypes.DurationProto(unavailable.RetryAfter)})
+		}
+
+		return st
+	case errors.Is(err, context.Canceled):
+		return status.New(codes.Canceled, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.New(codes.DeadlineExceeded, err.Error())
+	}
+
+	// Anything else is a bug, or a failure we did not anticipate, and its text may reveal our internals.
+	log.Printf("internal error: %s\n", err)
+
+	return status.New(codes.Internal, "internal error")
+}
+
+// UnaryServerInterceptor converts the errors of unary RPCs into gRPC statuses of the given domain.
+func UnaryServerInterceptor(domain string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		resp, err := handler(ctx, req)
+		if err != nil {
+			return nil, Status(err, domain).Err()
+		}
+
+		return resp, nil
+	}
+}
+
+// StreamServerInterceptor converts the errors of streaming RPCs into gRPC statuses of the given domain.
+func StreamServerInterceptor(domain string) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := handler(srv, ss); err != nil {
+			return Status(err, domain).Err()
+		}
+
+		return nil
+	}
+}
+
+// withDetails attaches error details to a status, falling back to the bare status should that fail.
+func withDetails(st *status.Status, details ...proto.Message) *status.Status {
+	detailed, err := st.WithDetails(details...)
+	if err != nil {
+		log.Printf("failed attaching error details: %s\n", err)
+		return st
+	}
+
+	return detailed
+}
+
+// reason builds an ErrorInfo reason, such as RACE_NOT_FOUND.
+func reason(resource, suffix string) string {
+	return strings.ToUpper(strings.ReplaceAll(resource, " ", "_")) + "_" + suffix
+}
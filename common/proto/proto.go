@@ -0,0 +1,5 @@
+package proto
+
+// The validation rules live in the proto directory at the root of the repo, alongside the protos of the
+// services that declare them. They declare no go_package, so every module maps them onto this package.
+//go:generate protoc -I ../../proto --go_out . --go_opt paths=source_relative --go_opt Mvalidate/validate.proto=git.neds.sh/matty/entain/common/proto/validate validate/validate.proto
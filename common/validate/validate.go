@@ -0,0 +1,197 @@
+// Package validate enforces the validation rules declared on the fields of request messages with the
+// (validate.rules) option, see proto/validate/validate.proto at the root of the repo.
+//
+// Rules are read from the message descriptors at runtime, so adding a rule to a field in the proto is
+// all it takes to enforce it. The fields of nested messages are validated too, whether or not they have
+// rules of their own.
+package validate
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"git.neds.sh/matty/entain/common/errs"
+	validatepb "git.neds.sh/matty/entain/common/proto/validate"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Validate checks a message against the rules declared on its fields. It returns an
+// *errs.InvalidArgument listing every violation, or nil if there are none.
+func Validate(msg proto.Message) error {
+	v := &validator{}
+	v.message(msg.ProtoReflect(), "")
+
+	if len(v.violations) == 0 {
+		return nil
+	}
+
+	return &errs.InvalidArgument{Violations: v.violations}
+}
+
+// UnaryServerInterceptor rejects unary requests that fail validation, before they reach the service.
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if msg, ok := req.(proto.Message); ok {
+			if err := Validate(msg); err != nil {
+				return nil, err
+			}
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor rejects each message received on a stream that fails validation.
+func StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &validatingStream{ss})
+	}
+}
+
+// validatingStream validates the messages received on a server stream.
+type validatingStream struct {
+	grpc.ServerStream
+}
+
+func (s *validatingStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+
+	if msg, ok := m.(proto.Message); ok {
+		return Validate(msg)
+	}
+
+	return nil
+}
+
+// validator collects the violations found in a message.
+type validator struct {
+	violations []errs.FieldViolation
+}
+
+func (v *validator) violate(path, format string, args ...interface{}) {
+	v.violations = append(v.violations, errs.FieldViolation{Field: path, Description: fmt.Sprintf(format, args...)})
+}
+
+// message validates every field of a message, naming them with the given path prefix.
+func (v *validator) message(msg protoreflect.Message, prefix string) {
+	fields := msg.Descriptor().Fields()
+
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		path := prefix + string(fd.Name())
+		rules, _ := proto.GetExtension(fd.Options(), validatepb.E_Rules).(*validatepb.FieldRules)
+
+		switch {
+		case fd.IsMap():
+			// No rules apply to maps yet.
+		case fd.IsList():
+			v.list(fd, msg.Get(fd).List(), path, rules.GetRepeated())
+		case fd.Message() != nil:
+			if msg.Has(fd) {
+				v.message(msg.Get(fd).Message(), path+".")
+			} else if rules.GetMessage().GetRequired() {
+				v.violate(path, "is required")
+			}
+		default:
+			v.scalar(msg.Get(fd), path, rules)
+		}
+	}
+}
+
+// list validates a repeated field, and each of its items.
+func (v *validator) list(fd protoreflect.FieldDescriptor, list protoreflect.List, path string, rules *validatepb.RepeatedRules) {
+	if rules == nil {
+		rules = &validatepb.RepeatedRules{}
+	}
+
+	if rules.MinItems != nil && uint64(list.Len()) < rules.GetMinItems() {
+		v.violate(path, "must have at least %d items", rules.GetMinItems())
+	}
+
+	if rules.MaxItems != nil && uint64(list.Len()) > rules.GetMaxItems() {
+		// The items of an oversized list are not checked, so a huge request cannot produce a huge error.
+		v.violate(path, "must have at most %d items", rules.GetMaxItems())
+		return
+	}
+
+	seen := make(map[interface{}]bool)
+
+	for i := 0; i < list.Len(); i++ {
+		item := list.Get(i)
+		itemPath := fmt.Sprintf("%s[%d]", path, i)
+
+		if fd.Message() != nil {
+			v.message(item.Message(), itemPath+".")
+			continue
+		}
+
+		if rules.GetUnique() {
+			if seen[item.Interface()] {
+				v.violate(itemPath, "must not repeat %v", item.Interface())
+			}
+
+			seen[item.Interface()] = true
+		}
+
+		v.scalar(item, itemPath, rules.GetItems())
+	}
+}
+
+// scalar validates a single scalar value.
+func (v *validator) scalar(value protoreflect.Value, path string, rules *validatepb.FieldRules) {
+	switch r := rules.GetType().(type) {
+	case *validatepb.FieldRules_Int32:
+		v.bounds(value.Int(), path, widen(r.Int32.Gt), widen(r.Int32.Gte), widen(r.Int32.Lt), widen(r.Int32.Lte))
+	case *validatepb.FieldRules_Int64:
+		v.bounds(value.Int(), path, r.Int64.Gt, r.Int64.Gte, r.Int64.Lt, r.Int64.Lte)
+	case *validatepb.FieldRules_String_:
+		v.string(value.String(), path, r.String_)
+	}
+}
+
+// bounds checks that an integer lies within the given bounds, any of which may be nil.
+func (v *validator) bounds(n int64, path string, gt, gte, lt, lte *int64) {
+	switch {
+	case gt != nil && n <= *gt:
+		v.violate(path, "must be greater than %d", *gt)
+	case gte != nil && n < *gte:
+		v.violate(path, "must be at least %d", *gte)
+	case lt != nil && n >= *lt:
+		v.violate(path, "must be less than %d", *lt)
+	case lte != nil && n > *lte:
+		v.violate(path, "must be at most %d", *lte)
+	}
+}
+
+func (v *validator) string(s, path string, rules *validatepb.StringRules) {
+	if rules.GetRequired() && strings.TrimSpace(s) == "" {
+		v.violate(path, "is required")
+		return
+	}
+
+	length := uint64(utf8.RuneCountInString(s))
+
+	switch {
+	case rules.MinLen != nil && length < rules.GetMinLen():
+		v.violate(path, "must be at least %d characters", rules.GetMinLen())
+	case rules.MaxLen != nil && length > rules.GetMaxLen():
+		v.violate(path, "must be at most %d characters", rules.GetMaxLen())
+	}
+}
+
+// widen converts an optional int32 bound into an int64 one.
+func widen(n *int32) *int64 {
+	if n == nil {
+		return nil
+	}
+
+	wide := int64(*n)
+
+	return &wide
+}
@@ -0,0 +1,228 @@
+// Package client is a Go SDK for the racing service.
+//
+// A Client dials the service once, and takes care of call timeouts and of retrying failed calls with
+// backoff, by status code:
+//
+//	c, err := client.New(ctx, client.WithEndpoint("racing:9000"), client.WithTimeout(2*time.Second))
+//	if err != nil {
+//		return err
+//	}
+//	defer c.Close()
+//
+//	races := client.ListRaces(ctx, c, &racing.ListRacesRequest{Filter: &racing.ListRacesRequestFilter{MeetingIds: []int64{1}}})
+//	for races.Next() {
+//		fmt.Println(races.Race().Name)
+//	}
+//	if err := races.Err(); err != nil {
+//		return err
+//	}
+//
+// The helpers of this package work with any Racing, so code using them can be tested against a Fake.
+package client
+
+import (
+	"context"
+
+	"git.neds.sh/matty/entain/racing/proto/racing"
+	"google.golang.org/genproto/protobuf/field_mask"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+// Racing is the racing API, as implemented by both the Client and the Fake.
+type Racing interface {
+	// ListRaces will return a page of races. Use ListRaces or AllRaces to iterate over every page.
+	ListRaces(ctx context.Context, in *racing.ListRacesRequest) (*racing.ListRacesResponse, error)
+
+	// GetRace will return a single race by ID. IsNotFound reports whether it does not exist.
+	GetRace(ctx context.Context, id int64) (*racing.Race, error)
+
+	// SearchRaces will return the races best matching a free text query.
+	SearchRaces(ctx context.Context, in *racing.SearchRacesRequest) (*racing.SearchRacesResponse, error)
+
+	// CreateRace will create a race, assigning it an ID unless it has one.
+	CreateRace(ctx context.Context, race *racing.Race) (*racing.Race, error)
+
+	// UpdateRace will change the named fields of a race, or all of them if none are named.
+	UpdateRace(ctx context.Context, race *racing.Race, fields ...string) (*racing.Race, error)
+
+	// DeleteRace will delete a race by ID.
+	DeleteRace(ctx context.Context, id int64) error
+
+	// TransitionRace will move a race to another state of its lifecycle, on behalf of the given actor.
+	TransitionRace(ctx context.Context, id int64, state racing.Race_State, actor, reason string) (*racing.TransitionRaceResponse, error)
+
+	// ListRaceTransitions will return every state change of a race, oldest first.
+	ListRaceTransitions(ctx context.Context, raceID int64) ([]*racing.RaceTransition, error)
+
+	// SubmitResult will record or amend the result of a race.
+	SubmitResult(ctx context.Context, in *racing.SubmitResultRequest) (*racing.Result, error)
+
+	// GetResult will return the current result of a race, along with its earlier versions if history is set.
+	GetResult(ctx context.Context, raceID int64, history bool) (*racing.GetResultResponse, error)
+
+	// ScratchRunner will withdraw a runner from a race, returning the race and the deductions the
+	// scratching brings.
+	ScratchRunner(ctx context.Context, in *racing.ScratchRunnerRequest) (*racing.ScratchRunnerResponse, error)
+
+	// UpdatePrices will set the fixed-odds prices of runners in a race, returning its market.
+	UpdatePrices(ctx context.Context, in *racing.UpdatePricesRequest) (*racing.Market, error)
+
+	// GetMarket will return the fixed-odds market of a race in the given odds format, along with the
+	// flucs of every runner if flucs is set.
+	GetMarket(ctx context.Context, raceID int64, format racing.OddsFormat, flucs bool) (*racing.Market, error)
+
+	// EstimateDividends will estimate the dividends of tote pools on a race.
+	EstimateDividends(ctx context.Context, in *racing.EstimateDividendsRequest) (*racing.EstimateDividendsResponse, error)
+
+	// SuspendTrading will suspend trading on a race, a meeting or all racing, until it is resumed or for
+	// the request's duration.
+	SuspendTrading(ctx context.Context, in *racing.SuspendTradingRequest) (*racing.Suspension, error)
+
+	// WatchRaces will stream changes to races from now on, until the context is done. Use Watch to
+	// keep watching through dropped connections.
+	WatchRaces(ctx context.Context, in *racing.WatchRacesRequest) (RaceWatcher, error)
+}
+
+// RaceWatcher receives the events of a race watch.
+type RaceWatcher interface {
+	// Recv will return the next event, blocking until there is one.
+	Recv() (*racing.RaceEvent, error)
+}
+
+// Client is a Racing backed by a connection to the racing service. It is safe for concurrent use.
+type Client struct {
+	conn   *grpc.ClientConn
+	racing racing.RacingClient
+}
+
+var (
+	_ Racing = (*Client)(nil)
+	_ Racing = (*Fake)(nil)
+)
+
+// New dials the racing service. Unless WithDialTimeout is given, it does not wait for the connection
+// to be established, and calls made before then wait for it instead.
+func New(ctx context.Context, opts ...Option) (*Client, error) {
+	o := defaultOptions()
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	dialOpts := []grpc.DialOption{
+		grpc.WithChainUnaryInterceptor(timeoutInterceptor(o.timeout), retryInterceptor(o.retries)),
+	}
+
+	if o.tls != nil {
+		dialOpts = append(dialOpts, grpc.WithTransportCredentials(credentials.NewTLS(o.tls)))
+	} else {
+		dialOpts = append(dialOpts, grpc.WithInsecure())
+	}
+
+	if o.dialTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, o.dialTimeout)
+		defer cancel()
+
+		dialOpts = append(dialOpts, grpc.WithBlock())
+	}
+
+	conn, err := grpc.DialContext(ctx, o.endpoint, append(dialOpts, o.dialOptions...)...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Client{conn: conn, racing: racing.NewRacingClient(conn)}, nil
+}
+
+// NewFromConn wraps an existing connection to the racing service. Its timeouts and retries are
+// those of the connection.
+func NewFromConn(conn *grpc.ClientConn) *Client {
+	return &Client{conn: conn, racing: racing.NewRacingClient(conn)}
+}
+
+// Close closes the connection to the racing service.
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
+
+// Raw returns the generated gRPC client, for calls this package does not wrap.
+func (c *Client) Raw() racing.RacingClient {
+	return c.racing
+}
+
+func (c *Client) ListRaces(ctx context.Context, in *racing.ListRacesRequest) (*racing.ListRacesResponse, error) {
+	return c.racing.ListRaces(ctx, in)
+}
+
+func (c *Client) GetRace(ctx context.Context, id int64) (*racing.Race, error) {
+	return c.racing.GetRace(ctx, &racing.GetRaceRequest{Id: id})
+}
+
+func (c *Client) SearchRaces(ctx context.Context, in *racing.SearchRacesRequest) (*racing.SearchRacesResponse, error) {
+	return c.racing.SearchRaces(ctx, in)
+}
+
+func (c *Client) CreateRace(ctx context.Context, race *racing.Race) (*racing.Race, error) {
+	return c.racing.CreateRace(ctx, &racing.CreateRaceRequest{Race: race})
+}
+
+func (c *Client) UpdateRace(ctx context.Context, race *racing.Race, fields ...string) (*racing.Race, error) {
+	in := &racing.UpdateRaceRequest{Race: race}
+	if len(fields) > 0 {
+		in.UpdateMask = &field_mask.FieldMask{Paths: fields}
+	}
+
+	return c.racing.UpdateRace(ctx, in)
+}
+
+func (c *Client) DeleteRace(ctx context.Context, id int64) error {
+	_, err := c.racing.DeleteRace(ctx, &racing.DeleteRaceRequest{Id: id})
+
+	return err
+}
+
+func (c *Client) TransitionRace(ctx context.Context, id int64, state racing.Race_State, actor, reason string) (*racing.TransitionRaceResponse, error) {
+	return c.racing.TransitionRace(ctx, &racing.TransitionRaceRequest{Id: id, State: state, Actor: actor, Reason: reason})
+}
+
+func (c *Client) ListRaceTransitions(ctx context.Context, raceID int64) ([]*racing.RaceTransition, error) {
+	resp, err := c.racing.ListRaceTransitions(ctx, &racing.ListRaceTransitionsRequest{RaceId: raceID})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Transitions, nil
+}
+
+func (c *Client) SubmitResult(ctx context.Context, in *racing.SubmitResultRequest) (*racing.Result, error) {
+	return c.racing.SubmitResult(ctx, in)
+}
+
+func (c *Client) GetResult(ctx context.Context, raceID int64, history bool) (*racing.GetResultResponse, error) {
+	return c.racing.GetResult(ctx, &racing.GetResultRequest{RaceId: raceID, History: history})
+}
+
+func (c *Client) ScratchRunner(ctx context.Context, in *racing.ScratchRunnerRequest) (*racing.ScratchRunnerResponse, error) {
+	return c.racing.ScratchRunner(ctx, in)
+}
+
+func (c *Client) UpdatePrices(ctx context.Context, in *racing.UpdatePricesRequest) (*racing.Market, error) {
+	return c.racing.UpdatePrices(ctx, in)
+}
+
+func (c *Client) GetMarket(ctx context.Context, raceID int64, format racing.OddsFormat, flucs bool) (*racing.Market, error) {
+	return c.racing.GetMarket(ctx, &racing.GetMarketRequest{RaceId: raceID, OddsFormat: format, Flucs: flucs})
+}
+
+func (c *Client) EstimateDividends(ctx context.Context, in *racing.EstimateDividendsRequest) (*racing.EstimateDividendsResponse, error) {
+	return c.racing.EstimateDividends(ctx, in)
+}
+
+func (c *Client) SuspendTrading(ctx context.Context, in *racing.SuspendTradingRequest) (*racing.Suspension, error) {
+	return c.racing.SuspendTrading(ctx, in)
+}
+
+func (c *Client) WatchRaces(ctx context.Context, in *racing.WatchRacesRequest) (RaceWatcher, error) {
+	return c.racing.WatchRaces(ctx, in)
+}
@@ -0,0 +1,655 @@
+package client
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"git.neds.sh/matty/entain/common/errs"
+	"git.neds.sh/matty/entain/racing/lifecycle"
+	"git.neds.sh/matty/entain/racing/odds"
+	"git.neds.sh/matty/entain/racing/proto/racing"
+	"git.neds.sh/matty/entain/racing/results"
+	"git.neds.sh/matty/entain/racing/scratchings"
+	"git.neds.sh/matty/entain/racing/suspensions"
+	"git.neds.sh/matty/entain/racing/tote"
+	"git.neds.sh/matty/entain/racing/transfer"
+	"github.com/golang/protobuf/ptypes"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Fake is an in-memory Racing, for testing code that uses the racing service without running it.
+//
+// It lists, pages, gets, searches, watches, creates, updates, deletes, transitions, results, scratches,
+// prices and suspends races, and estimates their dividends, much as the service does, with these
+// exceptions: filter expressions are not supported, and are rejected as unimplemented; searches match
+// the words of a query against race names only; page tokens are not tied to the filter they were issued
+// for; and suspensions stay on the races they cover, never resuming by themselves.
+// Errors are gRPC statuses with the same codes the service would use.
+type Fake struct {
+	// Now is the clock used to stamp the update time of races. It defaults to time.Now.
+	Now func() time.Time
+
+	mu          sync.Mutex
+	races       map[int64]*racing.Race
+	transitions map[int64][]*racing.RaceTransition
+	results     map[int64][]*racing.Result
+	prices      map[int64]map[int64]*racing.RunnerPrices
+	suspensions []*racing.Suspension
+	watchers    map[*fakeWatcher]struct{}
+}
+
+// NewFake returns a Fake holding the given races.
+func NewFake(races ...*racing.Race) *Fake {
+	f := &Fake{
+		Now:         time.Now,
+		races:       make(map[int64]*racing.Race),
+		transitions: make(map[int64][]*racing.RaceTransition),
+		results:     make(map[int64][]*racing.Result),
+		prices:      make(map[int64]map[int64]*racing.RunnerPrices),
+		watchers:    make(map[*fakeWatcher]struct{}),
+	}
+
+	for _, race := range races {
+		f.races[race.Id] = proto.Clone(race).(*racing.Race)
+	}
+
+	return f
+}
+
+// PutRace creates or updates a race, stamping its update time and telling any watchers about it.
+// Unlike CreateRace and UpdateRace, it does not validate the race, and it sets its state as given.
+func (f *Fake) PutRace(race *racing.Race) *racing.Race {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.put(race)
+}
+
+// put stores a race, assigning it the next free ID if it has none. The caller must hold the lock.
+func (f *Fake) put(race *racing.Race) *racing.Race {
+	stored := proto.Clone(race).(*racing.Race)
+	stored.Result = nil
+	stored.UpdateTime, _ = ptypes.TimestampProto(f.Now())
+
+	if stored.Id == 0 {
+		for id := range f.races {
+			if id > stored.Id {
+				stored.Id = id
+			}
+		}
+
+		stored.Id++
+	}
+
+	event := &racing.RaceEvent{Type: racing.RaceEvent_CREATED, Race: stored}
+	if _, ok := f.races[stored.Id]; ok {
+		event.Type = racing.RaceEvent_UPDATED
+	}
+
+	f.races[stored.Id] = stored
+	f.notify(event)
+
+	return proto.Clone(stored).(*racing.Race)
+}
+
+// notify tells the watchers of the race about an event. The caller must hold the lock.
+func (f *Fake) notify(event *racing.RaceEvent) {
+	for watcher := range f.watchers {
+		if matchesFilter(watcher.filter, event.Race) {
+			watcher.events <- proto.Clone(event).(*racing.RaceEvent)
+		}
+	}
+}
+
+func (f *Fake) CreateRace(ctx context.Context, race *racing.Race) (*racing.Race, error) {
+	if err := transfer.ValidateNewRace(race); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, ok := f.races[race.Id]; ok {
+		return nil, status.Errorf(codes.Aborted, "race %d conflict: a race with this ID already exists", race.Id)
+	}
+
+	created := proto.Clone(race).(*racing.Race)
+	created.State = racing.Race_SCHEDULED
+	created.Scratchings = nil
+
+	return f.put(created), nil
+}
+
+func (f *Fake) UpdateRace(ctx context.Context, race *racing.Race, fields ...string) (*racing.Race, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	existing, ok := f.races[race.Id]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "race %d not found", race.Id)
+	}
+
+	updated := proto.Clone(existing).(*racing.Race)
+
+	if len(fields) == 0 {
+		updated = proto.Clone(race).(*racing.Race)
+		updated.State = existing.State
+		updated.Scratchings = existing.Scratchings
+	}
+
+	for _, field := range fields {
+		fd := updated.ProtoReflect().Descriptor().Fields().ByName(protoreflect.Name(field))
+		if fd == nil || field == "update_time" || field == "state" || field == "scratchings" {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid update_mask: %q is not a field that can be updated", field)
+		}
+
+		updated.ProtoReflect().Set(fd, race.ProtoReflect().Get(fd))
+	}
+
+	if err := transfer.ValidateRace(updated); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	return f.put(updated), nil
+}
+
+func (f *Fake) DeleteRace(ctx context.Context, id int64) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	race, ok := f.races[id]
+	if !ok {
+		return status.Errorf(codes.NotFound, "race %d not found", id)
+	}
+
+	delete(f.races, id)
+	delete(f.transitions, id)
+	delete(f.results, id)
+	delete(f.prices, id)
+	f.notify(&racing.RaceEvent{Type: racing.RaceEvent_DELETED, Race: race})
+
+	return nil
+}
+
+func (f *Fake) TransitionRace(ctx context.Context, id int64, state racing.Race_State, actor, reason string) (*racing.TransitionRaceResponse, error) {
+	if actor == "" {
+		return nil, status.Error(codes.InvalidArgument, "invalid actor: is required")
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	race, ok := f.races[id]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "race %d not found", id)
+	}
+
+	if err := lifecycle.Check(race, state); err != nil {
+		return nil, statusError(err)
+	}
+
+	if state == racing.Race_INTERIM || state == racing.Race_FINAL {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid state: %s is reached by submitting a result", state)
+	}
+
+	moved, transition := f.transition(race, state, actor, reason)
+
+	return &racing.TransitionRaceResponse{
+		Race:       proto.Clone(moved).(*racing.Race),
+		Transition: proto.Clone(transition).(*racing.RaceTransition),
+	}, nil
+}
+
+// transition moves a race to another state, telling any watchers about it. The caller must hold the lock.
+func (f *Fake) transition(race *racing.Race, state racing.Race_State, actor, reason string) (*racing.Race, *racing.RaceTransition) {
+	transition := &racing.RaceTransition{
+		Id:     int64(len(f.transitions[race.Id]) + 1),
+		RaceId: race.Id,
+		From:   race.State,
+		To:     state,
+		Actor:  actor,
+		Reason: reason,
+	}
+	transition.Time, _ = ptypes.TimestampProto(f.Now())
+
+	moved := proto.Clone(race).(*racing.Race)
+	moved.State = state
+	moved.UpdateTime = transition.Time
+
+	f.races[race.Id] = moved
+	f.transitions[race.Id] = append(f.transitions[race.Id], transition)
+	f.notify(&racing.RaceEvent{Type: racing.RaceEvent_UPDATED, Race: moved, Transition: transition})
+
+	return moved, transition
+}
+
+func (f *Fake) SubmitResult(ctx context.Context, in *racing.SubmitResultRequest) (*racing.Result, error) {
+	if in.Actor == "" {
+		return nil, status.Error(codes.InvalidArgument, "invalid actor: is required")
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	race, ok := f.races[in.RaceId]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "race %d not found", in.RaceId)
+	}
+
+	var current *racing.Result
+	if versions := f.results[race.Id]; len(versions) > 0 {
+		current = versions[len(versions)-1]
+	}
+
+	if err := results.Check(race, current, in); err != nil {
+		return nil, statusError(err)
+	}
+
+	result := &racing.Result{
+		RaceId:   race.Id,
+		Version:  current.GetVersion() + 1,
+		Placings: results.Placings(in.Placings),
+		Final:    in.Final,
+		Protest:  in.Protest,
+		Actor:    in.Actor,
+		Reason:   in.Reason,
+	}
+	result.SubmitTime, _ = ptypes.TimestampProto(f.Now())
+
+	for _, state := range results.Moves(race.State, in.Final) {
+		race, _ = f.transition(race, state, in.Actor, strings.ToLower(state.String())+" result")
+	}
+
+	f.results[race.Id] = append(f.results[race.Id], result)
+
+	resulted := proto.Clone(race).(*racing.Race)
+	resulted.Result = result
+	f.notify(&racing.RaceEvent{Type: racing.RaceEvent_RESULTED, Race: resulted})
+
+	return proto.Clone(result).(*racing.Result), nil
+}
+
+func (f *Fake) GetResult(ctx context.Context, raceID int64, history bool) (*racing.GetResultResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, ok := f.races[raceID]; !ok {
+		return nil, status.Errorf(codes.NotFound, "race %d not found", raceID)
+	}
+
+	versions := f.results[raceID]
+	if len(versions) == 0 {
+		return nil, status.Errorf(codes.NotFound, "result %d not found", raceID)
+	}
+
+	resp := &racing.GetResultResponse{Result: proto.Clone(versions[len(versions)-1]).(*racing.Result)}
+
+	if history {
+		for _, result := range versions[:len(versions)-1] {
+			resp.History = append(resp.History, proto.Clone(result).(*racing.Result))
+		}
+	}
+
+	return resp, nil
+}
+
+func (f *Fake) ScratchRunner(ctx context.Context, in *racing.ScratchRunnerRequest) (*racing.ScratchRunnerResponse, error) {
+	if in.Actor == "" {
+		return nil, status.Error(codes.InvalidArgument, "invalid actor: is required")
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	race, ok := f.races[in.RaceId]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "race %d not found", in.RaceId)
+	}
+
+	if err := scratchings.Check(race, in.RunnerNumber); err != nil {
+		return nil, statusError(err)
+	}
+
+	prices := f.prices[race.Id][in.RunnerNumber]
+
+	scratching := scratchings.New(in, prices)
+	scratching.ScratchTime, _ = ptypes.TimestampProto(f.Now())
+
+	if prices != nil && (prices.Win != nil || prices.Place != nil) {
+		prices.Flucs = append(prices.Flucs, &racing.Fluc{Win: prices.Win, Place: prices.Place, Time: prices.UpdateTime})
+		prices.Win, prices.Place = nil, nil
+		prices.UpdateTime = scratching.ScratchTime
+	}
+
+	scratched := proto.Clone(race).(*racing.Race)
+	scratched.Scratchings = append(scratched.Scratchings, scratching)
+
+	return &racing.ScratchRunnerResponse{Race: f.put(scratched), Scratching: proto.Clone(scratching).(*racing.Scratching)}, nil
+}
+
+func (f *Fake) UpdatePrices(ctx context.Context, in *racing.UpdatePricesRequest) (*racing.Market, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	race, ok := f.races[in.RaceId]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "race %d not found", in.RaceId)
+	}
+
+	if err := odds.Check(race, in.Prices); err != nil {
+		return nil, statusError(err)
+	}
+
+	if f.prices[race.Id] == nil {
+		f.prices[race.Id] = make(map[int64]*racing.RunnerPrices)
+	}
+
+	updateTime, _ := ptypes.TimestampProto(f.Now())
+
+	for _, price := range in.Prices {
+		prices, ok := f.prices[race.Id][price.RunnerNumber]
+		if !ok {
+			prices = &racing.RunnerPrices{RunnerNumber: price.RunnerNumber}
+			f.prices[race.Id][price.RunnerNumber] = prices
+		} else {
+			prices.Flucs = append(prices.Flucs, &racing.Fluc{Win: prices.Win, Place: prices.Place, Time: prices.UpdateTime})
+		}
+
+		prices.Win = fakeOdds(price.Win)
+		prices.Place = fakeOdds(price.Place)
+		prices.UpdateTime = updateTime
+	}
+
+	return f.market(race, in.OddsFormat, false), nil
+}
+
+func (f *Fake) GetMarket(ctx context.Context, raceID int64, format racing.OddsFormat, flucs bool) (*racing.Market, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	race, ok := f.races[raceID]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "race %d not found", raceID)
+	}
+
+	return f.market(race, format, flucs), nil
+}
+
+// market returns a copy of the market of a race. The caller must hold the lock.
+func (f *Fake) market(race *racing.Race, format racing.OddsFormat, flucs bool) *racing.Market {
+	var priced []*racing.RunnerPrices
+
+	for _, prices := range f.prices[race.Id] {
+		copied := proto.Clone(prices).(*racing.RunnerPrices)
+		if !flucs {
+			copied.Flucs = nil
+		}
+
+		priced = append(priced, copied)
+	}
+
+	return odds.NewMarket(race, priced, format)
+}
+
+// fakeOdds returns decimal odds as the service stores them, with none for a price of 0.
+func fakeOdds(decimal float64) *racing.Odds {
+	if decimal == 0 {
+		return nil
+	}
+
+	return &racing.Odds{Decimal: decimal}
+}
+
+func (f *Fake) EstimateDividends(ctx context.Context, in *racing.EstimateDividendsRequest) (*racing.EstimateDividendsResponse, error) {
+	race, err := f.GetRace(ctx, in.RaceId)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := tote.EstimateDividends(race, in)
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	return resp, nil
+}
+
+func (f *Fake) SuspendTrading(ctx context.Context, in *racing.SuspendTradingRequest) (*racing.Suspension, error) {
+	if err := suspensions.Check(in); err != nil {
+		return nil, statusError(err)
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, ok := f.races[in.RaceId]; in.Scope == racing.Suspension_RACE && !ok {
+		return nil, status.Errorf(codes.NotFound, "race %d not found", in.RaceId)
+	}
+
+	suspension, err := suspensions.New(in, f.Now())
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	suspension.Id = int64(len(f.suspensions) + 1)
+	f.suspensions = append(f.suspensions, suspension)
+
+	for _, race := range f.sorted() {
+		if !suspensions.Covers(suspension, race) {
+			continue
+		}
+
+		suspended := proto.Clone(race).(*racing.Race)
+		suspended.Suspensions = append(suspended.Suspensions, proto.Clone(suspension).(*racing.Suspension))
+
+		f.races[race.Id] = suspended
+		f.notify(&racing.RaceEvent{Type: racing.RaceEvent_TRADING_SUSPENDED, Race: suspended, Suspension: suspension})
+	}
+
+	return proto.Clone(suspension).(*racing.Suspension), nil
+}
+
+func (f *Fake) ListRaceTransitions(ctx context.Context, raceID int64) ([]*racing.RaceTransition, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, ok := f.races[raceID]; !ok {
+		return nil, status.Errorf(codes.NotFound, "race %d not found", raceID)
+	}
+
+	transitions := make([]*racing.RaceTransition, len(f.transitions[raceID]))
+	for i, transition := range f.transitions[raceID] {
+		transitions[i] = proto.Clone(transition).(*racing.RaceTransition)
+	}
+
+	return transitions, nil
+}
+
+func (f *Fake) ListRaces(ctx context.Context, in *racing.ListRacesRequest) (*racing.ListRacesResponse, error) {
+	if in.FilterExpression != "" {
+		return nil, status.Error(codes.Unimplemented, "the fake racing service does not support filter expressions")
+	}
+
+	if in.PageSize < 0 {
+		return nil, status.Error(codes.InvalidArgument, "invalid page_size: must be at least 0")
+	}
+
+	var afterID int64
+	if in.PageToken != "" {
+		var err error
+		if afterID, err = strconv.ParseInt(in.PageToken, 10, 64); err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid page_token: is invalid, or was issued for a different filter")
+		}
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	resp := &racing.ListRacesResponse{}
+
+	for _, race := range f.sorted() {
+		if race.Id <= afterID || !matchesFilter(in.Filter, race) {
+			continue
+		}
+
+		if in.PageSize > 0 && len(resp.Races) == int(in.PageSize) {
+			resp.NextPageToken = strconv.FormatInt(resp.Races[len(resp.Races)-1].Id, 10)
+			break
+		}
+
+		listed := proto.Clone(race).(*racing.Race)
+		listed.Runners = nil
+		listed.Scratchings = nil
+		resp.Races = append(resp.Races, listed)
+	}
+
+	return resp, nil
+}
+
+func (f *Fake) GetRace(ctx context.Context, id int64) (*racing.Race, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	race, ok := f.races[id]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "race %d not found", id)
+	}
+
+	race = proto.Clone(race).(*racing.Race)
+	if versions := f.results[id]; len(versions) > 0 {
+		race.Result = proto.Clone(versions[len(versions)-1]).(*racing.Result)
+	}
+
+	return race, nil
+}
+
+func (f *Fake) SearchRaces(ctx context.Context, in *racing.SearchRacesRequest) (*racing.SearchRacesResponse, error) {
+	words := strings.Fields(strings.ToLower(in.Query))
+	if len(words) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "invalid query: is required")
+	}
+
+	limit := int(in.Limit)
+	if limit == 0 {
+		limit = 10
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	resp := &racing.SearchRacesResponse{}
+
+	for _, race := range f.sorted() {
+		if len(resp.Results) == limit {
+			break
+		}
+
+		name := strings.ToLower(race.Name)
+
+		matched := true
+		for _, word := range words {
+			matched = matched && strings.Contains(name, word)
+		}
+
+		if matched {
+			resp.Results = append(resp.Results, &racing.RaceSearchResult{
+				Race:    proto.Clone(race).(*racing.Race),
+				Snippet: race.Name,
+				Score:   1,
+			})
+		}
+	}
+
+	return resp, nil
+}
+
+func (f *Fake) WatchRaces(ctx context.Context, in *racing.WatchRacesRequest) (RaceWatcher, error) {
+	watcher := &fakeWatcher{
+		ctx:    ctx,
+		filter: in.Filter,
+		// Tests rarely read every event, so there is room enough that PutRace does not block on them.
+		events: make(chan *racing.RaceEvent, 1024),
+	}
+
+	f.mu.Lock()
+	f.watchers[watcher] = struct{}{}
+	f.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+
+		f.mu.Lock()
+		delete(f.watchers, watcher)
+		f.mu.Unlock()
+	}()
+
+	return watcher, nil
+}
+
+// sorted returns the races in order of their IDs. The caller must hold the lock.
+func (f *Fake) sorted() []*racing.Race {
+	races := make([]*racing.Race, 0, len(f.races))
+	for _, race := range f.races {
+		races = append(races, race)
+	}
+
+	sort.Slice(races, func(i, j int) bool { return races[i].Id < races[j].Id })
+
+	return races
+}
+
+// fakeWatcher receives the events of a watch on a Fake.
+type fakeWatcher struct {
+	ctx    context.Context
+	filter *racing.ListRacesRequestFilter
+	events chan *racing.RaceEvent
+}
+
+func (w *fakeWatcher) Recv() (*racing.RaceEvent, error) {
+	select {
+	case <-w.ctx.Done():
+		if w.ctx.Err() == context.DeadlineExceeded {
+			return nil, status.Error(codes.DeadlineExceeded, w.ctx.Err().Error())
+		}
+
+		return nil, status.Error(codes.Canceled, w.ctx.Err().Error())
+	case event := <-w.events:
+		return event, nil
+	}
+}
+
+// fakeDomain is the domain the racing service names in the ErrorInfo of its errors.
+const fakeDomain = "racing.entain.com"
+
+// statusError converts the errors of the racing domain into statuses with the codes the service uses.
+func statusError(err error) error {
+	return errs.Status(err, fakeDomain).Err()
+}
+
+// matchesFilter reports whether a race is one the filter asks for.
+func matchesFilter(filter *racing.ListRacesRequestFilter, race *racing.Race) bool {
+	return matchesAny(len(filter.GetMeetingIds()), func(i int) bool { return filter.MeetingIds[i] == race.MeetingId }) &&
+		matchesAny(len(filter.GetStates()), func(i int) bool { return filter.States[i] == race.State })
+}
+
+// matchesAny reports whether any of n values match, or true when there are none to match.
+func matchesAny(n int, match func(i int) bool) bool {
+	if n == 0 {
+		return true
+	}
+
+	for i := 0; i < n; i++ {
+		if match(i) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,217 @@
+package client
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"git.neds.sh/matty/entain/racing/proto/racing"
+	"github.com/golang/protobuf/ptypes"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestFakeTransitionRace(t *testing.T) {
+	tests := []struct {
+		name     string
+		from     racing.Race_State
+		to       racing.Race_State
+		actor    string
+		wantCode codes.Code
+	}{
+		{name: "scheduled to open", from: racing.Race_SCHEDULED, to: racing.Race_OPEN, actor: "trader"},
+		{name: "open to closed", from: racing.Race_OPEN, to: racing.Race_CLOSED, actor: "trader"},
+		{name: "closed to open", from: racing.Race_CLOSED, to: racing.Race_OPEN, actor: "trader", wantCode: codes.FailedPrecondition},
+		{name: "abandoned", from: racing.Race_ABANDONED, to: racing.Race_OPEN, actor: "trader", wantCode: codes.FailedPrecondition},
+		{name: "to a result", from: racing.Race_CLOSED, to: racing.Race_INTERIM, actor: "trader", wantCode: codes.InvalidArgument},
+		{name: "without an actor", from: racing.Race_SCHEDULED, to: racing.Race_OPEN, wantCode: codes.InvalidArgument},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := NewFake(&racing.Race{Id: 1, MeetingId: 1, Name: "Race", State: tt.from})
+
+			_, err := f.TransitionRace(context.Background(), 1, tt.to, tt.actor, "")
+			if code := status.Code(err); code != tt.wantCode {
+				t.Fatalf("TransitionRace() = %v, want code %s", err, tt.wantCode)
+			}
+
+			want := tt.to
+			if tt.wantCode != codes.OK {
+				want = tt.from
+			}
+
+			race, err := f.GetRace(context.Background(), 1)
+			if err != nil {
+				t.Fatalf("GetRace() = %v", err)
+			}
+
+			if race.State != want {
+				t.Errorf("state = %s, want %s", race.State, want)
+			}
+
+			transitions, err := f.ListRaceTransitions(context.Background(), 1)
+			if err != nil {
+				t.Fatalf("ListRaceTransitions() = %v", err)
+			}
+
+			if moved := len(transitions) == 1; moved != (tt.wantCode == codes.OK) {
+				t.Errorf("transitions = %v, want one only if the race moved", transitions)
+			}
+		})
+	}
+}
+
+func TestFakeCreateRace(t *testing.T) {
+	f := NewFake()
+	race := &racing.Race{Id: 7, MeetingId: 1, Name: "Race", Number: 1, AdvertisedStartTime: ptypes.TimestampNow(), State: racing.Race_FINAL}
+
+	created, err := f.CreateRace(context.Background(), race)
+	if err != nil {
+		t.Fatalf("CreateRace() = %v", err)
+	}
+
+	if created.State != racing.Race_SCHEDULED {
+		t.Errorf("state = %s, want new races %s", created.State, racing.Race_SCHEDULED)
+	}
+
+	if _, err := f.CreateRace(context.Background(), race); status.Code(err) != codes.Aborted {
+		t.Errorf("CreateRace() of an existing race = %v, want code %s", err, codes.Aborted)
+	}
+
+	if err := f.DeleteRace(context.Background(), 7); err != nil {
+		t.Fatalf("DeleteRace() = %v", err)
+	}
+
+	if _, err := f.GetRace(context.Background(), 7); !IsNotFound(err) {
+		t.Errorf("GetRace() of a deleted race = %v, want not found", err)
+	}
+}
+
+func TestFakeSuspendTrading(t *testing.T) {
+	tests := []struct {
+		name          string
+		req           *racing.SuspendTradingRequest
+		wantSuspended []int64
+		wantCode      codes.Code
+	}{
+		{
+			name:          "race",
+			req:           &racing.SuspendTradingRequest{Scope: racing.Suspension_RACE, RaceId: 2, Actor: "trader", Reason: "protest"},
+			wantSuspended: []int64{2},
+		},
+		{
+			name:          "meeting",
+			req:           &racing.SuspendTradingRequest{Scope: racing.Suspension_MEETING, MeetingId: 1, Actor: "trader", Reason: "track incident"},
+			wantSuspended: []int64{1, 3},
+		},
+		{
+			name:          "global",
+			req:           &racing.SuspendTradingRequest{Scope: racing.Suspension_GLOBAL, Actor: "trader", Reason: "outage"},
+			wantSuspended: []int64{1, 2, 3},
+		},
+		{
+			name:     "unknown race",
+			req:      &racing.SuspendTradingRequest{Scope: racing.Suspension_RACE, RaceId: 9, Actor: "trader"},
+			wantCode: codes.NotFound,
+		},
+		{
+			name:     "race naming a meeting",
+			req:      &racing.SuspendTradingRequest{Scope: racing.Suspension_RACE, RaceId: 2, MeetingId: 2, Actor: "trader"},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:     "without a scope",
+			req:      &racing.SuspendTradingRequest{RaceId: 2, Actor: "trader"},
+			wantCode: codes.InvalidArgument,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := NewFake(meetingRaces(3)...)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			watcher, err := f.WatchRaces(ctx, &racing.WatchRacesRequest{})
+			if err != nil {
+				t.Fatalf("WatchRaces() = %v", err)
+			}
+
+			suspension, err := f.SuspendTrading(context.Background(), tt.req)
+			if code := status.Code(err); code != tt.wantCode {
+				t.Fatalf("SuspendTrading() = %v, want code %s", err, tt.wantCode)
+			}
+
+			var suspended []int64
+			for _, id := range []int64{1, 2, 3} {
+				race, err := f.GetRace(context.Background(), id)
+				if err != nil {
+					t.Fatalf("GetRace() = %v", err)
+				}
+
+				if race.State != racing.Race_OPEN {
+					t.Errorf("race %d state = %s, want suspensions to leave it %s", id, race.State, racing.Race_OPEN)
+				}
+
+				if len(race.Suspensions) > 0 {
+					suspended = append(suspended, id)
+
+					if race.Suspensions[0].Id != suspension.GetId() {
+						t.Errorf("race %d suspension = %d, want %d", id, race.Suspensions[0].Id, suspension.GetId())
+					}
+				}
+			}
+
+			if !reflect.DeepEqual(suspended, tt.wantSuspended) {
+				t.Errorf("suspended races = %v, want %v", suspended, tt.wantSuspended)
+			}
+
+			for _, id := range tt.wantSuspended {
+				event, err := watcher.Recv()
+				if err != nil {
+					t.Fatalf("Recv() = %v", err)
+				}
+
+				if event.Type != racing.RaceEvent_TRADING_SUSPENDED || event.Race.Id != id || event.Suspension.GetId() != suspension.Id {
+					t.Errorf("event = %v, want race %d suspended by suspension %d", event, id, suspension.Id)
+				}
+			}
+		})
+	}
+}
+
+func TestFakeSuspensionsDoNotResume(t *testing.T) {
+	now := time.Date(2021, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	f := NewFake(meetingRaces(1)...)
+	f.Now = func() time.Time { return now }
+
+	suspension, err := f.SuspendTrading(context.Background(), &racing.SuspendTradingRequest{
+		Scope:    racing.Suspension_RACE,
+		RaceId:   1,
+		Actor:    "risk",
+		Reason:   "liability",
+		Duration: ptypes.DurationProto(time.Minute),
+	})
+	if err != nil {
+		t.Fatalf("SuspendTrading() = %v", err)
+	}
+
+	if resume, _ := ptypes.Timestamp(suspension.ResumeTime); !resume.Equal(now.Add(time.Minute)) {
+		t.Errorf("resume time = %s, want %s", resume, now.Add(time.Minute))
+	}
+
+	now = now.Add(time.Hour)
+
+	race, err := f.GetRace(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("GetRace() = %v", err)
+	}
+
+	if len(race.Suspensions) != 1 {
+		t.Errorf("suspensions = %v, want the suspension kept past its resume time", race.Suspensions)
+	}
+}
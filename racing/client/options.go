@@ -0,0 +1,105 @@
+package client
+
+import (
+	"crypto/tls"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+)
+
+const (
+	// DefaultEndpoint is where the racing service listens when run locally.
+	DefaultEndpoint = "localhost:9000"
+
+	// DefaultTimeout bounds each call, including its retries, when its context has no deadline.
+	DefaultTimeout = 10 * time.Second
+)
+
+// DefaultRetryPolicy is how calls failing with codes.Unavailable are retried, unless WithRetry says otherwise.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts:    3,
+	InitialBackoff: 100 * time.Millisecond,
+	MaxBackoff:     2 * time.Second,
+	Multiplier:     2,
+}
+
+// Option configures a Client.
+type Option func(*options)
+
+type options struct {
+	endpoint    string
+	tls         *tls.Config
+	dialTimeout time.Duration
+	timeout     time.Duration
+	retries     map[codes.Code]RetryPolicy
+	dialOptions []grpc.DialOption
+}
+
+func defaultOptions() *options {
+	return &options{
+		endpoint: DefaultEndpoint,
+		timeout:  DefaultTimeout,
+		retries:  map[codes.Code]RetryPolicy{codes.Unavailable: DefaultRetryPolicy},
+	}
+}
+
+// WithEndpoint sets the address of the racing service, such as "racing:9000".
+func WithEndpoint(endpoint string) Option {
+	return func(o *options) {
+		o.endpoint = endpoint
+	}
+}
+
+// WithTLS secures the connection with TLS. Without it, the connection is insecure.
+func WithTLS(config *tls.Config) Option {
+	return func(o *options) {
+		o.tls = config
+	}
+}
+
+// WithDialTimeout makes New wait for the connection to be established, for at most the given time.
+func WithDialTimeout(timeout time.Duration) Option {
+	return func(o *options) {
+		o.dialTimeout = timeout
+	}
+}
+
+// WithTimeout bounds each call, including its retries, when its context has no deadline of its own.
+// Zero leaves calls unbounded. Watches are never bounded.
+func WithTimeout(timeout time.Duration) Option {
+	return func(o *options) {
+		o.timeout = timeout
+	}
+}
+
+// WithRetry retries calls failing with any of the given codes according to the policy, replacing any
+// policy those codes had before. A policy with MaxAttempts of 1 or less stops them being retried.
+//
+// Only calls that read races are retried, as a retried write may be applied twice.
+func WithRetry(policy RetryPolicy, codes ...codes.Code) Option {
+	return func(o *options) {
+		for _, code := range codes {
+			if policy.MaxAttempts <= 1 {
+				delete(o.retries, code)
+				continue
+			}
+
+			o.retries[code] = policy
+		}
+	}
+}
+
+// WithoutRetries stops any call being retried.
+func WithoutRetries() Option {
+	return func(o *options) {
+		o.retries = make(map[codes.Code]RetryPolicy)
+	}
+}
+
+// WithDialOptions adds options to those used to dial the racing service, such as interceptors for tracing.
+func WithDialOptions(opts ...grpc.DialOption) Option {
+	return func(o *options) {
+		o.dialOptions = append(o.dialOptions, opts...)
+	}
+}
@@ -0,0 +1,84 @@
+package client
+
+import (
+	"context"
+
+	"git.neds.sh/matty/entain/racing/proto/racing"
+	"google.golang.org/protobuf/proto"
+)
+
+// DefaultPageSize is how many races a RaceIterator fetches at a time, unless the request says otherwise.
+const DefaultPageSize = 100
+
+// RaceIterator steps through every race of a listing, fetching a page at a time as it goes.
+type RaceIterator struct {
+	ctx    context.Context
+	racing Racing
+	req    *racing.ListRacesRequest
+
+	page []*racing.Race
+	race *racing.Race
+	err  error
+	done bool
+}
+
+// ListRaces returns an iterator over every race matching the request, starting from its page token.
+func ListRaces(ctx context.Context, r Racing, in *racing.ListRacesRequest) *RaceIterator {
+	req := proto.Clone(in).(*racing.ListRacesRequest)
+	if req.PageSize == 0 {
+		req.PageSize = DefaultPageSize
+	}
+
+	return &RaceIterator{ctx: ctx, racing: r, req: req}
+}
+
+// Next advances to the next race, returning false once there are no more or a page could not be fetched.
+func (it *RaceIterator) Next() bool {
+	for len(it.page) == 0 {
+		if it.done || it.err != nil {
+			return false
+		}
+
+		resp, err := it.racing.ListRaces(it.ctx, it.req)
+		if err != nil {
+			it.err = err
+			return false
+		}
+
+		it.page = resp.Races
+		it.req.PageToken = resp.NextPageToken
+		it.done = resp.NextPageToken == ""
+	}
+
+	it.race, it.page = it.page[0], it.page[1:]
+
+	return true
+}
+
+// Race returns the current race.
+func (it *RaceIterator) Race() *racing.Race {
+	return it.race
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (it *RaceIterator) Err() error {
+	return it.err
+}
+
+// PageToken resumes the listing after the last page fetched so far, when given as the page token of
+// the same request. It is empty once every page has been fetched.
+func (it *RaceIterator) PageToken() string {
+	return it.req.PageToken
+}
+
+// AllRaces returns every race matching the request, fetching as many pages as it takes.
+func AllRaces(ctx context.Context, r Racing, in *racing.ListRacesRequest) ([]*racing.Race, error) {
+	var races []*racing.Race
+
+	it := ListRaces(ctx, r, in)
+	for it.Next() {
+		races = append(races, it.Race())
+	}
+
+	return races, it.Err()
+}
@@ -0,0 +1,162 @@
+package client
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"git.neds.sh/matty/entain/racing/proto/racing"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// pagingRacing is a Racing counting the pages listed, failing with unavailable once it has listed failAfter
+// pages if that is set.
+type pagingRacing struct {
+	Racing
+
+	pages     int
+	failAfter int
+}
+
+func (r *pagingRacing) ListRaces(ctx context.Context, in *racing.ListRacesRequest) (*racing.ListRacesResponse, error) {
+	if r.failAfter > 0 && r.pages == r.failAfter {
+		return nil, status.Error(codes.Unavailable, "unavailable")
+	}
+
+	r.pages++
+
+	return r.Racing.ListRaces(ctx, in)
+}
+
+// meetingRaces returns races with IDs from 1 to n, the even ones at meeting 2 and the odd ones at meeting 1.
+func meetingRaces(n int64) []*racing.Race {
+	races := make([]*racing.Race, n)
+	for i := range races {
+		id := int64(i + 1)
+		races[i] = &racing.Race{Id: id, MeetingId: 2 - id%2, Name: "Race", State: racing.Race_OPEN}
+	}
+
+	return races
+}
+
+// ids returns the IDs of races.
+func ids(races []*racing.Race) []int64 {
+	var ids []int64
+	for _, race := range races {
+		ids = append(ids, race.Id)
+	}
+
+	return ids
+}
+
+func TestAllRaces(t *testing.T) {
+	tests := []struct {
+		name      string
+		races     int64
+		req       *racing.ListRacesRequest
+		want      []int64
+		wantPages int
+	}{
+		{
+			name:      "several pages",
+			races:     5,
+			req:       &racing.ListRacesRequest{PageSize: 2},
+			want:      []int64{1, 2, 3, 4, 5},
+			wantPages: 3,
+		},
+		{
+			name:      "pages filling exactly",
+			races:     4,
+			req:       &racing.ListRacesRequest{PageSize: 2},
+			want:      []int64{1, 2, 3, 4},
+			wantPages: 2,
+		},
+		{
+			name:      "one page by default",
+			races:     5,
+			req:       &racing.ListRacesRequest{},
+			want:      []int64{1, 2, 3, 4, 5},
+			wantPages: 1,
+		},
+		{
+			name:      "filtered",
+			races:     6,
+			req:       &racing.ListRacesRequest{PageSize: 2, Filter: &racing.ListRacesRequestFilter{MeetingIds: []int64{2}}},
+			want:      []int64{2, 4, 6},
+			wantPages: 2,
+		},
+		{
+			name:      "from a page token",
+			races:     5,
+			req:       &racing.ListRacesRequest{PageSize: 2, PageToken: "2"},
+			want:      []int64{3, 4, 5},
+			wantPages: 2,
+		},
+		{
+			name:      "none",
+			races:     0,
+			req:       &racing.ListRacesRequest{PageSize: 2},
+			wantPages: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &pagingRacing{Racing: NewFake(meetingRaces(tt.races)...)}
+
+			races, err := AllRaces(context.Background(), r, tt.req)
+			if err != nil {
+				t.Fatalf("AllRaces() = %v", err)
+			}
+
+			if got := ids(races); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("races = %v, want %v", got, tt.want)
+			}
+
+			if r.pages != tt.wantPages {
+				t.Errorf("pages = %d, want %d", r.pages, tt.wantPages)
+			}
+		})
+	}
+}
+
+func TestRaceIteratorStopsOnError(t *testing.T) {
+	r := &pagingRacing{Racing: NewFake(meetingRaces(5)...), failAfter: 1}
+	req := &racing.ListRacesRequest{PageSize: 2}
+
+	it := ListRaces(context.Background(), r, req)
+
+	var listed []int64
+	for it.Next() {
+		listed = append(listed, it.Race().Id)
+	}
+
+	if status.Code(it.Err()) != codes.Unavailable {
+		t.Fatalf("Err() = %v, want unavailable", it.Err())
+	}
+
+	if want := []int64{1, 2}; !reflect.DeepEqual(listed, want) {
+		t.Errorf("races = %v, want %v", listed, want)
+	}
+
+	if it.Next() {
+		t.Error("Next() = true after an error, want false")
+	}
+
+	// The listing resumes from the page that failed.
+	r.failAfter = 0
+
+	races, err := AllRaces(context.Background(), r, &racing.ListRacesRequest{PageSize: 2, PageToken: it.PageToken()})
+	if err != nil {
+		t.Fatalf("AllRaces() = %v", err)
+	}
+
+	if got, want := ids(races), []int64{3, 4, 5}; !reflect.DeepEqual(got, want) {
+		t.Errorf("resumed races = %v, want %v", got, want)
+	}
+
+	if req.PageToken != "" {
+		t.Errorf("request page token = %q, want the caller's request left unchanged", req.PageToken)
+	}
+}
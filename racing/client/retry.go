@@ -0,0 +1,118 @@
+package client
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// RetryPolicy says how often, and how soon, a failed call is retried.
+type RetryPolicy struct {
+	// MaxAttempts is the most times a call is made, including the first.
+	MaxAttempts int
+	// InitialBackoff is how long to wait before the first retry.
+	InitialBackoff time.Duration
+	// MaxBackoff caps how long to wait before any retry.
+	MaxBackoff time.Duration
+	// Multiplier grows the backoff after each retry.
+	Multiplier float64
+}
+
+// Backoff returns how long to wait before the given retry, counting from 1. The wait is randomised
+// between half and all of the exponential backoff, so that clients failing together spread out.
+func (p RetryPolicy) Backoff(retry int) time.Duration {
+	backoff := float64(p.InitialBackoff) * math.Pow(math.Max(p.Multiplier, 1), float64(retry-1))
+	if p.MaxBackoff > 0 && backoff > float64(p.MaxBackoff) {
+		backoff = float64(p.MaxBackoff)
+	}
+
+	return time.Duration(backoff/2 + rand.Float64()*backoff/2)
+}
+
+// readMethods are the methods that change nothing, and so may safely be retried.
+var readMethods = map[string]bool{
+	"/racing.Racing/ListRaces":   true,
+	"/racing.Racing/GetRace":     true,
+	"/racing.Racing/SearchRaces": true,
+
+	"/racing.Racing/ListRaceTransitions": true,
+	"/racing.Racing/GetResult":           true,
+	"/racing.Racing/GetMarket":           true,
+	"/racing.Racing/EstimateDividends":   true,
+}
+
+// timeoutInterceptor bounds calls whose context has no deadline.
+func timeoutInterceptor(timeout time.Duration) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if _, ok := ctx.Deadline(); !ok && timeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, timeout)
+			defer cancel()
+		}
+
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+// retryInterceptor retries reads that fail with a code having a retry policy.
+func retryInterceptor(policies map[codes.Code]RetryPolicy) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if !readMethods[method] {
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+
+		for attempt := 1; ; attempt++ {
+			err := invoker(ctx, method, req, reply, cc, opts...)
+			if err == nil {
+				return nil
+			}
+
+			st := status.Convert(err)
+
+			policy, ok := policies[st.Code()]
+			if !ok || attempt >= policy.MaxAttempts {
+				return err
+			}
+
+			if !sleep(ctx, retryDelay(policy, attempt, st)) {
+				return err
+			}
+		}
+	}
+}
+
+// retryDelay returns how long to wait before retrying a call, which is at least as long as the
+// service asked for in any RetryInfo it sent.
+func retryDelay(policy RetryPolicy, retry int, st *status.Status) time.Duration {
+	delay := policy.Backoff(retry)
+
+	for _, detail := range st.Details() {
+		if retryInfo, ok := detail.(*errdetails.RetryInfo); ok {
+			if asked, err := ptypes.Duration(retryInfo.RetryDelay); err == nil && asked > delay {
+				delay = asked
+			}
+		}
+	}
+
+	return delay
+}
+
+// sleep waits for the given time, reporting false if the context was done first.
+func sleep(ctx context.Context, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
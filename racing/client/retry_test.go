@@ -0,0 +1,140 @@
+package client
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// quickRetries retries calls failing as unavailable without waiting long between attempts.
+var quickRetries = map[codes.Code]RetryPolicy{
+	codes.Unavailable: {MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond, Multiplier: 2},
+}
+
+// failing returns an invoker failing with each of the given codes in turn, and succeeding once they
+// run out, along with how many times it was called.
+func failing(codes ...codes.Code) (grpc.UnaryInvoker, *int) {
+	calls := 0
+
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		calls++
+		if calls > len(codes) {
+			return nil
+		}
+
+		return status.Error(codes[calls-1], "failed")
+	}, &calls
+}
+
+func TestRetryInterceptor(t *testing.T) {
+	tests := []struct {
+		name      string
+		method    string
+		failures  []codes.Code
+		wantCalls int
+		wantCode  codes.Code
+	}{
+		{
+			name:      "unavailable is retried",
+			method:    "/racing.Racing/GetRace",
+			failures:  []codes.Code{codes.Unavailable, codes.Unavailable},
+			wantCalls: 3,
+			wantCode:  codes.OK,
+		},
+		{
+			name:      "unavailable is retried at most max attempts",
+			method:    "/racing.Racing/ListRaces",
+			failures:  []codes.Code{codes.Unavailable, codes.Unavailable, codes.Unavailable, codes.Unavailable},
+			wantCalls: 3,
+			wantCode:  codes.Unavailable,
+		},
+		{
+			name:      "invalid argument is not retried",
+			method:    "/racing.Racing/GetRace",
+			failures:  []codes.Code{codes.InvalidArgument},
+			wantCalls: 1,
+			wantCode:  codes.InvalidArgument,
+		},
+		{
+			name:      "retries stop at a code without a policy",
+			method:    "/racing.Racing/GetRace",
+			failures:  []codes.Code{codes.Unavailable, codes.NotFound},
+			wantCalls: 2,
+			wantCode:  codes.NotFound,
+		},
+		{
+			name:      "writes are not retried",
+			method:    "/racing.Racing/CreateRace",
+			failures:  []codes.Code{codes.Unavailable},
+			wantCalls: 1,
+			wantCode:  codes.Unavailable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			invoker, calls := failing(tt.failures...)
+
+			err := retryInterceptor(quickRetries)(context.Background(), tt.method, nil, nil, nil, invoker)
+			if code := status.Code(err); code != tt.wantCode {
+				t.Errorf("code = %s, want %s", code, tt.wantCode)
+			}
+
+			if *calls != tt.wantCalls {
+				t.Errorf("calls = %d, want %d", *calls, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestRetryInterceptorStopsWhenContextDone(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	invoker, calls := failing(codes.Unavailable, codes.Unavailable)
+
+	err := retryInterceptor(quickRetries)(ctx, "/racing.Racing/GetRace", nil, nil, nil, invoker)
+	if status.Code(err) != codes.Unavailable || *calls != 1 {
+		t.Errorf("err = %v after %d calls, want the first call's unavailable", err, *calls)
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 3, InitialBackoff: 100 * time.Millisecond, MaxBackoff: time.Second, Multiplier: 2}
+
+	asking := func(d time.Duration) *status.Status {
+		st, err := status.New(codes.Unavailable, "busy").WithDetails(&errdetails.RetryInfo{RetryDelay: ptypes.DurationProto(d)})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return st
+	}
+
+	tests := []struct {
+		name     string
+		retry    int
+		st       *status.Status
+		min, max time.Duration
+	}{
+		{name: "first retry", retry: 1, st: status.New(codes.Unavailable, "busy"), min: 50 * time.Millisecond, max: 100 * time.Millisecond},
+		{name: "backs off", retry: 3, st: status.New(codes.Unavailable, "busy"), min: 200 * time.Millisecond, max: 400 * time.Millisecond},
+		{name: "capped", retry: 10, st: status.New(codes.Unavailable, "busy"), min: 500 * time.Millisecond, max: time.Second},
+		{name: "service asks for longer", retry: 1, st: asking(5 * time.Second), min: 5 * time.Second, max: 5 * time.Second},
+		{name: "service asks for shorter", retry: 1, st: asking(time.Millisecond), min: 50 * time.Millisecond, max: 100 * time.Millisecond},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if delay := retryDelay(policy, tt.retry, tt.st); delay < tt.min || delay > tt.max {
+				t.Errorf("retryDelay() = %s, want between %s and %s", delay, tt.min, tt.max)
+			}
+		})
+	}
+}
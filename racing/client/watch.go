@@ -0,0 +1,65 @@
+package client
+
+import (
+	"context"
+	"time"
+
+	"git.neds.sh/matty/entain/racing/proto/racing"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// watchRetryPolicy is how Watch backs off between attempts to re-establish a watch.
+var watchRetryPolicy = RetryPolicy{
+	InitialBackoff: 100 * time.Millisecond,
+	MaxBackoff:     5 * time.Second,
+	Multiplier:     2,
+}
+
+// Watch calls fn with every race event matching the request, until the context is done or fn
+// returns an error, which Watch then returns.
+//
+// A watch that breaks because the service is unavailable is re-established with backoff. Events
+// made while it was broken are missed, so onReconnect, if not nil, is called after each reconnection
+// to let the caller catch up, for example by listing the races again.
+func Watch(ctx context.Context, r Racing, in *racing.WatchRacesRequest, fn func(*racing.RaceEvent) error, onReconnect func() error) error {
+	for retry := 0; ; retry++ {
+		if retry > 0 {
+			if !sleep(ctx, watchRetryPolicy.Backoff(retry)) {
+				return ctx.Err()
+			}
+		}
+
+		watcher, err := r.WatchRaces(ctx, in)
+		if err == nil && retry > 0 && onReconnect != nil {
+			if err := onReconnect(); err != nil {
+				return err
+			}
+		}
+
+		for err == nil {
+			var event *racing.RaceEvent
+			if event, err = watcher.Recv(); err == nil {
+				// The watch works again, so the next time it breaks we start backing off afresh.
+				retry = 0
+
+				if err := fn(event); err != nil {
+					return err
+				}
+			}
+		}
+
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		if status.Code(err) != codes.Unavailable {
+			return err
+		}
+	}
+}
+
+// IsNotFound reports whether an error says that what was asked for does not exist.
+func IsNotFound(err error) bool {
+	return status.Code(err) == codes.NotFound
+}
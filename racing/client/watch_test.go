@@ -0,0 +1,178 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"git.neds.sh/matty/entain/racing/proto/racing"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// scriptedWatch is a watch that fails to start with startErr, or receives events and then breaks with err.
+// Without either, it waits for its context once its events are received.
+type scriptedWatch struct {
+	startErr error
+	events   []int64
+	err      error
+}
+
+// scriptingRacing is a Racing whose watches follow scripts, one per call of WatchRaces.
+type scriptingRacing struct {
+	Racing
+
+	watches []scriptedWatch
+	calls   int
+}
+
+func (r *scriptingRacing) WatchRaces(ctx context.Context, in *racing.WatchRacesRequest) (RaceWatcher, error) {
+	if r.calls == len(r.watches) {
+		return nil, errors.New("no more watches scripted")
+	}
+
+	script := r.watches[r.calls]
+	r.calls++
+
+	if script.startErr != nil {
+		return nil, script.startErr
+	}
+
+	return &scriptedWatcher{ctx: ctx, script: script}, nil
+}
+
+type scriptedWatcher struct {
+	ctx    context.Context
+	script scriptedWatch
+}
+
+func (w *scriptedWatcher) Recv() (*racing.RaceEvent, error) {
+	if len(w.script.events) > 0 {
+		id := w.script.events[0]
+		w.script.events = w.script.events[1:]
+
+		return &racing.RaceEvent{Type: racing.RaceEvent_UPDATED, Race: &racing.Race{Id: id}}, nil
+	}
+
+	if w.script.err != nil {
+		return nil, w.script.err
+	}
+
+	<-w.ctx.Done()
+
+	return nil, status.Error(codes.Canceled, w.ctx.Err().Error())
+}
+
+var (
+	errStop     = errors.New("stop")
+	unavailable = status.Error(codes.Unavailable, "unavailable")
+)
+
+func TestWatch(t *testing.T) {
+	tests := []struct {
+		name           string
+		watches        []scriptedWatch
+		stopAfter      int
+		reconnectErr   error
+		want           []int64
+		wantReconnects int
+		wantErr        error
+		wantCode       codes.Code
+	}{
+		{
+			name: "reconnects after the watch breaks",
+			watches: []scriptedWatch{
+				{events: []int64{1}, err: unavailable},
+				{events: []int64{2}, err: unavailable},
+				{events: []int64{3}},
+			},
+			stopAfter:      3,
+			want:           []int64{1, 2, 3},
+			wantReconnects: 2,
+			wantErr:        errStop,
+		},
+		{
+			name: "retries a watch that cannot start",
+			watches: []scriptedWatch{
+				{startErr: unavailable},
+				{startErr: unavailable},
+				{events: []int64{1}},
+			},
+			stopAfter:      1,
+			want:           []int64{1},
+			wantReconnects: 1,
+			wantErr:        errStop,
+		},
+		{
+			name: "returns errors other than unavailable",
+			watches: []scriptedWatch{
+				{events: []int64{1}, err: status.Error(codes.PermissionDenied, "denied")},
+			},
+			want:     []int64{1},
+			wantCode: codes.PermissionDenied,
+		},
+		{
+			name: "returns the error of catching up",
+			watches: []scriptedWatch{
+				{events: []int64{1}, err: unavailable},
+				{events: []int64{2}},
+			},
+			reconnectErr:   errStop,
+			want:           []int64{1},
+			wantReconnects: 1,
+			wantErr:        errStop,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &scriptingRacing{watches: tt.watches}
+
+			var got []int64
+			fn := func(event *racing.RaceEvent) error {
+				got = append(got, event.Race.Id)
+				if len(got) == tt.stopAfter {
+					return errStop
+				}
+
+				return nil
+			}
+
+			reconnects := 0
+			onReconnect := func() error {
+				reconnects++
+				return tt.reconnectErr
+			}
+
+			err := Watch(context.Background(), r, &racing.WatchRacesRequest{}, fn, onReconnect)
+			switch {
+			case tt.wantErr != nil && err != tt.wantErr:
+				t.Errorf("Watch() = %v, want %v", err, tt.wantErr)
+			case tt.wantErr == nil && status.Code(err) != tt.wantCode:
+				t.Errorf("Watch() = %v, want code %s", err, tt.wantCode)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("events = %v, want %v", got, tt.want)
+			}
+
+			if reconnects != tt.wantReconnects {
+				t.Errorf("reconnects = %d, want %d", reconnects, tt.wantReconnects)
+			}
+		})
+	}
+}
+
+func TestWatchStopsWhenContextDone(t *testing.T) {
+	f := NewFake(&racing.Race{Id: 1, MeetingId: 1, Name: "Race", State: racing.Race_OPEN})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	err := Watch(ctx, f, &racing.WatchRacesRequest{}, func(*racing.RaceEvent) error { return nil }, nil)
+	if err != context.DeadlineExceeded {
+		t.Errorf("Watch() = %v, want %v", err, context.DeadlineExceeded)
+	}
+}
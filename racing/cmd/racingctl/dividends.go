@@ -0,0 +1,119 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/encoding/protojson"
+	"gopkg.in/yaml.v3"
+
+	"git.neds.sh/matty/entain/racing/proto/racing"
+)
+
+func newDividendsCommand(g *globals) *cobra.Command {
+	var (
+		poolsPath    string
+		jurisdiction string
+		scratched    []int64
+		placings     []string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "dividends ID --pools FILE --jurisdiction CODE [--scratched RUNNER,...] [--placing POSITION:RUNNER...]",
+		Short: "Estimate the tote dividends of a race",
+		Long: `Estimate the tote dividends of a race from what has been invested in its pools.
+
+The pools are read from a JSON or YAML file, as the pools of an EstimateDividends request, such as:
+
+  - type: WIN
+    investments:
+      - {runners: [4], amount: 2000}
+      - {runners: [7], amount: 3000}
+  - type: QUINELLA
+    total: 12000
+    investments:
+      - {runners: [4, 7], amount: 850}
+
+The dividends are for the race's result, unless placings are given to estimate them for instead.`,
+		Example: `  racingctl dividends 42 --pools pools.yaml --jurisdiction NSW --scratched 9
+  racingctl dividends 42 --pools pools.yaml --jurisdiction VIC --placing 1:4 --placing 2:7 --placing 3:2`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			in, err := readPools(poolsPath)
+			if err != nil {
+				return err
+			}
+
+			if in.RaceId, err = parseID(args[0]); err != nil {
+				return err
+			}
+
+			in.Jurisdiction = jurisdiction
+			in.Scratched = scratched
+
+			for _, arg := range placings {
+				placing, err := parsePlacing(arg)
+				if err != nil {
+					return err
+				}
+
+				in.Placings = append(in.Placings, placing)
+			}
+
+			p, err := g.printer()
+			if err != nil {
+				return err
+			}
+
+			c, err := g.connect(cmd.Context())
+			if err != nil {
+				return err
+			}
+			defer c.Close()
+
+			resp, err := c.EstimateDividends(cmd.Context(), in)
+			if err != nil {
+				return err
+			}
+
+			return p.Dividends(resp)
+		},
+	}
+
+	cmd.Flags().StringVar(&poolsPath, "pools", "", "JSON or YAML file of what has been invested in each pool")
+	cmd.Flags().StringVar(&jurisdiction, "jurisdiction", "", "state code of where the tote is licensed, such as NSW")
+	cmd.Flags().Int64SliceVar(&scratched, "scratched", nil, "runners withdrawn from the race, repeated or comma separated")
+	cmd.Flags().StringArrayVar(&placings, "placing", nil, "a placed runner as POSITION:RUNNER, in order of position, repeated")
+	_ = cmd.MarkFlagRequired("pools")
+	_ = cmd.MarkFlagRequired("jurisdiction")
+
+	return cmd
+}
+
+// readPools reads the pools of an EstimateDividends request from a JSON or YAML file.
+func readPools(path string) (*racing.EstimateDividendsRequest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	// JSON is YAML, so both are read as YAML and passed to protojson, which knows the names of the fields.
+	var pools interface{}
+	if err := yaml.Unmarshal(data, &pools); err != nil {
+		return nil, fmt.Errorf("reading pools from %s: %w", path, err)
+	}
+
+	data, err = json.Marshal(map[string]interface{}{"pools": pools})
+	if err != nil {
+		return nil, fmt.Errorf("reading pools from %s: %w", path, err)
+	}
+
+	var in racing.EstimateDividendsRequest
+	if err := protojson.Unmarshal(data, &in); err != nil {
+		return nil, fmt.Errorf("reading pools from %s: %w", path, err)
+	}
+
+	return &in, nil
+}
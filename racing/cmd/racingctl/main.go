@@ -0,0 +1,139 @@
+// Command racingctl is a command line client for the racing service.
+//
+// It talks to the service over gRPC, through racing/client, and prints races as a table, JSON or YAML.
+// Endpoints are kept as named profiles in a config file, so that switching between environments is a
+// matter of --profile, or of making another profile the default with "racingctl profile use".
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"git.neds.sh/matty/entain/racing/client"
+)
+
+// globals are the flags shared by every command.
+type globals struct {
+	configPath string
+	profile    string
+	endpoint   string
+	tls        bool
+	timeout    time.Duration
+	output     string
+}
+
+func main() {
+	if err := newRootCommand().Execute(); err != nil {
+		os.Exit(1)
+	}
+}
+
+func newRootCommand() *cobra.Command {
+	g := &globals{}
+
+	root := &cobra.Command{
+		Use:           "racingctl",
+		Short:         "racingctl manages the races of the racing service",
+		SilenceUsage:  true,
+		SilenceErrors: false,
+	}
+
+	flags := root.PersistentFlags()
+	flags.StringVar(&g.configPath, "config", defaultConfigPath(), "path to the config file holding the profiles")
+	flags.StringVarP(&g.profile, "profile", "p", "", "profile to use (default the current profile of the config file)")
+	flags.StringVar(&g.endpoint, "endpoint", "", "gRPC endpoint of the racing service, overriding the profile")
+	flags.BoolVar(&g.tls, "tls", false, "connect with TLS, overriding the profile")
+	flags.DurationVar(&g.timeout, "timeout", client.DefaultTimeout, "how long each call may take")
+	flags.StringVarP(&g.output, "output", "o", string(tableOutput), "output format, one of table, json or yaml")
+
+	_ = root.RegisterFlagCompletionFunc("profile", g.completeProfiles)
+	_ = root.RegisterFlagCompletionFunc("output", func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+		return []string{string(tableOutput), string(jsonOutput), string(yamlOutput)}, cobra.ShellCompDirectiveNoFileComp
+	})
+
+	root.AddCommand(
+		newListCommand(g),
+		newGetCommand(g),
+		newWatchCommand(g),
+		newCreateCommand(g),
+		newUpdateCommand(g),
+		newDeleteCommand(g),
+		newTransitionCommand(g),
+		newHistoryCommand(g),
+		newResultCommand(g),
+		newSubmitResultCommand(g),
+		newScratchCommand(g),
+		newMarketCommand(g),
+		newPriceCommand(g),
+		newDividendsCommand(g),
+		newSuspendCommand(g),
+		newResumeCommand(g),
+		newSuspensionsCommand(g),
+		newProfileCommand(g),
+	)
+
+	return root
+}
+
+// connect dials the racing service named by the flags and the profile.
+func (g *globals) connect(ctx context.Context) (*client.Client, error) {
+	profile, err := g.resolveProfile()
+	if err != nil {
+		return nil, err
+	}
+
+	opts := []client.Option{
+		client.WithEndpoint(profile.Endpoint),
+		client.WithTimeout(g.timeout),
+	}
+
+	if profile.TLS {
+		opts = append(opts, client.WithTLS(&tls.Config{ServerName: profile.ServerName}))
+	}
+
+	return client.New(ctx, opts...)
+}
+
+// resolveProfile returns the profile to use, with any overrides from the flags applied.
+func (g *globals) resolveProfile() (Profile, error) {
+	config, err := loadConfig(g.configPath)
+	if err != nil {
+		return Profile{}, err
+	}
+
+	name := g.profile
+	if name == "" {
+		name = config.CurrentProfile
+	}
+
+	profile := Profile{Endpoint: client.DefaultEndpoint}
+
+	if name != "" {
+		p, ok := config.Profiles[name]
+		if !ok {
+			return Profile{}, fmt.Errorf("no profile named %q in %s", name, g.configPath)
+		}
+
+		profile = p
+	}
+
+	if g.endpoint != "" {
+		profile.Endpoint = g.endpoint
+	}
+
+	if g.tls {
+		profile.TLS = true
+	}
+
+	return profile, nil
+}
+
+// printer returns the printer for the chosen output format.
+func (g *globals) printer() (printer, error) {
+	return newPrinter(outputFormat(g.output), os.Stdout)
+}
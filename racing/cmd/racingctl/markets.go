@@ -0,0 +1,172 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"git.neds.sh/matty/entain/racing/proto/racing"
+)
+
+// oddsFormatFlag is an odds format given by name.
+type oddsFormatFlag struct {
+	format racing.OddsFormat
+}
+
+func (f *oddsFormatFlag) String() string {
+	return strings.ToLower(f.format.String())
+}
+
+func (f *oddsFormatFlag) Set(value string) error {
+	format, ok := racing.OddsFormat_value[strings.ToUpper(value)]
+	if !ok {
+		return fmt.Errorf("unknown odds format %q, expected decimal, fractional or american", value)
+	}
+
+	f.format = racing.OddsFormat(format)
+
+	return nil
+}
+
+func (f *oddsFormatFlag) Type() string {
+	return "format"
+}
+
+// register adds the --odds flag to a command.
+func (f *oddsFormatFlag) register(cmd *cobra.Command) {
+	cmd.Flags().Var(f, "odds", "odds format to show prices in: decimal, fractional or american")
+	_ = cmd.RegisterFlagCompletionFunc("odds", func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+		return []string{"decimal", "fractional", "american"}, cobra.ShellCompDirectiveNoFileComp
+	})
+}
+
+func newMarketCommand(g *globals) *cobra.Command {
+	var (
+		format oddsFormatFlag
+		flucs  bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "market ID [--odds FORMAT] [--flucs]",
+		Short: "Get the fixed-odds win and place prices of a race",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := parseID(args[0])
+			if err != nil {
+				return err
+			}
+
+			p, err := g.printer()
+			if err != nil {
+				return err
+			}
+
+			c, err := g.connect(cmd.Context())
+			if err != nil {
+				return err
+			}
+			defer c.Close()
+
+			market, err := c.GetMarket(cmd.Context(), id, format.format, flucs)
+			if err != nil {
+				return err
+			}
+
+			return p.Market(market)
+		},
+	}
+
+	format.register(cmd)
+	cmd.Flags().BoolVar(&flucs, "flucs", false, "also print every earlier price of each runner")
+
+	return cmd
+}
+
+func newPriceCommand(g *globals) *cobra.Command {
+	var (
+		prices []string
+		format oddsFormatFlag
+	)
+
+	cmd := &cobra.Command{
+		Use:   "price ID --price RUNNER:WIN[:PLACE]... [--odds FORMAT]",
+		Short: "Set the fixed-odds prices of runners in a race",
+		Example: `  # Runner 4 is 3.50 the win and 1.60 the place, and runner 7 is 8.00 the win with no place price.
+  racingctl price 42 --price 4:3.5:1.6 --price 7:8`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			in := racing.UpdatePricesRequest{OddsFormat: format.format}
+
+			var err error
+			if in.RaceId, err = parseID(args[0]); err != nil {
+				return err
+			}
+
+			for _, arg := range prices {
+				price, err := parsePrice(arg)
+				if err != nil {
+					return err
+				}
+
+				in.Prices = append(in.Prices, price)
+			}
+
+			p, err := g.printer()
+			if err != nil {
+				return err
+			}
+
+			c, err := g.connect(cmd.Context())
+			if err != nil {
+				return err
+			}
+			defer c.Close()
+
+			market, err := c.UpdatePrices(cmd.Context(), &in)
+			if err != nil {
+				return err
+			}
+
+			return p.Market(market)
+		},
+	}
+
+	cmd.Flags().StringArrayVar(&prices, "price", nil, "a runner's decimal odds as RUNNER:WIN[:PLACE], 0 to withdraw a price, repeated")
+	format.register(cmd)
+	_ = cmd.MarkFlagRequired("price")
+
+	return cmd
+}
+
+// parsePrice parses the prices of a runner given as RUNNER:WIN[:PLACE], in decimal odds.
+func parsePrice(arg string) (*racing.PriceUpdate, error) {
+	invalid := fmt.Errorf("invalid price %q, expected RUNNER:WIN[:PLACE] such as 4:3.5:1.6", arg)
+
+	parts := strings.Split(arg, ":")
+	if len(parts) < 2 || len(parts) > 3 {
+		return nil, invalid
+	}
+
+	var (
+		price racing.PriceUpdate
+		err   error
+	)
+
+	if price.RunnerNumber, err = strconv.ParseInt(parts[0], 10, 64); err != nil {
+		return nil, invalid
+	}
+
+	if price.Win, err = strconv.ParseFloat(parts[1], 64); err != nil {
+		return nil, invalid
+	}
+
+	if len(parts) == 3 {
+		if price.Place, err = strconv.ParseFloat(parts[2], 64); err != nil {
+			return nil, invalid
+		}
+	}
+
+	return &price, nil
+}
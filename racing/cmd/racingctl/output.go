@@ -0,0 +1,470 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	timestamp "github.com/golang/protobuf/ptypes/timestamp"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"gopkg.in/yaml.v3"
+
+	"git.neds.sh/matty/entain/racing/proto/racing"
+)
+
+// outputFormat is how races are printed.
+type outputFormat string
+
+const (
+	tableOutput outputFormat = "table"
+	jsonOutput  outputFormat = "json"
+	yamlOutput  outputFormat = "yaml"
+)
+
+// printer prints races in an output format.
+type printer struct {
+	format outputFormat
+	w      io.Writer
+	// table is the writer of a table whose header has been written, when printing events as they come.
+	table *tabwriter.Writer
+}
+
+func newPrinter(format outputFormat, w io.Writer) (printer, error) {
+	switch format {
+	case tableOutput, jsonOutput, yamlOutput:
+		return printer{format: format, w: w}, nil
+	}
+
+	return printer{}, fmt.Errorf("unknown output format %q, expected table, json or yaml", format)
+}
+
+// marshaler writes messages with the names and every field of the proto, so scripts can rely on them.
+var marshaler = protojson.MarshalOptions{UseProtoNames: true, EmitUnpopulated: true}
+
+// raceColumns head the columns of raceRow.
+var raceColumns = []string{"ID", "MEETING", "NUMBER", "NAME", "VISIBLE", "STATE", "ADVERTISED START", "UPDATED"}
+
+// Races prints a list of races, as a table, a JSON array or a YAML sequence.
+func (p *printer) Races(races []*racing.Race) error {
+	switch p.format {
+	case tableOutput:
+		w := p.newTable(raceColumns...)
+		for _, race := range races {
+			fmt.Fprintln(w, raceRow(race))
+		}
+
+		return w.Flush()
+	default:
+		messages := make([]proto.Message, len(races))
+		for i, race := range races {
+			messages[i] = race
+		}
+
+		return p.write(messages...)
+	}
+}
+
+// Race prints a single race, along with its runners, scratchings, suspensions and result if it has them.
+func (p *printer) Race(race *racing.Race) error {
+	if p.format == tableOutput {
+		if err := p.Races([]*racing.Race{race}); err != nil {
+			return err
+		}
+
+		if len(race.Runners) > 0 {
+			fmt.Fprintln(p.w)
+
+			w := p.newTable("RUNNER", "NAME", "BARRIER")
+			for _, runner := range race.Runners {
+				fmt.Fprintf(w, "%d\t%s\t%d\n", runner.Number, runner.Name, runner.Barrier)
+			}
+
+			if err := w.Flush(); err != nil {
+				return err
+			}
+		}
+
+		if len(race.Scratchings) > 0 {
+			fmt.Fprintln(p.w)
+
+			w := p.newTable("SCRATCHED", "TIME", "PRICE", "WIN DEDUCTION", "PLACE DEDUCTION", "ACTOR", "REASON")
+			for _, scratching := range race.Scratchings {
+				fmt.Fprintf(w, "%d\t%s\t%.2f\t%g%%\t%g%%\t%s\t%s\n",
+					scratching.RunnerNumber, formatTime(scratching.ScratchTime), scratching.Price,
+					scratching.WinDeduction, scratching.PlaceDeduction, scratching.Actor, scratching.Reason)
+			}
+
+			if err := w.Flush(); err != nil {
+				return err
+			}
+		}
+
+		if len(race.Suspensions) > 0 {
+			fmt.Fprintln(p.w)
+
+			if err := p.Suspensions(race.Suspensions); err != nil {
+				return err
+			}
+		}
+
+		if race.Result != nil {
+			fmt.Fprintln(p.w)
+
+			return p.result(race.Result)
+		}
+
+		return nil
+	}
+
+	data, err := marshaler.Marshal(race)
+	if err != nil {
+		return err
+	}
+
+	return p.writeDocument(data)
+}
+
+// Result prints the result of a race, followed by the versions it amended, latest first.
+func (p *printer) Result(resp *racing.GetResultResponse) error {
+	if p.format != tableOutput {
+		data, err := marshaler.Marshal(resp)
+		if err != nil {
+			return err
+		}
+
+		return p.writeDocument(data)
+	}
+
+	if err := p.result(resp.Result); err != nil {
+		return err
+	}
+
+	for i := len(resp.History) - 1; i >= 0; i-- {
+		fmt.Fprintln(p.w)
+
+		if err := p.result(resp.History[i]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// result prints a version of a result as a table of its placings, headed by what it is.
+func (p *printer) result(result *racing.Result) error {
+	kind := "Interim"
+	if result.Final {
+		kind = "Final"
+	}
+
+	fmt.Fprintf(p.w, "%s result, version %d, by %s at %s", kind, result.Version, result.Actor, formatTime(result.SubmitTime))
+	if result.Protest != racing.Result_NO_PROTEST {
+		fmt.Fprintf(p.w, ", protest %s", strings.ToLower(result.Protest.String()))
+	}
+
+	if result.Reason != "" {
+		fmt.Fprintf(p.w, ": %s", result.Reason)
+	}
+
+	fmt.Fprintln(p.w)
+
+	w := p.newTable("POSITION", "RUNNER", "MARGIN", "DEAD HEAT")
+	for _, placing := range result.Placings {
+		fmt.Fprintf(w, "%d\t%d\t%g\t%t\n", placing.Position, placing.RunnerNumber, placing.Margin, placing.DeadHeat)
+	}
+
+	return w.Flush()
+}
+
+// Market prints the prices of a race, along with their flucs if it has them, followed by its market
+// percentages.
+func (p *printer) Market(market *racing.Market) error {
+	if p.format != tableOutput {
+		data, err := marshaler.Marshal(market)
+		if err != nil {
+			return err
+		}
+
+		return p.writeDocument(data)
+	}
+
+	w := p.newTable("RUNNER", "NAME", "WIN", "PLACE", "UPDATED")
+	for _, runner := range market.Runners {
+		name := runner.RunnerName
+		if runner.Scratched {
+			name += " (scratched)"
+		}
+
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\n",
+			runner.RunnerNumber, name, formatOdds(runner.Win), formatOdds(runner.Place), formatTime(runner.UpdateTime))
+
+		for _, fluc := range runner.Flucs {
+			fmt.Fprintf(w, "\t\t%s\t%s\t%s\n", formatOdds(fluc.Win), formatOdds(fluc.Place), formatTime(fluc.Time))
+		}
+	}
+
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	_, err := fmt.Fprintf(p.w, "\nWin market %.2f%%, place market %.2f%%\n", market.WinPercentage, market.PlacePercentage)
+
+	return err
+}
+
+// Dividends prints the estimated dividends of each pool, with what the pool was made up of.
+func (p *printer) Dividends(resp *racing.EstimateDividendsResponse) error {
+	if p.format != tableOutput {
+		data, err := marshaler.Marshal(resp)
+		if err != nil {
+			return err
+		}
+
+		return p.writeDocument(data)
+	}
+
+	for i, pool := range resp.Pools {
+		if i > 0 {
+			fmt.Fprintln(p.w)
+		}
+
+		fmt.Fprintf(p.w, "%s pool %s: total %.2f, refunded %.2f, commission %.2f (%g%%), net %.2f",
+			pool.Type, pool.Outcome, pool.Total, pool.Refunded, pool.Commission, math.Round(pool.CommissionRate*10000)/100, pool.Net)
+
+		switch pool.Outcome {
+		case racing.PoolDividends_PAID:
+			fmt.Fprintf(p.w, ", breakage %.2f\n", pool.Breakage)
+		case racing.PoolDividends_JACKPOT:
+			fmt.Fprintf(p.w, ", jackpot %.2f\n", pool.Jackpot)
+		default:
+			fmt.Fprintln(p.w)
+		}
+
+		if len(pool.Dividends) == 0 {
+			continue
+		}
+
+		w := p.newTable("RUNNERS", "DIVIDEND", "INVESTMENT")
+		for _, dividend := range pool.Dividends {
+			runners := make([]string, len(dividend.Runners))
+			for i, runner := range dividend.Runners {
+				runners[i] = strconv.FormatInt(runner, 10)
+			}
+
+			fmt.Fprintf(w, "%s\t%.2f\t%.2f\n", strings.Join(runners, "-"), dividend.Amount, dividend.Investment)
+		}
+
+		if err := w.Flush(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Transitions prints the state changes of a race.
+func (p *printer) Transitions(transitions []*racing.RaceTransition) error {
+	switch p.format {
+	case tableOutput:
+		w := p.newTable("TIME", "FROM", "TO", "ACTOR", "REASON")
+		for _, transition := range transitions {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n",
+				formatTime(transition.Time), transition.From, transition.To, transition.Actor, transition.Reason)
+		}
+
+		return w.Flush()
+	default:
+		messages := make([]proto.Message, len(transitions))
+		for i, transition := range transitions {
+			messages[i] = transition
+		}
+
+		return p.write(messages...)
+	}
+}
+
+// Suspensions prints suspensions of trading, with who made and lifted them.
+func (p *printer) Suspensions(suspensions []*racing.Suspension) error {
+	switch p.format {
+	case tableOutput:
+		w := p.newTable("ID", "SUSPENDS", "TIME", "ACTOR", "REASON", "RESUMES", "LIFTED", "LIFTED BY", "LIFT REASON")
+		for _, suspension := range suspensions {
+			fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+				suspension.Id, suspended(suspension), formatTime(suspension.SuspendTime), suspension.Actor, suspension.Reason,
+				formatTime(suspension.ResumeTime), formatTime(suspension.LiftTime), suspension.LiftActor, suspension.LiftReason)
+		}
+
+		return w.Flush()
+	default:
+		messages := make([]proto.Message, len(suspensions))
+		for i, suspension := range suspensions {
+			messages[i] = suspension
+		}
+
+		return p.write(messages...)
+	}
+}
+
+// Event prints a race event as soon as it happens: a table row, a line of JSON or a YAML document.
+func (p *printer) Event(event *racing.RaceEvent) error {
+	if p.format == tableOutput {
+		if p.table == nil {
+			p.table = p.newTable(append([]string{"EVENT"}, raceColumns...)...)
+		}
+
+		fmt.Fprintf(p.table, "%s\t%s\n", event.Type, raceRow(event.Race))
+
+		return p.table.Flush()
+	}
+
+	data, err := marshaler.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	if p.format == jsonOutput {
+		var line bytes.Buffer
+		if err := json.Compact(&line, data); err != nil {
+			return err
+		}
+
+		_, err := fmt.Fprintln(p.w, line.String())
+
+		return err
+	}
+
+	if _, err := fmt.Fprintln(p.w, "---"); err != nil {
+		return err
+	}
+
+	return p.writeDocument(data)
+}
+
+// write prints messages as a JSON array or a YAML sequence.
+func (p *printer) write(messages ...proto.Message) error {
+	items := make([]json.RawMessage, len(messages))
+
+	for i, msg := range messages {
+		data, err := marshaler.Marshal(msg)
+		if err != nil {
+			return err
+		}
+
+		items[i] = data
+	}
+
+	data, err := json.Marshal(items)
+	if err != nil {
+		return err
+	}
+
+	return p.writeDocument(data)
+}
+
+// writeDocument prints a JSON document, converting it to YAML if need be.
+func (p *printer) writeDocument(data []byte) error {
+	if p.format == jsonOutput {
+		var indented bytes.Buffer
+		if err := json.Indent(&indented, data, "", "  "); err != nil {
+			return err
+		}
+
+		_, err := fmt.Fprintln(p.w, indented.String())
+
+		return err
+	}
+
+	// JSON is YAML, so it can be read as a YAML node, which keeps the order of the fields. Only its
+	// style needs changing, from the flow style of JSON to the block style expected of YAML.
+	var node yaml.Node
+	if err := yaml.Unmarshal(data, &node); err != nil {
+		return err
+	}
+
+	blockStyle(&node)
+
+	encoder := yaml.NewEncoder(p.w)
+	encoder.SetIndent(2)
+
+	if err := encoder.Encode(&node); err != nil {
+		return err
+	}
+
+	return encoder.Close()
+}
+
+// blockStyle clears the style of a YAML node and everything within it. Strings are still quoted
+// where they would otherwise be read as something else, such as the numbers int64 fields are
+// written as in JSON.
+func blockStyle(node *yaml.Node) {
+	node.Style = 0
+
+	for _, child := range node.Content {
+		blockStyle(child)
+	}
+}
+
+func (p *printer) newTable(columns ...string) *tabwriter.Writer {
+	w := tabwriter.NewWriter(p.w, 0, 4, 2, ' ', 0)
+
+	for i, column := range columns {
+		if i > 0 {
+			fmt.Fprint(w, "\t")
+		}
+
+		fmt.Fprint(w, column)
+	}
+
+	fmt.Fprintln(w)
+
+	return w
+}
+
+// raceRow formats a race as the tab separated cells of a table row.
+func raceRow(race *racing.Race) string {
+	return fmt.Sprintf("%d\t%d\t%d\t%s\t%t\t%s\t%s\t%s",
+		race.Id, race.MeetingId, race.Number, race.Name, race.Visible, race.State,
+		formatTime(race.AdvertisedStartTime), formatTime(race.UpdateTime))
+}
+
+// suspended describes what a suspension suspends: a race, a meeting or all racing.
+func suspended(suspension *racing.Suspension) string {
+	switch suspension.Scope {
+	case racing.Suspension_RACE:
+		return fmt.Sprintf("race %d", suspension.RaceId)
+	case racing.Suspension_MEETING:
+		return fmt.Sprintf("meeting %d", suspension.MeetingId)
+	}
+
+	return "all racing"
+}
+
+// formatOdds returns the display of odds, or - if there are none.
+func formatOdds(odds *racing.Odds) string {
+	if odds == nil {
+		return "-"
+	}
+
+	return odds.Display
+}
+
+func formatTime(ts *timestamp.Timestamp) string {
+	if ts == nil {
+		return "-"
+	}
+
+	t, err := ptypes.Timestamp(ts)
+	if err != nil {
+		return "invalid"
+	}
+
+	return t.Local().Format(time.RFC3339)
+}
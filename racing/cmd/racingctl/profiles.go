@@ -0,0 +1,221 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// Config is the racingctl config file, which holds named profiles.
+type Config struct {
+	// CurrentProfile is used when no --profile is given.
+	CurrentProfile string `yaml:"current-profile,omitempty"`
+	// Profiles are keyed by name.
+	Profiles map[string]Profile `yaml:"profiles,omitempty"`
+}
+
+// Profile says how to reach an instance of the racing service.
+type Profile struct {
+	// Endpoint is the gRPC address of the service, such as "racing.staging:9000".
+	Endpoint string `yaml:"endpoint"`
+	// TLS secures the connection with TLS.
+	TLS bool `yaml:"tls,omitempty"`
+	// ServerName overrides the name the TLS certificate of the service is checked against.
+	ServerName string `yaml:"server-name,omitempty"`
+}
+
+// defaultConfigPath is racingctl/config.yaml in the user's config directory, such as ~/.config.
+func defaultConfigPath() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "racingctl.yaml"
+	}
+
+	return filepath.Join(dir, "racingctl", "config.yaml")
+}
+
+// loadConfig reads the config file, which need not exist.
+func loadConfig(path string) (*Config, error) {
+	config := &Config{Profiles: make(map[string]Profile)}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return config, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := yaml.Unmarshal(data, config); err != nil {
+		return nil, fmt.Errorf("failed reading %s: %w", path, err)
+	}
+
+	if config.Profiles == nil {
+		config.Profiles = make(map[string]Profile)
+	}
+
+	return config, nil
+}
+
+// saveConfig writes the config file, creating its directory if need be.
+func saveConfig(path string, config *Config) error {
+	data, err := yaml.Marshal(config)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, data, 0o600)
+}
+
+func newProfileCommand(g *globals) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "profile",
+		Short: "Manage the profiles naming racing service endpoints",
+	}
+
+	cmd.AddCommand(newProfileListCommand(g), newProfileSetCommand(g), newProfileUseCommand(g), newProfileDeleteCommand(g))
+
+	return cmd
+}
+
+func newProfileListCommand(g *globals) *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List the profiles, marking the current one with *",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			config, err := loadConfig(g.configPath)
+			if err != nil {
+				return err
+			}
+
+			w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 4, 2, ' ', 0)
+			fmt.Fprintln(w, "CURRENT\tNAME\tENDPOINT\tTLS")
+
+			for _, name := range profileNames(config) {
+				current := ""
+				if name == config.CurrentProfile {
+					current = "*"
+				}
+
+				profile := config.Profiles[name]
+				fmt.Fprintf(w, "%s\t%s\t%s\t%t\n", current, name, profile.Endpoint, profile.TLS)
+			}
+
+			return w.Flush()
+		},
+	}
+}
+
+func newProfileSetCommand(g *globals) *cobra.Command {
+	var profile Profile
+
+	cmd := &cobra.Command{
+		Use:   "set NAME --endpoint HOST:PORT",
+		Short: "Create or replace a profile",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			config, err := loadConfig(g.configPath)
+			if err != nil {
+				return err
+			}
+
+			// The endpoint and TLS flags are shared with every command, where they override the profile.
+			profile.Endpoint, profile.TLS = g.endpoint, g.tls
+			if profile.Endpoint == "" {
+				return fmt.Errorf("an --endpoint is required")
+			}
+
+			config.Profiles[args[0]] = profile
+			if config.CurrentProfile == "" {
+				config.CurrentProfile = args[0]
+			}
+
+			return saveConfig(g.configPath, config)
+		},
+	}
+
+	cmd.Flags().StringVar(&profile.ServerName, "server-name", "", "name to check the TLS certificate of the service against")
+
+	return cmd
+}
+
+func newProfileUseCommand(g *globals) *cobra.Command {
+	return &cobra.Command{
+		Use:               "use NAME",
+		Short:             "Make a profile the current one",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: g.completeProfiles,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			config, err := loadConfig(g.configPath)
+			if err != nil {
+				return err
+			}
+
+			if _, ok := config.Profiles[args[0]]; !ok {
+				return fmt.Errorf("no profile named %q", args[0])
+			}
+
+			config.CurrentProfile = args[0]
+
+			return saveConfig(g.configPath, config)
+		},
+	}
+}
+
+func newProfileDeleteCommand(g *globals) *cobra.Command {
+	return &cobra.Command{
+		Use:               "delete NAME",
+		Short:             "Delete a profile",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: g.completeProfiles,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			config, err := loadConfig(g.configPath)
+			if err != nil {
+				return err
+			}
+
+			if _, ok := config.Profiles[args[0]]; !ok {
+				return fmt.Errorf("no profile named %q", args[0])
+			}
+
+			delete(config.Profiles, args[0])
+			if config.CurrentProfile == args[0] {
+				config.CurrentProfile = ""
+			}
+
+			return saveConfig(g.configPath, config)
+		},
+	}
+}
+
+// completeProfiles completes the names of profiles.
+func (g *globals) completeProfiles(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	config, err := loadConfig(g.configPath)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+
+	return profileNames(config), cobra.ShellCompDirectiveNoFileComp
+}
+
+func profileNames(config *Config) []string {
+	names := make([]string, 0, len(config.Profiles))
+	for name := range config.Profiles {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
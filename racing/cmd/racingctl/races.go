@@ -0,0 +1,485 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"os/user"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"git.neds.sh/matty/entain/racing/client"
+	"git.neds.sh/matty/entain/racing/proto/racing"
+)
+
+// filterFlags are the flags for each field of a race filter.
+type filterFlags struct {
+	meetingIDs []int64
+	states     []string
+	expression string
+}
+
+func (f *filterFlags) register(cmd *cobra.Command, withExpression bool) {
+	flags := cmd.Flags()
+	flags.Int64SliceVar(&f.meetingIDs, "meeting-id", nil, "only races of these meetings, repeated or comma separated")
+	flags.StringSliceVar(&f.states, "state", nil, "only races in these states, such as open or suspended, repeated or comma separated")
+	_ = cmd.RegisterFlagCompletionFunc("state", completeStates)
+
+	if withExpression {
+		flags.StringVar(&f.expression, "filter", "", `AIP-160 filter expression, such as 'visible = true AND number <= 3'`)
+	}
+}
+
+func (f *filterFlags) filter() (*racing.ListRacesRequestFilter, error) {
+	filter := &racing.ListRacesRequestFilter{MeetingIds: f.meetingIDs}
+
+	for _, name := range f.states {
+		state, err := parseState(name)
+		if err != nil {
+			return nil, err
+		}
+
+		filter.States = append(filter.States, state)
+	}
+
+	return filter, nil
+}
+
+func newListCommand(g *globals) *cobra.Command {
+	var (
+		filter    filterFlags
+		pageSize  int32
+		pageToken string
+		limit     int
+	)
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List races, fetching every page unless limited",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			p, err := g.printer()
+			if err != nil {
+				return err
+			}
+
+			in, err := filter.filter()
+			if err != nil {
+				return err
+			}
+
+			c, err := g.connect(cmd.Context())
+			if err != nil {
+				return err
+			}
+			defer c.Close()
+
+			it := client.ListRaces(cmd.Context(), c, &racing.ListRacesRequest{
+				Filter:           in,
+				FilterExpression: filter.expression,
+				PageSize:         pageSize,
+				PageToken:        pageToken,
+			})
+
+			var races []*racing.Race
+			for (limit == 0 || len(races) < limit) && it.Next() {
+				races = append(races, it.Race())
+			}
+
+			if err := it.Err(); err != nil {
+				return err
+			}
+
+			return p.Races(races)
+		},
+	}
+
+	filter.register(cmd, true)
+	cmd.Flags().Int32Var(&pageSize, "page-size", client.DefaultPageSize, "how many races to fetch at a time")
+	cmd.Flags().StringVar(&pageToken, "page-token", "", "continue a previous listing from this page token")
+	cmd.Flags().IntVar(&limit, "limit", 0, "stop after this many races, or 0 for all of them")
+
+	return cmd
+}
+
+func newGetCommand(g *globals) *cobra.Command {
+	return &cobra.Command{
+		Use:   "get ID",
+		Short: "Get a race",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := parseID(args[0])
+			if err != nil {
+				return err
+			}
+
+			return g.withRace(cmd.Context(), func(c *client.Client) (*racing.Race, error) {
+				return c.GetRace(cmd.Context(), id)
+			})
+		},
+	}
+}
+
+func newWatchCommand(g *globals) *cobra.Command {
+	var filter filterFlags
+
+	cmd := &cobra.Command{
+		Use:   "watch",
+		Short: "Print changes to races as they happen, until interrupted",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			p, err := g.printer()
+			if err != nil {
+				return err
+			}
+
+			in, err := filter.filter()
+			if err != nil {
+				return err
+			}
+
+			ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt)
+			defer stop()
+
+			c, err := g.connect(ctx)
+			if err != nil {
+				return err
+			}
+			defer c.Close()
+
+			err = client.Watch(ctx, c, &racing.WatchRacesRequest{Filter: in}, p.Event, func() error {
+				fmt.Fprintln(os.Stderr, "reconnected, changes made while disconnected were missed")
+				return nil
+			})
+			if ctx.Err() != nil {
+				return nil
+			}
+
+			return err
+		},
+	}
+
+	filter.register(cmd, false)
+
+	return cmd
+}
+
+// raceFlags are the flags for each field of a race.
+type raceFlags struct {
+	id              int64
+	meetingID       int64
+	name            string
+	number          int64
+	visible         bool
+	advertisedStart string
+}
+
+// flagFields maps the flags of raceFlags to the fields of a race they set.
+var flagFields = map[string]string{
+	"meeting-id": "meeting_id",
+	"name":       "name",
+	"number":     "number",
+	"visible":    "visible",
+	"start":      "advertised_start_time",
+}
+
+func (f *raceFlags) register(flags *pflag.FlagSet) {
+	flags.Int64Var(&f.meetingID, "meeting-id", 0, "meeting the race belongs to")
+	flags.StringVar(&f.name, "name", "", "name of the race")
+	flags.Int64Var(&f.number, "number", 0, "number of the race within its meeting")
+	flags.BoolVar(&f.visible, "visible", false, "whether the race is visible")
+	flags.StringVar(&f.advertisedStart, "start", "", "advertised start time, in RFC 3339 such as 2026-11-03T15:00:00+11:00")
+}
+
+func (f *raceFlags) race() (*racing.Race, error) {
+	race := &racing.Race{
+		Id:        f.id,
+		MeetingId: f.meetingID,
+		Name:      f.name,
+		Number:    f.number,
+		Visible:   f.visible,
+	}
+
+	if f.advertisedStart != "" {
+		start, err := time.Parse(time.RFC3339, f.advertisedStart)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --start: %w", err)
+		}
+
+		if race.AdvertisedStartTime, err = ptypes.TimestampProto(start); err != nil {
+			return nil, err
+		}
+	}
+
+	return race, nil
+}
+
+func newCreateCommand(g *globals) *cobra.Command {
+	var race raceFlags
+
+	cmd := &cobra.Command{
+		Use:   "create --meeting-id ID --name NAME --number N --start TIME",
+		Short: "Create a race",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			in, err := race.race()
+			if err != nil {
+				return err
+			}
+
+			return g.withRace(cmd.Context(), func(c *client.Client) (*racing.Race, error) {
+				return c.CreateRace(cmd.Context(), in)
+			})
+		},
+	}
+
+	cmd.Flags().Int64Var(&race.id, "id", 0, "ID of the race (default the next free ID)")
+	race.register(cmd.Flags())
+
+	return cmd
+}
+
+func newUpdateCommand(g *globals) *cobra.Command {
+	var race raceFlags
+
+	cmd := &cobra.Command{
+		Use:   "update ID [--meeting-id ID] [--name NAME] [--number N] [--visible] [--start TIME]",
+		Short: "Update the fields of a race given by flags, leaving the others as they are",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var err error
+			if race.id, err = parseID(args[0]); err != nil {
+				return err
+			}
+
+			in, err := race.race()
+			if err != nil {
+				return err
+			}
+
+			var fields []string
+			cmd.Flags().Visit(func(flag *pflag.Flag) {
+				if field, ok := flagFields[flag.Name]; ok {
+					fields = append(fields, field)
+				}
+			})
+
+			if len(fields) == 0 {
+				return fmt.Errorf("nothing to update, give at least one of --meeting-id, --name, --number, --visible or --start")
+			}
+
+			return g.withRace(cmd.Context(), func(c *client.Client) (*racing.Race, error) {
+				return c.UpdateRace(cmd.Context(), in, fields...)
+			})
+		},
+	}
+
+	race.register(cmd.Flags())
+
+	return cmd
+}
+
+func newDeleteCommand(g *globals) *cobra.Command {
+	return &cobra.Command{
+		Use:   "delete ID",
+		Short: "Delete a race",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := parseID(args[0])
+			if err != nil {
+				return err
+			}
+
+			c, err := g.connect(cmd.Context())
+			if err != nil {
+				return err
+			}
+			defer c.Close()
+
+			if err := c.DeleteRace(cmd.Context(), id); err != nil {
+				return err
+			}
+
+			fmt.Fprintf(cmd.ErrOrStderr(), "deleted race %d\n", id)
+
+			return nil
+		},
+	}
+}
+
+func newTransitionCommand(g *globals) *cobra.Command {
+	var actor, reason string
+
+	cmd := &cobra.Command{
+		Use:   "transition ID STATE [--reason REASON]",
+		Short: "Move a race to another state, such as open, suspended or abandoned",
+		Args:  cobra.ExactArgs(2),
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			if len(args) != 1 {
+				return nil, cobra.ShellCompDirectiveNoFileComp
+			}
+
+			return completeStates(cmd, args, toComplete)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := parseID(args[0])
+			if err != nil {
+				return err
+			}
+
+			state, err := parseState(args[1])
+			if err != nil {
+				return err
+			}
+
+			return g.withRace(cmd.Context(), func(c *client.Client) (*racing.Race, error) {
+				resp, err := c.TransitionRace(cmd.Context(), id, state, actor, reason)
+				if err != nil {
+					return nil, err
+				}
+
+				return resp.Race, nil
+			})
+		},
+	}
+
+	cmd.Flags().StringVar(&actor, "actor", defaultActor(), "who is making the change")
+	cmd.Flags().StringVar(&reason, "reason", "", "why the change is being made")
+
+	return cmd
+}
+
+func newScratchCommand(g *globals) *cobra.Command {
+	in := racing.ScratchRunnerRequest{}
+
+	cmd := &cobra.Command{
+		Use:   "scratch ID RUNNER [--reason REASON]",
+		Short: "Scratch a runner from a race, working out the deductions it brings",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var err error
+			if in.RaceId, err = parseID(args[0]); err != nil {
+				return err
+			}
+
+			if in.RunnerNumber, err = strconv.ParseInt(args[1], 10, 64); err != nil || in.RunnerNumber <= 0 {
+				return fmt.Errorf("invalid runner number %q", args[1])
+			}
+
+			return g.withRace(cmd.Context(), func(c *client.Client) (*racing.Race, error) {
+				resp, err := c.ScratchRunner(cmd.Context(), &in)
+				if err != nil {
+					return nil, err
+				}
+
+				return resp.Race, nil
+			})
+		},
+	}
+
+	cmd.Flags().StringVar(&in.Actor, "actor", defaultActor(), "who is scratching the runner")
+	cmd.Flags().StringVar(&in.Reason, "reason", "", "why the runner is being scratched")
+
+	return cmd
+}
+
+func newHistoryCommand(g *globals) *cobra.Command {
+	return &cobra.Command{
+		Use:   "history ID",
+		Short: "List the state changes of a race",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := parseID(args[0])
+			if err != nil {
+				return err
+			}
+
+			p, err := g.printer()
+			if err != nil {
+				return err
+			}
+
+			c, err := g.connect(cmd.Context())
+			if err != nil {
+				return err
+			}
+			defer c.Close()
+
+			transitions, err := c.ListRaceTransitions(cmd.Context(), id)
+			if err != nil {
+				return err
+			}
+
+			return p.Transitions(transitions)
+		},
+	}
+}
+
+// parseState returns the race state of the given name, in any case.
+func parseState(name string) (racing.Race_State, error) {
+	state, ok := racing.Race_State_value[strings.ToUpper(name)]
+	if !ok || state == int32(racing.Race_STATE_UNSPECIFIED) {
+		return 0, fmt.Errorf("unknown race state %q, expected one of %s", name, strings.Join(stateNames(), ", "))
+	}
+
+	return racing.Race_State(state), nil
+}
+
+// stateNames returns the names of the race states, in lower case and lifecycle order.
+func stateNames() []string {
+	var names []string
+	for state := racing.Race_SCHEDULED; racing.Race_State_name[int32(state)] != ""; state++ {
+		names = append(names, strings.ToLower(state.String()))
+	}
+
+	return names
+}
+
+func completeStates(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return stateNames(), cobra.ShellCompDirectiveNoFileComp
+}
+
+// defaultActor names the user running racingctl as the actor of the changes they make.
+func defaultActor() string {
+	if u, err := user.Current(); err == nil && u.Username != "" {
+		return u.Username
+	}
+
+	return "racingctl"
+}
+
+// withRace connects to the service, calls it, and prints the race it returns.
+func (g *globals) withRace(ctx context.Context, call func(c *client.Client) (*racing.Race, error)) error {
+	p, err := g.printer()
+	if err != nil {
+		return err
+	}
+
+	c, err := g.connect(ctx)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+
+	race, err := call(c)
+	if err != nil {
+		return err
+	}
+
+	return p.Race(race)
+}
+
+func parseID(arg string) (int64, error) {
+	id, err := strconv.ParseInt(arg, 10, 64)
+	if err != nil || id <= 0 {
+		return 0, fmt.Errorf("invalid race ID %q", arg)
+	}
+
+	return id, nil
+}
@@ -0,0 +1,145 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"git.neds.sh/matty/entain/racing/proto/racing"
+)
+
+func newResultCommand(g *globals) *cobra.Command {
+	var history bool
+
+	cmd := &cobra.Command{
+		Use:   "result ID [--history]",
+		Short: "Get the result of a race",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := parseID(args[0])
+			if err != nil {
+				return err
+			}
+
+			p, err := g.printer()
+			if err != nil {
+				return err
+			}
+
+			c, err := g.connect(cmd.Context())
+			if err != nil {
+				return err
+			}
+			defer c.Close()
+
+			resp, err := c.GetResult(cmd.Context(), id, history)
+			if err != nil {
+				return err
+			}
+
+			return p.Result(resp)
+		},
+	}
+
+	cmd.Flags().BoolVar(&history, "history", false, "also print every version the result amended")
+
+	return cmd
+}
+
+func newSubmitResultCommand(g *globals) *cobra.Command {
+	var (
+		placings []string
+		in       racing.SubmitResultRequest
+		protest  string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "submit-result ID --placing POSITION:RUNNER[:MARGIN]... [--final] [--protest STATUS] [--reason REASON]",
+		Short: "Submit or amend the result of a race",
+		Example: `  # Runner 4 wins by a length and a half from runner 7, who dead heats for second with runner 2.
+  racingctl submit-result 42 --placing 1:4 --placing 2:7:1.5 --placing 2:2`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var err error
+			if in.RaceId, err = parseID(args[0]); err != nil {
+				return err
+			}
+
+			for _, arg := range placings {
+				placing, err := parsePlacing(arg)
+				if err != nil {
+					return err
+				}
+
+				in.Placings = append(in.Placings, placing)
+			}
+
+			value, ok := racing.Result_Protest_value[strings.ToUpper(protest)]
+			if !ok {
+				return fmt.Errorf("unknown protest status %q, expected no_protest, lodged, upheld or dismissed", protest)
+			}
+
+			in.Protest = racing.Result_Protest(value)
+
+			p, err := g.printer()
+			if err != nil {
+				return err
+			}
+
+			c, err := g.connect(cmd.Context())
+			if err != nil {
+				return err
+			}
+			defer c.Close()
+
+			result, err := c.SubmitResult(cmd.Context(), &in)
+			if err != nil {
+				return err
+			}
+
+			return p.Result(&racing.GetResultResponse{Result: result})
+		},
+	}
+
+	cmd.Flags().StringArrayVar(&placings, "placing", nil, "a placed runner as POSITION:RUNNER[:MARGIN], in order of position, repeated")
+	cmd.Flags().BoolVar(&in.Final, "final", false, "whether the result is official")
+	cmd.Flags().StringVar(&protest, "protest", "no_protest", "status of any protest: no_protest, lodged, upheld or dismissed")
+	cmd.Flags().StringVar(&in.Actor, "actor", defaultActor(), "who is submitting the result")
+	cmd.Flags().StringVar(&in.Reason, "reason", "", "why the result is being amended")
+	_ = cmd.MarkFlagRequired("placing")
+
+	return cmd
+}
+
+// parsePlacing parses a placing given as POSITION:RUNNER[:MARGIN], with the margin in lengths.
+func parsePlacing(arg string) (*racing.Placing, error) {
+	invalid := fmt.Errorf("invalid placing %q, expected POSITION:RUNNER[:MARGIN] such as 2:7:1.5", arg)
+
+	parts := strings.Split(arg, ":")
+	if len(parts) < 2 || len(parts) > 3 {
+		return nil, invalid
+	}
+
+	var (
+		placing racing.Placing
+		err     error
+	)
+
+	if placing.Position, err = strconv.ParseInt(parts[0], 10, 64); err != nil {
+		return nil, invalid
+	}
+
+	if placing.RunnerNumber, err = strconv.ParseInt(parts[1], 10, 64); err != nil {
+		return nil, invalid
+	}
+
+	if len(parts) == 3 {
+		if placing.Margin, err = strconv.ParseFloat(parts[2], 64); err != nil {
+			return nil, invalid
+		}
+	}
+
+	return &placing, nil
+}
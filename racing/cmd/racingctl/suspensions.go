@@ -0,0 +1,141 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/spf13/cobra"
+
+	"git.neds.sh/matty/entain/racing/proto/racing"
+)
+
+func newSuspendCommand(g *globals) *cobra.Command {
+	var (
+		in       racing.SuspendTradingRequest
+		global   bool
+		duration time.Duration
+	)
+
+	cmd := &cobra.Command{
+		Use:   "suspend (--race ID | --meeting ID | --global) --reason REASON [--for DURATION]",
+		Short: "Suspend trading on a race, a meeting or all racing, until resumed or for a duration",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			switch {
+			case in.RaceId != 0 && in.MeetingId == 0 && !global:
+				in.Scope = racing.Suspension_RACE
+			case in.MeetingId != 0 && in.RaceId == 0 && !global:
+				in.Scope = racing.Suspension_MEETING
+			case global && in.RaceId == 0 && in.MeetingId == 0:
+				in.Scope = racing.Suspension_GLOBAL
+			default:
+				return fmt.Errorf("give exactly one of --race, --meeting or --global")
+			}
+
+			if cmd.Flags().Changed("for") {
+				in.Duration = ptypes.DurationProto(duration)
+			}
+
+			p, err := g.printer()
+			if err != nil {
+				return err
+			}
+
+			c, err := g.connect(cmd.Context())
+			if err != nil {
+				return err
+			}
+			defer c.Close()
+
+			suspension, err := c.Raw().SuspendTrading(cmd.Context(), &in)
+			if err != nil {
+				return err
+			}
+
+			return p.Suspensions([]*racing.Suspension{suspension})
+		},
+	}
+
+	cmd.Flags().Int64Var(&in.RaceId, "race", 0, "race to suspend")
+	cmd.Flags().Int64Var(&in.MeetingId, "meeting", 0, "meeting whose races to suspend")
+	cmd.Flags().BoolVar(&global, "global", false, "suspend all racing")
+	cmd.Flags().StringVar(&in.Actor, "actor", defaultActor(), "who is suspending trading")
+	cmd.Flags().StringVar(&in.Reason, "reason", "", "why trading is being suspended")
+	cmd.Flags().DurationVar(&duration, "for", 0, "resume trading by itself after this long, such as 10m (default until resumed)")
+
+	return cmd
+}
+
+func newResumeCommand(g *globals) *cobra.Command {
+	var in racing.ResumeTradingRequest
+
+	cmd := &cobra.Command{
+		Use:   "resume SUSPENSION [--reason REASON]",
+		Short: "Lift a suspension of trading",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var err error
+			if in.Id, err = strconv.ParseInt(args[0], 10, 64); err != nil || in.Id <= 0 {
+				return fmt.Errorf("invalid suspension ID %q", args[0])
+			}
+
+			p, err := g.printer()
+			if err != nil {
+				return err
+			}
+
+			c, err := g.connect(cmd.Context())
+			if err != nil {
+				return err
+			}
+			defer c.Close()
+
+			suspension, err := c.Raw().ResumeTrading(cmd.Context(), &in)
+			if err != nil {
+				return err
+			}
+
+			return p.Suspensions([]*racing.Suspension{suspension})
+		},
+	}
+
+	cmd.Flags().StringVar(&in.Actor, "actor", defaultActor(), "who is resuming trading")
+	cmd.Flags().StringVar(&in.Reason, "reason", "", "why trading is being resumed")
+
+	return cmd
+}
+
+func newSuspensionsCommand(g *globals) *cobra.Command {
+	var in racing.ListSuspensionsRequest
+
+	cmd := &cobra.Command{
+		Use:   "suspensions [--in-force]",
+		Short: "List the suspensions of trading, and who made and lifted them",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			p, err := g.printer()
+			if err != nil {
+				return err
+			}
+
+			c, err := g.connect(cmd.Context())
+			if err != nil {
+				return err
+			}
+			defer c.Close()
+
+			resp, err := c.Raw().ListSuspensions(cmd.Context(), &in)
+			if err != nil {
+				return err
+			}
+
+			return p.Suspensions(resp.Suspensions)
+		},
+	}
+
+	cmd.Flags().BoolVar(&in.InForce, "in-force", false, "only the suspensions in force now")
+
+	return cmd
+}
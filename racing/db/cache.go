@@ -0,0 +1,308 @@
+package db
+
+import (
+	"container/list"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+	"google.golang.org/protobuf/proto"
+
+	"git.neds.sh/matty/entain/racing/filtering"
+	"git.neds.sh/matty/entain/racing/proto/racing"
+)
+
+// CachedRacesRepo is a RacesRepo that serves repeated queries from memory.
+type CachedRacesRepo interface {
+	RacesRepo
+
+	// Stats will return how effective the cache has been so far.
+	Stats() CacheStats
+}
+
+// CacheStats counts how queries to a CachedRacesRepo have been served.
+type CacheStats struct {
+	// Hits were served from the cache.
+	Hits int64 `json:"hits"`
+	// Misses had to query the underlying repository.
+	Misses int64 `json:"misses"`
+	// Coalesced were misses that shared the result of an identical query already in flight.
+	Coalesced int64 `json:"coalesced"`
+	// Evictions were entries dropped to keep the cache within its size limit.
+	Evictions int64 `json:"evictions"`
+	// Entries is the number of queries currently cached.
+	Entries int `json:"entries"`
+	// HitRatio is the proportion of queries served from the cache.
+	HitRatio float64 `json:"hit_ratio"`
+}
+
+// cacheEntry is a cached query result, which is stale once it expires.
+type cacheEntry struct {
+	key     string
+	value   interface{}
+	expires time.Time
+}
+
+type cachedRacesRepo struct {
+	RacesRepo
+
+	size int
+	ttl  time.Duration
+	now  func() time.Time
+
+	mu    sync.Mutex
+	lru   *list.List
+	items map[string]*list.Element
+	// generation is bumped by every write, so that queries started before it are not cached after it.
+	generation uint64
+	stats      CacheStats
+
+	flights singleflight.Group
+}
+
+// NewCachedRacesRepo wraps a races repository with a read-through cache of up to size query results,
+// each of which is kept for at most ttl. Identical queries made at the same time share a single query
+// of the underlying repository, and any write through the cache empties it.
+func NewCachedRacesRepo(repo RacesRepo, size int, ttl time.Duration) CachedRacesRepo {
+	return &cachedRacesRepo{
+		RacesRepo: repo,
+		size:      size,
+		ttl:       ttl,
+		now:       time.Now,
+		lru:       list.New(),
+		items:     make(map[string]*list.Element),
+	}
+}
+
+func (c *cachedRacesRepo) List(filter *racing.ListRacesRequestFilter, expr filtering.Expr, page Page) ([]*racing.Race, error) {
+	value, err := c.get(listKey(filter, expr, page), func() (interface{}, error) {
+		return c.RacesRepo.List(filter, expr, page)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	races := value.([]*racing.Race)
+
+	// Callers are free to modify what they are given, so they each get their own copy.
+	clones := make([]*racing.Race, len(races))
+	for i, race := range races {
+		clones[i] = proto.Clone(race).(*racing.Race)
+	}
+
+	return clones, nil
+}
+
+func (c *cachedRacesRepo) Get(id int64) (*racing.Race, error) {
+	value, err := c.get("get|"+strconv.FormatInt(id, 10), func() (interface{}, error) {
+		return c.RacesRepo.Get(id)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return proto.Clone(value.(*racing.Race)).(*racing.Race), nil
+}
+
+func (c *cachedRacesRepo) Search(query string, limit int) ([]*racing.RaceSearchResult, error) {
+	key := "search|" + strconv.Itoa(limit) + "|" + strings.ToLower(searchMatch(query))
+
+	value, err := c.get(key, func() (interface{}, error) {
+		return c.RacesRepo.Search(query, limit)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	results := value.([]*racing.RaceSearchResult)
+
+	clones := make([]*racing.RaceSearchResult, len(results))
+	for i, result := range results {
+		clones[i] = proto.Clone(result).(*racing.RaceSearchResult)
+	}
+
+	return clones, nil
+}
+
+func (c *cachedRacesRepo) Import(races []*racing.Race, dryRun bool) (ImportResult, error) {
+	result, err := c.RacesRepo.Import(races, dryRun)
+
+	// Even a failed import may have partly applied, so the cache is emptied regardless.
+	if !dryRun {
+		c.purge()
+	}
+
+	return result, err
+}
+
+func (c *cachedRacesRepo) Create(race *racing.Race) (*racing.Race, error) {
+	defer c.purge()
+
+	return c.RacesRepo.Create(race)
+}
+
+func (c *cachedRacesRepo) Update(race *racing.Race) (*racing.Race, error) {
+	defer c.purge()
+
+	return c.RacesRepo.Update(race)
+}
+
+func (c *cachedRacesRepo) Delete(id int64) (*racing.Race, error) {
+	defer c.purge()
+
+	return c.RacesRepo.Delete(id)
+}
+
+func (c *cachedRacesRepo) Transition(id int64, from, to racing.Race_State, actor, reason string) (*racing.Race, *racing.RaceTransition, error) {
+	defer c.purge()
+
+	return c.RacesRepo.Transition(id, from, to, actor, reason)
+}
+
+func (c *cachedRacesRepo) SaveResult(result *racing.Result, version int64, from racing.Race_State, moves []racing.Race_State) (*racing.Result, []*racing.RaceTransition, error) {
+	defer c.purge()
+
+	return c.RacesRepo.SaveResult(result, version, from, moves)
+}
+
+func (c *cachedRacesRepo) Scratch(scratching *racing.Scratching) (*racing.Race, *racing.Scratching, error) {
+	defer c.purge()
+
+	return c.RacesRepo.Scratch(scratching)
+}
+
+func (c *cachedRacesRepo) Stats() CacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	stats := c.stats
+	stats.Entries = c.lru.Len()
+
+	if total := stats.Hits + stats.Misses; total > 0 {
+		stats.HitRatio = float64(stats.Hits) / float64(total)
+	}
+
+	return stats
+}
+
+// get returns the cached value for the key, loading and caching it on a miss.
+func (c *cachedRacesRepo) get(key string, load func() (interface{}, error)) (interface{}, error) {
+	c.mu.Lock()
+
+	if elem, ok := c.items[key]; ok {
+		entry := elem.Value.(*cacheEntry)
+		if c.now().Before(entry.expires) {
+			c.lru.MoveToFront(elem)
+			c.stats.Hits++
+			c.mu.Unlock()
+
+			return entry.value, nil
+		}
+
+		c.remove(elem)
+	}
+
+	c.stats.Misses++
+	generation := c.generation
+	c.mu.Unlock()
+
+	// Queries are only coalesced within a generation, so none can be handed a result from before a write.
+	flight := key + "#" + strconv.FormatUint(generation, 10)
+
+	value, err, shared := c.flights.Do(flight, func() (interface{}, error) {
+		value, err := load()
+		if err == nil {
+			c.put(key, value, generation)
+		}
+
+		return value, err
+	})
+
+	if shared {
+		c.mu.Lock()
+		c.stats.Coalesced++
+		c.mu.Unlock()
+	}
+
+	return value, err
+}
+
+// put caches the value of a query, unless a write has happened since the query started.
+func (c *cachedRacesRepo) put(key string, value interface{}, generation uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if generation != c.generation || c.size <= 0 {
+		return
+	}
+
+	if elem, ok := c.items[key]; ok {
+		c.remove(elem)
+	}
+
+	c.items[key] = c.lru.PushFront(&cacheEntry{
+		key:     key,
+		value:   value,
+		expires: c.now().Add(c.ttl),
+	})
+
+	for c.lru.Len() > c.size {
+		c.remove(c.lru.Back())
+		c.stats.Evictions++
+	}
+}
+
+// remove drops an entry from the cache. The caller must hold the lock.
+func (c *cachedRacesRepo) remove(elem *list.Element) {
+	c.lru.Remove(elem)
+	delete(c.items, elem.Value.(*cacheEntry).key)
+}
+
+// purge empties the cache, and stops queries already in flight from being cached.
+func (c *cachedRacesRepo) purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.generation++
+	c.lru.Init()
+	c.items = make(map[string]*list.Element)
+}
+
+// listKey normalises a list query, so that equivalent filters share a cache entry.
+func listKey(filter *racing.ListRacesRequestFilter, expr filtering.Expr, page Page) string {
+	var meetingIDs []int64
+	if filter != nil {
+		meetingIDs = append(meetingIDs, filter.MeetingIds...)
+	}
+
+	sort.Slice(meetingIDs, func(i, j int) bool { return meetingIDs[i] < meetingIDs[j] })
+
+	var states []string
+	for _, state := range filter.GetStates() {
+		states = append(states, state.String())
+	}
+
+	sort.Strings(states)
+
+	var key strings.Builder
+	key.WriteString("list|" + strconv.FormatInt(page.AfterID, 10) + "|" + strconv.Itoa(page.Size) + "|")
+
+	for i, meetingID := range meetingIDs {
+		if i > 0 && meetingID == meetingIDs[i-1] {
+			continue
+		}
+
+		key.WriteString(strconv.FormatInt(meetingID, 10) + ",")
+	}
+
+	key.WriteString("|" + strings.Join(states, ","))
+
+	if expr != nil {
+		key.WriteString("|" + expr.String())
+	}
+
+	return key.String()
+}
@@ -0,0 +1,263 @@
+package db
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"git.neds.sh/matty/entain/racing/filtering"
+	"git.neds.sh/matty/entain/racing/proto/racing"
+)
+
+// countingRepo is a RacesRepo counting the queries that reach it. Writes succeed without doing anything,
+// and Get blocks while its gate is set, until the gate is closed.
+type countingRepo struct {
+	RacesRepo
+
+	mu      sync.Mutex
+	queries int
+	gate    chan struct{}
+	started chan struct{}
+}
+
+func (r *countingRepo) count() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.queries
+}
+
+func (r *countingRepo) query() {
+	r.mu.Lock()
+	r.queries++
+	gate, started := r.gate, r.started
+	r.mu.Unlock()
+
+	if gate != nil {
+		close(started)
+		<-gate
+	}
+}
+
+func (r *countingRepo) List(*racing.ListRacesRequestFilter, filtering.Expr, Page) ([]*racing.Race, error) {
+	r.query()
+	return []*racing.Race{{Id: 1}}, nil
+}
+
+func (r *countingRepo) Get(id int64) (*racing.Race, error) {
+	r.query()
+	return &racing.Race{Id: id}, nil
+}
+
+func (r *countingRepo) Search(string, int) ([]*racing.RaceSearchResult, error) {
+	r.query()
+	return []*racing.RaceSearchResult{{Race: &racing.Race{Id: 1}}}, nil
+}
+
+func (r *countingRepo) Create(race *racing.Race) (*racing.Race, error) {
+	return race, nil
+}
+
+func (r *countingRepo) Update(race *racing.Race) (*racing.Race, error) {
+	return race, nil
+}
+
+func (r *countingRepo) Delete(id int64) (*racing.Race, error) {
+	return &racing.Race{Id: id}, nil
+}
+
+func (r *countingRepo) Transition(id int64, _, _ racing.Race_State, _, _ string) (*racing.Race, *racing.RaceTransition, error) {
+	return &racing.Race{Id: id}, &racing.RaceTransition{}, nil
+}
+
+func (r *countingRepo) SaveResult(result *racing.Result, _ int64, _ racing.Race_State, _ []racing.Race_State) (*racing.Result, []*racing.RaceTransition, error) {
+	return result, nil, nil
+}
+
+func (r *countingRepo) Scratch(scratching *racing.Scratching) (*racing.Race, *racing.Scratching, error) {
+	return &racing.Race{}, scratching, nil
+}
+
+func (r *countingRepo) Import([]*racing.Race, bool) (ImportResult, error) {
+	return ImportResult{}, nil
+}
+
+// reads are the cached queries, each of which must be served from the cache when repeated.
+var reads = map[string]func(repo RacesRepo) error{
+	"list": func(repo RacesRepo) error {
+		_, err := repo.List(&racing.ListRacesRequestFilter{MeetingIds: []int64{1}}, nil, Page{})
+		return err
+	},
+	"get": func(repo RacesRepo) error {
+		_, err := repo.Get(1)
+		return err
+	},
+	"search": func(repo RacesRepo) error {
+		_, err := repo.Search("Flemington", 5)
+		return err
+	},
+}
+
+func TestCachedRacesRepoInvalidation(t *testing.T) {
+	writes := []struct {
+		name  string
+		write func(repo RacesRepo) error
+		purge bool
+	}{
+		{name: "create", purge: true, write: func(repo RacesRepo) error {
+			_, err := repo.Create(&racing.Race{})
+			return err
+		}},
+		{name: "update", purge: true, write: func(repo RacesRepo) error {
+			_, err := repo.Update(&racing.Race{Id: 1})
+			return err
+		}},
+		{name: "delete", purge: true, write: func(repo RacesRepo) error {
+			_, err := repo.Delete(1)
+			return err
+		}},
+		{name: "transition", purge: true, write: func(repo RacesRepo) error {
+			_, _, err := repo.Transition(1, racing.Race_SCHEDULED, racing.Race_OPEN, "trader", "")
+			return err
+		}},
+		{name: "save result", purge: true, write: func(repo RacesRepo) error {
+			_, _, err := repo.SaveResult(&racing.Result{RaceId: 1}, 0, racing.Race_CLOSED, nil)
+			return err
+		}},
+		{name: "scratch", purge: true, write: func(repo RacesRepo) error {
+			_, _, err := repo.Scratch(&racing.Scratching{RaceId: 1, RunnerNumber: 4})
+			return err
+		}},
+		{name: "import", purge: true, write: func(repo RacesRepo) error {
+			_, err := repo.Import([]*racing.Race{{Id: 1}}, false)
+			return err
+		}},
+		{name: "dry run import", purge: false, write: func(repo RacesRepo) error {
+			_, err := repo.Import([]*racing.Race{{Id: 1}}, true)
+			return err
+		}},
+	}
+
+	for _, tt := range writes {
+		for name, read := range reads {
+			t.Run(tt.name+" then "+name, func(t *testing.T) {
+				underlying := &countingRepo{}
+				cache := NewCachedRacesRepo(underlying, 10, time.Minute)
+
+				for i := 0; i < 2; i++ {
+					if err := read(cache); err != nil {
+						t.Fatalf("read = %v", err)
+					}
+				}
+
+				if got := underlying.count(); got != 1 {
+					t.Fatalf("queries before %s = %d, want 1", tt.name, got)
+				}
+
+				if err := tt.write(cache); err != nil {
+					t.Fatalf("%s = %v", tt.name, err)
+				}
+
+				if err := read(cache); err != nil {
+					t.Fatalf("read = %v", err)
+				}
+
+				want := 1
+				if tt.purge {
+					want = 2
+				}
+
+				if got := underlying.count(); got != want {
+					t.Errorf("queries after %s = %d, want %d", tt.name, got, want)
+				}
+			})
+		}
+	}
+}
+
+// TestCachedRacesRepoStalePut checks that a query in flight during a write is not cached after it, as it
+// may have read the races from before the write.
+func TestCachedRacesRepoStalePut(t *testing.T) {
+	underlying := &countingRepo{gate: make(chan struct{}), started: make(chan struct{})}
+	cache := NewCachedRacesRepo(underlying, 10, time.Minute)
+
+	done := make(chan error)
+	go func() {
+		_, err := cache.Get(1)
+		done <- err
+	}()
+
+	<-underlying.started
+
+	if _, err := cache.Update(&racing.Race{Id: 1}); err != nil {
+		t.Fatalf("Update() = %v", err)
+	}
+
+	underlying.mu.Lock()
+	close(underlying.gate)
+	underlying.gate = nil
+	underlying.mu.Unlock()
+
+	if err := <-done; err != nil {
+		t.Fatalf("Get() = %v", err)
+	}
+
+	if _, err := cache.Get(1); err != nil {
+		t.Fatalf("Get() = %v", err)
+	}
+
+	if got := underlying.count(); got != 2 {
+		t.Errorf("queries = %d, want 2, the query in flight during the write not having been cached", got)
+	}
+
+	if _, err := cache.Get(1); err != nil {
+		t.Fatalf("Get() = %v", err)
+	}
+
+	if got := underlying.count(); got != 2 {
+		t.Errorf("queries = %d, want 2, the query after the write having been cached", got)
+	}
+}
+
+func TestCachedRacesRepoExpiryAndEviction(t *testing.T) {
+	underlying := &countingRepo{}
+	cache := NewCachedRacesRepo(underlying, 2, time.Minute).(*cachedRacesRepo)
+
+	now := time.Date(2026, 11, 3, 15, 0, 0, 0, time.UTC)
+	cache.now = func() time.Time { return now }
+
+	get := func(id int64) {
+		if _, err := cache.Get(id); err != nil {
+			t.Fatalf("Get(%d) = %v", id, err)
+		}
+	}
+
+	get(1)
+	get(2)
+	get(1)
+	get(3)
+
+	if got := underlying.count(); got != 3 {
+		t.Fatalf("queries = %d, want 3", got)
+	}
+
+	// Race 2 was the least recently used, so it was evicted for race 3.
+	get(1)
+	get(2)
+
+	if got := underlying.count(); got != 4 {
+		t.Errorf("queries = %d, want 4, with race 2 evicted and race 1 kept", got)
+	}
+
+	now = now.Add(time.Minute)
+	get(2)
+
+	if got := underlying.count(); got != 5 {
+		t.Errorf("queries = %d, want 5, with race 2 expired", got)
+	}
+
+	stats := cache.Stats()
+	if stats.Hits != 2 || stats.Misses != 5 || stats.Evictions != 2 || stats.Entries != 2 {
+		t.Errorf("Stats() = %+v, want 2 hits, 5 misses, 2 evictions and 2 entries", stats)
+	}
+}
@@ -1,13 +1,28 @@
 package db
 
 import (
+	"math/rand"
 	"time"
 
 	"syreclabs.com/go/faker"
 )
 
+// meetingNames are the venues of our dummy meetings, with IDs 1 to 10.
+var meetingNames = []string{
+	"Flemington",
+	"Randwick",
+	"Caulfield",
+	"Rosehill Gardens",
+	"Moonee Valley",
+	"Eagle Farm",
+	"Morphettville",
+	"Ascot",
+	"Doomben",
+	"Warwick Farm",
+}
+
 func (r *racesRepo) seed() error {
-	statement, err := r.db.Prepare(`CREATE TABLE IF NOT EXISTS races (id INTEGER PRIMARY KEY, meeting_id INTEGER, name TEXT, number INTEGER, visible INTEGER, advertised_start_time DATETIME)`)
+	statement, err := r.db.Prepare(`CREATE TABLE IF NOT EXISTS races (id INTEGER PRIMARY KEY, meeting_id INTEGER, name TEXT, number INTEGER, visible INTEGER, advertised_start_time DATETIME, update_time DATETIME)`)
 	if err == nil {
 		_, err = statement.Exec()
 	}
@@ -26,5 +41,35 @@ func (r *racesRepo) seed() error {
 		}
 	}
 
+	statement, err = r.db.Prepare(`CREATE TABLE IF NOT EXISTS runners (race_id INTEGER, number INTEGER, name TEXT, barrier INTEGER, PRIMARY KEY (race_id, number))`)
+	if err == nil {
+		_, err = statement.Exec()
+	}
+
+	for i := 1; i <= 100; i++ {
+		// Fields are of 6 to 14 runners, each drawn in a different barrier.
+		count := 6 + i%9
+		barriers := rand.Perm(count)
+
+		for number := 1; number <= count; number++ {
+			statement, err = r.db.Prepare(`INSERT OR IGNORE INTO runners(race_id, number, name, barrier) VALUES (?,?,?,?)`)
+			if err == nil {
+				_, err = statement.Exec(i, number, faker.Commerce().Color()+" "+faker.Name().FirstName(), barriers[number-1]+1)
+			}
+		}
+	}
+
+	statement, err = r.db.Prepare(`CREATE TABLE IF NOT EXISTS meetings (id INTEGER PRIMARY KEY, name TEXT)`)
+	if err == nil {
+		_, err = statement.Exec()
+	}
+
+	for i, name := range meetingNames {
+		statement, err = r.db.Prepare(`INSERT OR IGNORE INTO meetings(id, name) VALUES (?,?)`)
+		if err == nil {
+			_, err = statement.Exec(i+1, name)
+		}
+	}
+
 	return err
 }
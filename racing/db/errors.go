@@ -0,0 +1,34 @@
+package db
+
+import (
+	"errors"
+	"time"
+
+	"github.com/mattn/go-sqlite3"
+
+	"git.neds.sh/matty/entain/common/errs"
+)
+
+// busyRetryAfter is how long clients are asked to wait when the database is busy.
+const busyRetryAfter = time.Second
+
+// wrapError translates database errors into the errors of the racing domain, where one applies.
+func wrapError(err error) error {
+	var sqliteErr sqlite3.Error
+	if errors.As(err, &sqliteErr) {
+		switch sqliteErr.Code {
+		case sqlite3.ErrBusy, sqlite3.ErrLocked:
+			return &errs.Unavailable{RetryAfter: busyRetryAfter, Err: err}
+		}
+	}
+
+	return err
+}
+
+// isConstraintError reports whether a statement failed because it broke a constraint, such as a
+// primary key being taken.
+func isConstraintError(err error) bool {
+	var sqliteErr sqlite3.Error
+
+	return errors.As(err, &sqliteErr) && sqliteErr.Code == sqlite3.ErrConstraint
+}
@@ -0,0 +1,145 @@
+package db
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/timestamp"
+
+	"git.neds.sh/matty/entain/racing/proto/racing"
+)
+
+// MarketsRepo provides repository access to the fixed-odds prices of runners. Its tables are created by
+// the RacesRepo sharing its database, which must be initialised first.
+type MarketsRepo interface {
+	// Prices will return the priced runners of a race, in order of their numbers, with their current
+	// prices as decimal odds. If flucs is set, each has every earlier price it had, oldest first.
+	Prices(raceID int64, flucs bool) ([]*racing.RunnerPrices, error)
+
+	// Update will set the prices of runners of a race, keeping the prices they replace as flucs.
+	Update(raceID int64, prices []*racing.PriceUpdate) error
+}
+
+type marketsRepo struct {
+	db *sql.DB
+}
+
+// NewMarketsRepo creates a new markets repository.
+func NewMarketsRepo(db *sql.DB) MarketsRepo {
+	return &marketsRepo{db: db}
+}
+
+func (m *marketsRepo) Prices(raceID int64, flucs bool) ([]*racing.RunnerPrices, error) {
+	runners, err := m.prices(raceID, flucs)
+
+	return runners, wrapError(err)
+}
+
+func (m *marketsRepo) prices(raceID int64, flucs bool) ([]*racing.RunnerPrices, error) {
+	queries := getRaceQueries()
+
+	rows, err := m.db.Query(queries[pricesList], raceID)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		runners  []*racing.RunnerPrices
+		byNumber = make(map[int64]*racing.RunnerPrices)
+	)
+
+	err = scanPrices(rows, func(number int64, win, place *racing.Odds, at *timestamp.Timestamp) {
+		runner := &racing.RunnerPrices{RunnerNumber: number, Win: win, Place: place, UpdateTime: at}
+		runners = append(runners, runner)
+		byNumber[number] = runner
+	})
+	if err != nil || !flucs {
+		return runners, err
+	}
+
+	if rows, err = m.db.Query(queries[priceHistoryList], raceID); err != nil {
+		return nil, err
+	}
+
+	// The history includes the current prices, as the last price of each runner, which are not flucs.
+	err = scanPrices(rows, func(number int64, win, place *racing.Odds, at *timestamp.Timestamp) {
+		if runner, ok := byNumber[number]; ok {
+			runner.Flucs = append(runner.Flucs, &racing.Fluc{Win: win, Place: place, Time: at})
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, runner := range runners {
+		if len(runner.Flucs) > 0 {
+			runner.Flucs = runner.Flucs[:len(runner.Flucs)-1]
+		}
+	}
+
+	return runners, nil
+}
+
+func (m *marketsRepo) Update(raceID int64, prices []*racing.PriceUpdate) error {
+	return wrapError(m.update(raceID, prices))
+}
+
+func (m *marketsRepo) update(raceID int64, prices []*racing.PriceUpdate) error {
+	tx, err := m.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	queries := getRaceQueries()
+	now := time.Now().UTC().Truncate(time.Millisecond).Format(time.RFC3339Nano)
+
+	for _, price := range prices {
+		if _, err := tx.Exec(queries[pricesUpsert], raceID, price.RunnerNumber, price.Win, price.Place, now); err != nil {
+			return err
+		}
+
+		if _, err := tx.Exec(queries[priceHistoryInsert], raceID, price.RunnerNumber, price.Win, price.Place, now); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// scanPrices reads rows of runner numbers, win and place prices, and times, passing each to fn with
+// prices of zero, which are not offered, left unset.
+func scanPrices(rows *sql.Rows, fn func(number int64, win, place *racing.Odds, at *timestamp.Timestamp)) error {
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			number     int64
+			win, place float64
+			at         time.Time
+		)
+
+		if err := rows.Scan(&number, &win, &place, &at); err != nil {
+			return err
+		}
+
+		ts, err := ptypes.TimestampProto(at)
+		if err != nil {
+			return err
+		}
+
+		fn(number, decimalOdds(win), decimalOdds(place), ts)
+	}
+
+	return rows.Err()
+}
+
+// decimalOdds returns a stored price as odds, or nil if the price is zero.
+func decimalOdds(price float64) *racing.Odds {
+	if price == 0 {
+		return nil
+	}
+
+	return &racing.Odds{Decimal: price}
+}
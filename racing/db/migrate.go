@@ -0,0 +1,183 @@
+package db
+
+import "fmt"
+
+// migrations bring databases created by earlier versions of the racing service up to date. Each must be
+// safe to run against a database it has already been applied to.
+var migrations = []func(r *racesRepo) error{
+	func(r *racesRepo) error {
+		if err := r.addColumn("races", "update_time", "DATETIME"); err != nil {
+			return err
+		}
+
+		_, err := r.db.Exec(`UPDATE races SET update_time = strftime('%Y-%m-%dT%H:%M:%SZ', 'now') WHERE update_time IS NULL`)
+
+		return err
+	},
+	func(r *racesRepo) error {
+		if err := r.addColumn("races", "state", "TEXT"); err != nil {
+			return err
+		}
+
+		// Races from before there was a lifecycle are taken to have opened, and to have closed once they jumped.
+		if _, err := r.db.Exec(`UPDATE races SET state = CASE
+			WHEN advertised_start_time <= strftime('%Y-%m-%dT%H:%M:%SZ', 'now') THEN 'CLOSED'
+			ELSE 'OPEN'
+		END WHERE state IS NULL`); err != nil {
+			return err
+		}
+
+		_, err := r.db.Exec(`CREATE TABLE IF NOT EXISTS race_transitions (
+			id INTEGER PRIMARY KEY,
+			race_id INTEGER NOT NULL,
+			from_state TEXT NOT NULL,
+			to_state TEXT NOT NULL,
+			actor TEXT NOT NULL,
+			reason TEXT NOT NULL,
+			time DATETIME NOT NULL
+		)`)
+		if err != nil {
+			return err
+		}
+
+		_, err = r.db.Exec(`CREATE INDEX IF NOT EXISTS race_transitions_race_id ON race_transitions (race_id)`)
+
+		return err
+	},
+	func(r *racesRepo) error {
+		// Each version of a result is kept, along with its placings, so amendments never lose what they replaced.
+		_, err := r.db.Exec(`CREATE TABLE IF NOT EXISTS results (
+			race_id INTEGER NOT NULL,
+			version INTEGER NOT NULL,
+			final INTEGER NOT NULL,
+			protest TEXT NOT NULL,
+			actor TEXT NOT NULL,
+			reason TEXT NOT NULL,
+			submit_time DATETIME NOT NULL,
+			PRIMARY KEY (race_id, version)
+		)`)
+		if err != nil {
+			return err
+		}
+
+		_, err = r.db.Exec(`CREATE TABLE IF NOT EXISTS placings (
+			race_id INTEGER NOT NULL,
+			version INTEGER NOT NULL,
+			position INTEGER NOT NULL,
+			runner_number INTEGER NOT NULL,
+			margin REAL NOT NULL,
+			dead_heat INTEGER NOT NULL,
+			PRIMARY KEY (race_id, version, runner_number)
+		)`)
+
+		return err
+	},
+	func(r *racesRepo) error {
+		// Prices are the current prices of runners, and price_history every price they have had, including those.
+		_, err := r.db.Exec(`CREATE TABLE IF NOT EXISTS prices (
+			race_id INTEGER NOT NULL,
+			runner_number INTEGER NOT NULL,
+			win REAL NOT NULL,
+			place REAL NOT NULL,
+			update_time DATETIME NOT NULL,
+			PRIMARY KEY (race_id, runner_number)
+		)`)
+		if err != nil {
+			return err
+		}
+
+		_, err = r.db.Exec(`CREATE TABLE IF NOT EXISTS price_history (
+			id INTEGER PRIMARY KEY,
+			race_id INTEGER NOT NULL,
+			runner_number INTEGER NOT NULL,
+			win REAL NOT NULL,
+			place REAL NOT NULL,
+			time DATETIME NOT NULL
+		)`)
+		if err != nil {
+			return err
+		}
+
+		_, err = r.db.Exec(`CREATE INDEX IF NOT EXISTS price_history_race_id ON price_history (race_id, runner_number)`)
+
+		return err
+	},
+	func(r *racesRepo) error {
+		// Each runner can only be scratched once, and the prices it had are kept for the record.
+		_, err := r.db.Exec(`CREATE TABLE IF NOT EXISTS scratchings (
+			race_id INTEGER NOT NULL,
+			runner_number INTEGER NOT NULL,
+			actor TEXT NOT NULL,
+			reason TEXT NOT NULL,
+			scratch_time DATETIME NOT NULL,
+			price REAL NOT NULL,
+			win_deduction REAL NOT NULL,
+			place_deduction REAL NOT NULL,
+			PRIMARY KEY (race_id, runner_number)
+		)`)
+
+		return err
+	},
+	func(r *racesRepo) error {
+		// Suspensions are kept once lifted, as the record of who suspended and resumed trading and why.
+		_, err := r.db.Exec(`CREATE TABLE IF NOT EXISTS suspensions (
+			id INTEGER PRIMARY KEY,
+			scope TEXT NOT NULL,
+			race_id INTEGER NOT NULL,
+			meeting_id INTEGER NOT NULL,
+			actor TEXT NOT NULL,
+			reason TEXT NOT NULL,
+			suspend_time DATETIME NOT NULL,
+			resume_time DATETIME,
+			lift_time DATETIME,
+			lift_actor TEXT NOT NULL DEFAULT '',
+			lift_reason TEXT NOT NULL DEFAULT ''
+		)`)
+		if err != nil {
+			return err
+		}
+
+		_, err = r.db.Exec(`CREATE INDEX IF NOT EXISTS suspensions_lift_time ON suspensions (lift_time)`)
+
+		return err
+	},
+}
+
+// migrate applies every migration in order.
+func (r *racesRepo) migrate() error {
+	for i, migration := range migrations {
+		if err := migration(r); err != nil {
+			return fmt.Errorf("applying migration %d: %w", i+1, err)
+		}
+	}
+
+	return nil
+}
+
+// addColumn adds a column to a table, unless the table already has it.
+func (r *racesRepo) addColumn(table, column, definition string) error {
+	rows, err := r.db.Query(`SELECT name FROM pragma_table_info(?)`, table)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return err
+		}
+
+		if name == column {
+			return nil
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	_, err = r.db.Exec(fmt.Sprintf(`ALTER TABLE %s ADD COLUMN %s %s`, table, column, definition))
+
+	return err
+}
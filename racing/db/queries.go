@@ -1,9 +1,55 @@
 package db
 
 const (
-	racesList = "list"
+	racesList   = "list"
+	racesGet    = "get"
+	racesInsert = "insert"
+	racesUpdate = "update"
+	racesDelete = "delete"
+	racesState  = "state"
+	racesUpsert = "upsert"
+	racesSearch = "search"
+
+	racesTransition     = "transition"
+	transitionsInsert   = "transitions_insert"
+	transitionsList     = "transitions_list"
+	transitionsDeleteOf = "transitions_delete_of"
+
+	runnersList     = "runners_list"
+	runnersInsert   = "runners_insert"
+	runnersDeleteOf = "runners_delete_of"
+
+	resultsVersion   = "results_version"
+	resultsList      = "results_list"
+	resultsInsert    = "results_insert"
+	resultsDeleteOf  = "results_delete_of"
+	placingsList     = "placings_list"
+	placingsInsert   = "placings_insert"
+	placingsDeleteOf = "placings_delete_of"
+
+	pricesList           = "prices_list"
+	pricesUpsert         = "prices_upsert"
+	pricesDeleteOf       = "prices_delete_of"
+	priceHistoryList     = "price_history_list"
+	priceHistoryInsert   = "price_history_insert"
+	priceHistoryDeleteOf = "price_history_delete_of"
+	pricesWithdraw       = "prices_withdraw"
+
+	racesTouch          = "touch"
+	scratchingsList     = "scratchings_list"
+	scratchingsInsert   = "scratchings_insert"
+	scratchingsDeleteOf = "scratchings_delete_of"
+
+	suspensionsList     = "suspensions_list"
+	suspensionsGet      = "suspensions_get"
+	suspensionsInsert   = "suspensions_insert"
+	suspensionsLift     = "suspensions_lift"
+	suspensionsDeleteOf = "suspensions_delete_of"
 )
 
+// suspensionColumns are the columns of a suspension, in the order scanSuspensions reads them.
+const suspensionColumns = `id, scope, race_id, meeting_id, actor, reason, suspend_time, resume_time, lift_time, lift_actor, lift_reason`
+
 func getRaceQueries() map[string]string {
 	return map[string]string{
 		racesList: `
@@ -13,8 +59,176 @@ func getRaceQueries() map[string]string {
 				name, 
 				number, 
 				visible, 
-				advertised_start_time 
+				advertised_start_time,
+				update_time,
+				state
 			FROM races
 		`,
+		racesGet: `
+			SELECT
+				id,
+				meeting_id,
+				name,
+				number,
+				visible,
+				advertised_start_time,
+				update_time,
+				state
+			FROM races
+			WHERE id = ?
+		`,
+		racesSearch: `
+			SELECT
+				races.id,
+				races.meeting_id,
+				races.name,
+				races.number,
+				races.visible,
+				races.advertised_start_time,
+				races.update_time,
+				races.state,
+				highlight(races_fts, 2, '<mark>', '</mark>') || ' ' ||
+					highlight(races_fts, 1, '<mark>', '</mark>') || ': ' ||
+					highlight(races_fts, 0, '<mark>', '</mark>'),
+				-bm25(races_fts, 10.0, 5.0, 2.0)
+			FROM races_fts
+			JOIN races ON races.id = races_fts.rowid
+			WHERE races_fts MATCH ?
+			ORDER BY bm25(races_fts, 10.0, 5.0, 2.0)
+			LIMIT ?
+		`,
+		racesInsert: `
+			INSERT INTO races(id, meeting_id, name, number, visible, advertised_start_time, update_time, state)
+			VALUES (?,?,?,?,?,?,?,'SCHEDULED')
+		`,
+		racesUpdate: `
+			UPDATE races SET
+				meeting_id = ?,
+				name = ?,
+				number = ?,
+				visible = ?,
+				advertised_start_time = ?,
+				update_time = ?
+			WHERE id = ?
+		`,
+		racesDelete: `DELETE FROM races WHERE id = ?`,
+		racesState:  `SELECT state FROM races WHERE id = ?`,
+		racesUpsert: `
+			INSERT INTO races(id, meeting_id, name, number, visible, advertised_start_time, update_time, state)
+			VALUES (?,?,?,?,?,?,?,'SCHEDULED')
+			ON CONFLICT(id) DO UPDATE SET
+				meeting_id = excluded.meeting_id,
+				name = excluded.name,
+				number = excluded.number,
+				visible = excluded.visible,
+				advertised_start_time = excluded.advertised_start_time,
+				update_time = excluded.update_time
+		`,
+		// The race only moves if it is still in the state the move was checked against.
+		racesTransition: `UPDATE races SET state = ?, update_time = ? WHERE id = ? AND state = ?`,
+		transitionsInsert: `
+			INSERT INTO race_transitions(race_id, from_state, to_state, actor, reason, time)
+			VALUES (?,?,?,?,?,?)
+		`,
+		transitionsList: `
+			SELECT id, race_id, from_state, to_state, actor, reason, time
+			FROM race_transitions
+			WHERE race_id = ?
+			ORDER BY id
+		`,
+		transitionsDeleteOf: `DELETE FROM race_transitions WHERE race_id = ?`,
+		runnersList: `
+			SELECT number, name, barrier
+			FROM runners
+			WHERE race_id = ?
+			ORDER BY number
+		`,
+		runnersInsert:   `INSERT INTO runners(race_id, number, name, barrier) VALUES (?,?,?,?)`,
+		runnersDeleteOf: `DELETE FROM runners WHERE race_id = ?`,
+		resultsVersion:  `SELECT COALESCE(MAX(version), 0) FROM results WHERE race_id = ?`,
+		// The latest version comes first, and the rest only when the history is asked for.
+		resultsList: `
+			SELECT race_id, version, final, protest, actor, reason, submit_time
+			FROM results
+			WHERE race_id = ?
+			ORDER BY version DESC
+			LIMIT ?
+		`,
+		resultsInsert: `
+			INSERT INTO results(race_id, version, final, protest, actor, reason, submit_time)
+			VALUES (?,?,?,?,?,?,?)
+		`,
+		resultsDeleteOf: `DELETE FROM results WHERE race_id = ?`,
+		placingsList: `
+			SELECT version, position, runner_number, margin, dead_heat
+			FROM placings
+			WHERE race_id = ? AND version >= ?
+			ORDER BY version, rowid
+		`,
+		placingsInsert: `
+			INSERT INTO placings(race_id, version, position, runner_number, margin, dead_heat)
+			VALUES (?,?,?,?,?,?)
+		`,
+		placingsDeleteOf: `DELETE FROM placings WHERE race_id = ?`,
+		pricesList: `
+			SELECT runner_number, win, place, update_time
+			FROM prices
+			WHERE race_id = ?
+			ORDER BY runner_number
+		`,
+		pricesUpsert: `
+			INSERT INTO prices(race_id, runner_number, win, place, update_time)
+			VALUES (?,?,?,?,?)
+			ON CONFLICT(race_id, runner_number) DO UPDATE SET
+				win = excluded.win,
+				place = excluded.place,
+				update_time = excluded.update_time
+		`,
+		pricesDeleteOf: `DELETE FROM prices WHERE race_id = ?`,
+		priceHistoryList: `
+			SELECT runner_number, win, place, time
+			FROM price_history
+			WHERE race_id = ?
+			ORDER BY runner_number, id
+		`,
+		priceHistoryInsert: `
+			INSERT INTO price_history(race_id, runner_number, win, place, time)
+			VALUES (?,?,?,?,?)
+		`,
+		priceHistoryDeleteOf: `DELETE FROM price_history WHERE race_id = ?`,
+		pricesWithdraw: `
+			UPDATE prices SET win = 0, place = 0, update_time = ?
+			WHERE race_id = ? AND runner_number = ? AND (win != 0 OR place != 0)
+		`,
+		racesTouch: `UPDATE races SET update_time = ? WHERE id = ?`,
+		scratchingsList: `
+			SELECT race_id, runner_number, actor, reason, scratch_time, price, win_deduction, place_deduction
+			FROM scratchings
+			WHERE race_id = ?
+			ORDER BY scratch_time, rowid
+		`,
+		scratchingsInsert: `
+			INSERT INTO scratchings(race_id, runner_number, actor, reason, scratch_time, price, win_deduction, place_deduction)
+			VALUES (?,?,?,?,?,?,?,?)
+		`,
+		scratchingsDeleteOf: `DELETE FROM scratchings WHERE race_id = ?`,
+		// Only the suspensions not yet lifted are listed when asked for, as they are the only ones that can be in force.
+		suspensionsList: `
+			SELECT ` + suspensionColumns + `
+			FROM suspensions
+			WHERE lift_time IS NULL OR ? = 0
+			ORDER BY id
+		`,
+		suspensionsGet: `SELECT ` + suspensionColumns + ` FROM suspensions WHERE id = ?`,
+		suspensionsInsert: `
+			INSERT INTO suspensions(scope, race_id, meeting_id, actor, reason, suspend_time, resume_time)
+			VALUES (?,?,?,?,?,?,?)
+		`,
+		// A suspension is only lifted once.
+		suspensionsLift: `
+			UPDATE suspensions SET lift_time = ?, lift_actor = ?, lift_reason = ?
+			WHERE id = ? AND lift_time IS NULL
+		`,
+		suspensionsDeleteOf: `DELETE FROM suspensions WHERE scope = 'RACE' AND race_id = ?`,
 	}
 }
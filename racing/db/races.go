@@ -2,12 +2,16 @@ package db
 
 import (
 	"database/sql"
+	"github.com/golang/protobuf/proto"
 	"github.com/golang/protobuf/ptypes"
 	_ "github.com/mattn/go-sqlite3"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
 
+	"git.neds.sh/matty/entain/common/errs"
+	"git.neds.sh/matty/entain/racing/filtering"
 	"git.neds.sh/matty/entain/racing/proto/racing"
 )
 
@@ -16,13 +20,91 @@ type RacesRepo interface {
 	// Init will initialise our races repository.
 	Init() error
 
-	// List will return a list of races.
-	List(filter *racing.ListRacesRequestFilter) ([]*racing.Race, error)
+	// List will return a page of races matching both the filter and the parsed filter expression, either of which may be nil.
+	// An expression that does not type check against the race fields is reported as a *filtering.Error.
+	List(filter *racing.ListRacesRequestFilter, expr filtering.Expr, page Page) ([]*racing.Race, error)
+
+	// Get will return the race with the given ID, along with its scratchings, or an *errs.NotFound error.
+	Get(id int64) (*racing.Race, error)
+
+	// Create will store a new race and its runners, assigning it the next free ID unless it has one, which
+	// must not be taken.
+	Create(race *racing.Race) (*racing.Race, error)
+
+	// Update will replace the fields and runners of an existing race, or return an *errs.NotFound error.
+	Update(race *racing.Race) (*racing.Race, error)
+
+	// Delete will delete a race, returning it as it was, or an *errs.NotFound error.
+	Delete(id int64) (*racing.Race, error)
+
+	// Search will return up to limit races matching a free text query, best match first, or an
+	// *errs.Unimplemented error if racing was built without FTS5.
+	Search(query string, limit int) ([]*racing.RaceSearchResult, error)
+
+	// Transition will move a race from one state to another, recording who moved it and why. It returns an
+	// *errs.Conflict error if the race is no longer in the from state, and does not check that the move
+	// is allowed.
+	Transition(id int64, from, to racing.Race_State, actor, reason string) (*racing.Race, *racing.RaceTransition, error)
+
+	// ListTransitions will return every state change of a race, oldest first, or an *errs.NotFound error.
+	ListTransitions(raceID int64) ([]*racing.RaceTransition, error)
+
+	// Runners will return the runners of a race, in order of their numbers.
+	Runners(raceID int64) ([]*racing.Runner, error)
+
+	// Result will return the current result of a race, or nil if it has none, along with every earlier
+	// version of it, oldest first, if history is set.
+	Result(raceID int64, history bool) (*racing.Result, []*racing.Result, error)
+
+	// SaveResult will store a new version of a race's result, and move the race through the given states
+	// on behalf of the result's actor. It returns an *errs.Conflict error if the race is no longer in the
+	// from state, or its result is no longer at the given version, and does not otherwise check either.
+	SaveResult(result *racing.Result, version int64, from racing.Race_State, moves []racing.Race_State) (*racing.Result, []*racing.RaceTransition, error)
+
+	// Scratch will record the scratching of a runner, taking down any prices it has, and return the race
+	// after it. It returns an *errs.FailedPrecondition error if the runner is already scratched, and does
+	// not otherwise check the scratching.
+	Scratch(scratching *racing.Scratching) (*racing.Race, *racing.Scratching, error)
+
+	// Import will create or update the given races by ID, all within a single transaction.
+	// When dryRun is set the transaction is rolled back, reporting what would have changed.
+	Import(races []*racing.Race, dryRun bool) (ImportResult, error)
+}
+
+// Page selects a page of races, in order of their IDs.
+type Page struct {
+	// AfterID skips the races up to and including this ID.
+	AfterID int64
+	// Size is the most races to return, or zero for all of them.
+	Size int
+}
+
+// ImportResult summarises the changes made by an import.
+type ImportResult struct {
+	Created int64
+	Updated int64
+	// Events describe each race that was created or updated, as it was stored.
+	Events []*racing.RaceEvent
+}
+
+// raceFields are the fields of a race that can be used in a filter expression.
+var raceFields = filtering.Schema{
+	"id":                    {Column: "id", Type: filtering.Int},
+	"meeting_id":            {Column: "meeting_id", Type: filtering.Int},
+	"name":                  {Column: "name", Type: filtering.Text},
+	"number":                {Column: "number", Type: filtering.Int},
+	"visible":               {Column: "visible", Type: filtering.Bool},
+	"advertised_start_time": {Column: "advertised_start_time", Type: filtering.Timestamp},
+	"update_time":           {Column: "update_time", Type: filtering.Timestamp},
+	"state":                 {Column: "state", Type: filtering.Text},
 }
 
 type racesRepo struct {
 	db   *sql.DB
 	init sync.Once
+
+	// searchable is whether SQLite has FTS5, and so the search index, as found by Init.
+	searchable bool
 }
 
 // NewRacesRepo creates a new races repository.
@@ -35,14 +117,26 @@ func (r *racesRepo) Init() error {
 	var err error
 
 	r.init.Do(func() {
+		if err = r.checkSearch(); err != nil {
+			return
+		}
+
 		// For test/example purposes, we seed the DB with some dummy races.
-		err = r.seed()
+		if err = r.seed(); err != nil {
+			return
+		}
+
+		if err = r.migrate(); err != nil {
+			return
+		}
+
+		err = r.index()
 	})
 
 	return err
 }
 
-func (r *racesRepo) List(filter *racing.ListRacesRequestFilter) ([]*racing.Race, error) {
+func (r *racesRepo) List(filter *racing.ListRacesRequestFilter, expr filtering.Expr, page Page) ([]*racing.Race, error) {
 	var (
 		err   error
 		query string
@@ -51,27 +145,257 @@ func (r *racesRepo) List(filter *racing.ListRacesRequestFilter) ([]*racing.Race,
 
 	query = getRaceQueries()[racesList]
 
-	query, args = r.applyFilter(query, filter)
+	query, args, err = r.applyFilter(query, filter, expr, page)
+	if err != nil {
+		return nil, err
+	}
 
 	rows, err := r.db.Query(query, args...)
 	if err != nil {
-		return nil, err
+		return nil, wrapError(err)
 	}
 
 	return r.scanRaces(rows)
 }
 
-func (r *racesRepo) applyFilter(query string, filter *racing.ListRacesRequestFilter) (string, []interface{}) {
+func (r *racesRepo) Get(id int64) (*racing.Race, error) {
+	rows, err := r.db.Query(getRaceQueries()[racesGet], id)
+	if err != nil {
+		return nil, wrapError(err)
+	}
+
+	races, err := r.scanRaces(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(races) == 0 {
+		return nil, &errs.NotFound{Resource: "race", ID: strconv.FormatInt(id, 10)}
+	}
+
+	if races[0].Scratchings, err = scratchings(r.db, id); err != nil {
+		return nil, wrapError(err)
+	}
+
+	return races[0], nil
+}
+
+func (r *racesRepo) Create(race *racing.Race) (*racing.Race, error) {
+	var id interface{}
+	if race.Id > 0 {
+		id = race.Id
+	}
+
+	created, err := r.write(race, func(tx *sql.Tx, advertisedStart, now string) (sql.Result, error) {
+		return tx.Exec(getRaceQueries()[racesInsert], id, race.MeetingId, race.Name, race.Number, race.Visible, advertisedStart, now)
+	})
+	if isConstraintError(err) {
+		return nil, &errs.Conflict{Resource: "race", ID: strconv.FormatInt(race.Id, 10), Reason: "a race with this ID already exists"}
+	}
+
+	if created != nil {
+		created.State = racing.Race_SCHEDULED
+	}
+
+	return created, wrapError(err)
+}
+
+func (r *racesRepo) Update(race *racing.Race) (*racing.Race, error) {
+	updated, err := r.write(race, func(tx *sql.Tx, advertisedStart, now string) (sql.Result, error) {
+		return tx.Exec(getRaceQueries()[racesUpdate], race.MeetingId, race.Name, race.Number, race.Visible, advertisedStart, now, race.Id)
+	})
+
+	return updated, wrapError(err)
+}
+
+// write stores a race and its runners with the given statement, which is passed its advertised start and
+// update times as stored, returning the race as it was stored.
+func (r *racesRepo) write(race *racing.Race, exec func(tx *sql.Tx, advertisedStart, now string) (sql.Result, error)) (*racing.Race, error) {
+	advertisedStart, err := ptypes.Timestamp(race.AdvertisedStartTime)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	now := time.Now().UTC().Truncate(time.Second)
+
+	result, err := exec(tx, advertisedStart.UTC().Format(time.RFC3339), now.Format(time.RFC3339))
+	if err != nil {
+		return nil, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+
+	if affected == 0 {
+		return nil, &errs.NotFound{Resource: "race", ID: strconv.FormatInt(race.Id, 10)}
+	}
+
+	stored := proto.Clone(race).(*racing.Race)
+	stored.Result = nil
+
+	if stored.Id == 0 {
+		if stored.Id, err = result.LastInsertId(); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := r.writeRunners(tx, stored.Id, stored.Runners); err != nil {
+		return nil, err
+	}
+
+	if stored.Scratchings, err = scratchings(tx, stored.Id); err != nil {
+		return nil, err
+	}
+
+	if stored.UpdateTime, err = ptypes.TimestampProto(now); err != nil {
+		return nil, err
+	}
+
+	return stored, tx.Commit()
+}
+
+func (r *racesRepo) Delete(id int64) (*racing.Race, error) {
+	race, err := r.Get(id)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, wrapError(err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.Exec(getRaceQueries()[racesDelete], id)
+	if err != nil {
+		return nil, wrapError(err)
+	}
+
+	// Someone else may have deleted the race since we read it, in which case it is theirs to report.
+	if affected, err := result.RowsAffected(); err != nil || affected == 0 {
+		return nil, &errs.NotFound{Resource: "race", ID: strconv.FormatInt(id, 10)}
+	}
+
+	// Race IDs may be reused, so a deleted race must not leave anything to the next race to get its ID.
+	for _, query := range []string{transitionsDeleteOf, runnersDeleteOf, resultsDeleteOf, placingsDeleteOf, pricesDeleteOf, priceHistoryDeleteOf, scratchingsDeleteOf, suspensionsDeleteOf} {
+		if _, err := tx.Exec(getRaceQueries()[query], id); err != nil {
+			return nil, wrapError(err)
+		}
+	}
+
+	return race, wrapError(tx.Commit())
+}
+
+func (r *racesRepo) Import(races []*racing.Race, dryRun bool) (ImportResult, error) {
+	result, err := r.importRaces(races, dryRun)
+
+	return result, wrapError(err)
+}
+
+func (r *racesRepo) importRaces(races []*racing.Race, dryRun bool) (ImportResult, error) {
+	var result ImportResult
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return result, err
+	}
+
+	// Rolling back after a commit is a no-op, so this covers both errors and dry runs.
+	defer tx.Rollback()
+
+	queries := getRaceQueries()
+
+	state, err := tx.Prepare(queries[racesState])
+	if err != nil {
+		return result, err
+	}
+	defer state.Close()
+
+	upsert, err := tx.Prepare(queries[racesUpsert])
+	if err != nil {
+		return result, err
+	}
+	defer upsert.Close()
+
+	now := time.Now().UTC().Truncate(time.Second)
+
+	updateTime, err := ptypes.TimestampProto(now)
+	if err != nil {
+		return result, err
+	}
+
+	for _, race := range races {
+		advertisedStart, err := ptypes.Timestamp(race.AdvertisedStartTime)
+		if err != nil {
+			return result, err
+		}
+
+		// Imports leave the state of existing races alone, and create new ones scheduled.
+		var current string
+		if err := state.QueryRow(race.Id).Scan(&current); err != nil && err != sql.ErrNoRows {
+			return result, err
+		}
+
+		if _, err := upsert.Exec(
+			race.Id,
+			race.MeetingId,
+			race.Name,
+			race.Number,
+			race.Visible,
+			advertisedStart.UTC().Format(time.RFC3339),
+			now.Format(time.RFC3339),
+		); err != nil {
+			return result, err
+		}
+
+		event := &racing.RaceEvent{Type: racing.RaceEvent_CREATED, Race: proto.Clone(race).(*racing.Race)}
+		event.Race.UpdateTime = updateTime
+		event.Race.Scratchings = nil
+
+		if current != "" {
+			event.Type = racing.RaceEvent_UPDATED
+			event.Race.State = parseState(current)
+			result.Updated++
+		} else {
+			event.Race.State = racing.Race_SCHEDULED
+			result.Created++
+		}
+
+		result.Events = append(result.Events, event)
+	}
+
+	if dryRun {
+		return result, nil
+	}
+
+	return result, tx.Commit()
+}
+
+func (r *racesRepo) applyFilter(query string, filter *racing.ListRacesRequestFilter, expr filtering.Expr, page Page) (string, []interface{}, error) {
 	var (
 		clauses []string
 		args    []interface{}
 	)
 
-	if filter == nil {
-		return query, args
+	if expr != nil {
+		clause, exprArgs, err := filtering.Compile(expr, raceFields)
+		if err != nil {
+			return "", nil, err
+		}
+
+		clauses = append(clauses, clause)
+		args = append(args, exprArgs...)
 	}
 
-	if len(filter.MeetingIds) > 0 {
+	if filter != nil && len(filter.MeetingIds) > 0 {
 		clauses = append(clauses, "meeting_id IN ("+strings.Repeat("?,", len(filter.MeetingIds)-1)+"?)")
 
 		for _, meetingID := range filter.MeetingIds {
@@ -79,11 +403,31 @@ func (r *racesRepo) applyFilter(query string, filter *racing.ListRacesRequestFil
 		}
 	}
 
+	if filter != nil && len(filter.States) > 0 {
+		clauses = append(clauses, "state IN ("+strings.Repeat("?,", len(filter.States)-1)+"?)")
+
+		for _, state := range filter.States {
+			args = append(args, state.String())
+		}
+	}
+
+	if page.AfterID > 0 {
+		clauses = append(clauses, "id > ?")
+		args = append(args, page.AfterID)
+	}
+
 	if len(clauses) != 0 {
 		query += " WHERE " + strings.Join(clauses, " AND ")
 	}
 
-	return query, args
+	query += " ORDER BY id"
+
+	if page.Size > 0 {
+		query += " LIMIT ?"
+		args = append(args, page.Size)
+	}
+
+	return query, args, nil
 }
 
 func (m *racesRepo) scanRaces(
@@ -93,9 +437,10 @@ func (m *racesRepo) scanRaces(
 
 	for rows.Next() {
 		var race racing.Race
-		var advertisedStart time.Time
+		var advertisedStart, updated time.Time
+		var state string
 
-		if err := rows.Scan(&race.Id, &race.MeetingId, &race.Name, &race.Number, &race.Visible, &advertisedStart); err != nil {
+		if err := rows.Scan(&race.Id, &race.MeetingId, &race.Name, &race.Number, &race.Visible, &advertisedStart, &updated, &state); err != nil {
 			if err == sql.ErrNoRows {
 				return nil, nil
 			}
@@ -110,6 +455,12 @@ func (m *racesRepo) scanRaces(
 
 		race.AdvertisedStartTime = ts
 
+		if race.UpdateTime, err = ptypes.TimestampProto(updated); err != nil {
+			return nil, err
+		}
+
+		race.State = parseState(state)
+
 		races = append(races, &race)
 	}
 
@@ -0,0 +1,222 @@
+package db
+
+import (
+	"database/sql"
+	"strconv"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+
+	"git.neds.sh/matty/entain/common/errs"
+	"git.neds.sh/matty/entain/racing/proto/racing"
+)
+
+func (r *racesRepo) Runners(raceID int64) ([]*racing.Runner, error) {
+	rows, err := r.db.Query(getRaceQueries()[runnersList], raceID)
+	if err != nil {
+		return nil, wrapError(err)
+	}
+	defer rows.Close()
+
+	var runners []*racing.Runner
+
+	for rows.Next() {
+		var runner racing.Runner
+		if err := rows.Scan(&runner.Number, &runner.Name, &runner.Barrier); err != nil {
+			return nil, err
+		}
+
+		runners = append(runners, &runner)
+	}
+
+	return runners, rows.Err()
+}
+
+// writeRunners replaces the runners of a race within a transaction.
+func (r *racesRepo) writeRunners(tx *sql.Tx, raceID int64, runners []*racing.Runner) error {
+	queries := getRaceQueries()
+
+	if _, err := tx.Exec(queries[runnersDeleteOf], raceID); err != nil {
+		return err
+	}
+
+	for _, runner := range runners {
+		if _, err := tx.Exec(queries[runnersInsert], raceID, runner.Number, runner.Name, runner.Barrier); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *racesRepo) Result(raceID int64, history bool) (*racing.Result, []*racing.Result, error) {
+	current, earlier, err := r.result(raceID, history)
+
+	return current, earlier, wrapError(err)
+}
+
+func (r *racesRepo) result(raceID int64, history bool) (*racing.Result, []*racing.Result, error) {
+	limit := 1
+	if history {
+		limit = -1
+	}
+
+	rows, err := r.db.Query(getRaceQueries()[resultsList], raceID, limit)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	versions, err := r.scanResults(rows)
+	if err != nil || len(versions) == 0 {
+		return nil, nil, err
+	}
+
+	// Versions are listed latest first, so the last is the oldest wanted. Placings keep the order they were
+	// submitted in, which matters for dead heats.
+	if err := r.addPlacings(raceID, versions); err != nil {
+		return nil, nil, err
+	}
+
+	earlier := make([]*racing.Result, 0, len(versions)-1)
+	for i := len(versions) - 1; i > 0; i-- {
+		earlier = append(earlier, versions[i])
+	}
+
+	return versions[0], earlier, nil
+}
+
+func (r *racesRepo) scanResults(rows *sql.Rows) ([]*racing.Result, error) {
+	defer rows.Close()
+
+	var versions []*racing.Result
+
+	for rows.Next() {
+		var (
+			result    racing.Result
+			protest   string
+			submitted time.Time
+		)
+
+		if err := rows.Scan(&result.RaceId, &result.Version, &result.Final, &protest, &result.Actor, &result.Reason, &submitted); err != nil {
+			return nil, err
+		}
+
+		result.Protest = racing.Result_Protest(racing.Result_Protest_value[protest])
+
+		var err error
+		if result.SubmitTime, err = ptypes.TimestampProto(submitted); err != nil {
+			return nil, err
+		}
+
+		versions = append(versions, &result)
+	}
+
+	return versions, rows.Err()
+}
+
+// addPlacings fills in the placings of the given versions of a race's result, which are latest first.
+func (r *racesRepo) addPlacings(raceID int64, versions []*racing.Result) error {
+	byVersion := make(map[int64]*racing.Result, len(versions))
+	for _, result := range versions {
+		byVersion[result.Version] = result
+	}
+
+	rows, err := r.db.Query(getRaceQueries()[placingsList], raceID, versions[len(versions)-1].Version)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			version int64
+			placing racing.Placing
+		)
+
+		if err := rows.Scan(&version, &placing.Position, &placing.RunnerNumber, &placing.Margin, &placing.DeadHeat); err != nil {
+			return err
+		}
+
+		if result, ok := byVersion[version]; ok {
+			result.Placings = append(result.Placings, &placing)
+		}
+	}
+
+	return rows.Err()
+}
+
+func (r *racesRepo) SaveResult(result *racing.Result, version int64, from racing.Race_State, moves []racing.Race_State) (*racing.Result, []*racing.RaceTransition, error) {
+	saved, transitions, err := r.saveResult(result, version, from, moves)
+
+	return saved, transitions, wrapError(err)
+}
+
+func (r *racesRepo) saveResult(result *racing.Result, version int64, from racing.Race_State, moves []racing.Race_State) (*racing.Result, []*racing.RaceTransition, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, nil, err
+	}
+	defer tx.Rollback()
+
+	queries := getRaceQueries()
+	id := strconv.FormatInt(result.RaceId, 10)
+
+	var state string
+	if err := tx.QueryRow(queries[racesState], result.RaceId).Scan(&state); err == sql.ErrNoRows {
+		return nil, nil, &errs.NotFound{Resource: "race", ID: id}
+	} else if err != nil {
+		return nil, nil, err
+	}
+
+	if state != from.String() {
+		return nil, nil, &errs.Conflict{Resource: "race", ID: id, Reason: "the race is no longer " + from.String()}
+	}
+
+	var latest int64
+	if err := tx.QueryRow(queries[resultsVersion], result.RaceId).Scan(&latest); err != nil {
+		return nil, nil, err
+	}
+
+	if latest != version {
+		return nil, nil, &errs.Conflict{Resource: "race", ID: id, Reason: "the result has been amended since it was read"}
+	}
+
+	now := time.Now().UTC().Truncate(time.Second)
+
+	saved := proto.Clone(result).(*racing.Result)
+	saved.Version = latest + 1
+
+	if saved.SubmitTime, err = ptypes.TimestampProto(now); err != nil {
+		return nil, nil, err
+	}
+
+	if _, err := tx.Exec(queries[resultsInsert], saved.RaceId, saved.Version, saved.Final, saved.Protest.String(), saved.Actor, saved.Reason, now.Format(time.RFC3339)); err != nil {
+		return nil, nil, err
+	}
+
+	for _, placing := range saved.Placings {
+		if _, err := tx.Exec(queries[placingsInsert], saved.RaceId, saved.Version, placing.Position, placing.RunnerNumber, placing.Margin, placing.DeadHeat); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	var transitions []*racing.RaceTransition
+
+	for _, to := range moves {
+		reason := "interim result"
+		if to == racing.Race_FINAL {
+			reason = "final result"
+		}
+
+		transition, err := r.transitionTx(tx, saved.RaceId, from, to, saved.Actor, reason, now)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		transitions = append(transitions, transition)
+		from = to
+	}
+
+	return saved, transitions, tx.Commit()
+}
@@ -0,0 +1,121 @@
+package db
+
+import (
+	"database/sql"
+	"strconv"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+
+	"git.neds.sh/matty/entain/common/errs"
+	"git.neds.sh/matty/entain/racing/proto/racing"
+)
+
+// querier runs queries, whether within a transaction or not.
+type querier interface {
+	Query(query string, args ...interface{}) (*sql.Rows, error)
+}
+
+func (r *racesRepo) Scratch(scratching *racing.Scratching) (*racing.Race, *racing.Scratching, error) {
+	race, scratched, err := r.scratch(scratching)
+
+	return race, scratched, wrapError(err)
+}
+
+func (r *racesRepo) scratch(scratching *racing.Scratching) (*racing.Race, *racing.Scratching, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, nil, err
+	}
+	defer tx.Rollback()
+
+	queries := getRaceQueries()
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	at := now.Format(time.RFC3339Nano)
+
+	_, err = tx.Exec(queries[scratchingsInsert], scratching.RaceId, scratching.RunnerNumber, scratching.Actor, scratching.Reason, at,
+		scratching.Price, scratching.WinDeduction, scratching.PlaceDeduction)
+	if isConstraintError(err) {
+		return nil, nil, &errs.FailedPrecondition{
+			Resource: "race",
+			ID:       strconv.FormatInt(scratching.RaceId, 10),
+			Reason:   "runner " + strconv.FormatInt(scratching.RunnerNumber, 10) + " is already scratched",
+		}
+	}
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Prices are only taken down if there are any, so that a runner that was never priced stays that way.
+	result, err := tx.Exec(queries[pricesWithdraw], at, scratching.RaceId, scratching.RunnerNumber)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if affected, err := result.RowsAffected(); err != nil {
+		return nil, nil, err
+	} else if affected > 0 {
+		if _, err := tx.Exec(queries[priceHistoryInsert], scratching.RaceId, scratching.RunnerNumber, 0, 0, at); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	result, err = tx.Exec(queries[racesTouch], now.Truncate(time.Second).Format(time.RFC3339), scratching.RaceId)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if affected, err := result.RowsAffected(); err != nil || affected == 0 {
+		return nil, nil, &errs.NotFound{Resource: "race", ID: strconv.FormatInt(scratching.RaceId, 10)}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, nil, err
+	}
+
+	scratched := proto.Clone(scratching).(*racing.Scratching)
+	if scratched.ScratchTime, err = ptypes.TimestampProto(now); err != nil {
+		return nil, nil, err
+	}
+
+	race, err := r.Get(scratching.RaceId)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return race, scratched, nil
+}
+
+// scratchings returns the scratchings of a race, in the order they were made.
+func scratchings(q querier, raceID int64) ([]*racing.Scratching, error) {
+	rows, err := q.Query(getRaceQueries()[scratchingsList], raceID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var scratchings []*racing.Scratching
+
+	for rows.Next() {
+		var (
+			scratching racing.Scratching
+			at         time.Time
+		)
+
+		if err := rows.Scan(&scratching.RaceId, &scratching.RunnerNumber, &scratching.Actor, &scratching.Reason, &at,
+			&scratching.Price, &scratching.WinDeduction, &scratching.PlaceDeduction); err != nil {
+			return nil, err
+		}
+
+		var err error
+		if scratching.ScratchTime, err = ptypes.TimestampProto(at); err != nil {
+			return nil, err
+		}
+
+		scratchings = append(scratchings, &scratching)
+	}
+
+	return scratchings, rows.Err()
+}
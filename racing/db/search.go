@@ -0,0 +1,189 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/golang/protobuf/ptypes"
+
+	"git.neds.sh/matty/entain/common/errs"
+	"git.neds.sh/matty/entain/racing/proto/racing"
+)
+
+// searchSchema creates the full text search index over races and their meetings, along with the triggers
+// that keep it in sync. The index's rowid is the race ID, and races are numbered R1, R2... as they are on
+// a race card, so that "Flemington R7" finds the seventh race at Flemington.
+//
+// Full text search requires SQLite's FTS5 extension, which racing is only built with given -tags sqlite_fts5.
+// Without it, everything else is served as usual, and searches are answered as unimplemented.
+var searchSchema = []string{
+	`CREATE VIRTUAL TABLE IF NOT EXISTS races_fts USING fts5(name, number, meeting_name, prefix='2 3')`,
+
+	`CREATE TRIGGER IF NOT EXISTS races_fts_insert AFTER INSERT ON races BEGIN
+		INSERT INTO races_fts(rowid, name, number, meeting_name)
+		VALUES (new.id, new.name, 'R' || new.number, COALESCE((SELECT name FROM meetings WHERE id = new.meeting_id), ''));
+	END`,
+
+	`CREATE TRIGGER IF NOT EXISTS races_fts_update AFTER UPDATE ON races BEGIN
+		DELETE FROM races_fts WHERE rowid = old.id;
+		INSERT INTO races_fts(rowid, name, number, meeting_name)
+		VALUES (new.id, new.name, 'R' || new.number, COALESCE((SELECT name FROM meetings WHERE id = new.meeting_id), ''));
+	END`,
+
+	`CREATE TRIGGER IF NOT EXISTS races_fts_delete AFTER DELETE ON races BEGIN
+		DELETE FROM races_fts WHERE rowid = old.id;
+	END`,
+
+	`CREATE TRIGGER IF NOT EXISTS meetings_fts_insert AFTER INSERT ON meetings BEGIN
+		UPDATE races_fts SET meeting_name = new.name WHERE rowid IN (SELECT id FROM races WHERE meeting_id = new.id);
+	END`,
+
+	`CREATE TRIGGER IF NOT EXISTS meetings_fts_update AFTER UPDATE OF name ON meetings BEGIN
+		UPDATE races_fts SET meeting_name = new.name WHERE rowid IN (SELECT id FROM races WHERE meeting_id = new.id);
+	END`,
+
+	`CREATE TRIGGER IF NOT EXISTS meetings_fts_delete AFTER DELETE ON meetings BEGIN
+		UPDATE races_fts SET meeting_name = '' WHERE rowid IN (SELECT id FROM races WHERE meeting_id = old.id);
+	END`,
+
+	// The index is rebuilt from scratch, as races may have been stored before it existed, or changed while
+	// a build without FTS5 was not keeping it in sync.
+	`DELETE FROM races_fts`,
+
+	`INSERT INTO races_fts(rowid, name, number, meeting_name)
+		SELECT races.id, races.name, 'R' || races.number, COALESCE(meetings.name, '')
+		FROM races LEFT JOIN meetings ON meetings.id = races.meeting_id`,
+}
+
+// searchTriggers are the triggers of searchSchema, which a build without FTS5 drops so that writes to
+// races and meetings do not fail trying to keep the index in sync.
+var searchTriggers = []string{
+	"races_fts_insert",
+	"races_fts_update",
+	"races_fts_delete",
+	"meetings_fts_insert",
+	"meetings_fts_update",
+	"meetings_fts_delete",
+}
+
+const (
+	defaultSearchLimit = 10
+	maxSearchLimit     = 100
+)
+
+// checkSearch finds whether SQLite has FTS5, and if it does not, drops any triggers left behind by a
+// build that had it, before anything is written to races or meetings.
+func (r *racesRepo) checkSearch() error {
+	if err := r.db.QueryRow(`SELECT sqlite_compileoption_used('ENABLE_FTS5')`).Scan(&r.searchable); err != nil {
+		return err
+	}
+
+	if r.searchable {
+		return nil
+	}
+
+	for _, trigger := range searchTriggers {
+		if _, err := r.db.Exec(`DROP TRIGGER IF EXISTS ` + trigger); err != nil {
+			return fmt.Errorf("dropping search trigger %s: %w", trigger, err)
+		}
+	}
+
+	log.Println("full text search is unavailable, as racing was built without -tags sqlite_fts5")
+
+	return nil
+}
+
+// index creates the full text search index, if SQLite has FTS5.
+func (r *racesRepo) index() error {
+	if !r.searchable {
+		return nil
+	}
+
+	for _, statement := range searchSchema {
+		if _, err := r.db.Exec(statement); err != nil {
+			return fmt.Errorf("creating search index: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (r *racesRepo) Search(query string, limit int) ([]*racing.RaceSearchResult, error) {
+	if !r.searchable {
+		return nil, &errs.Unimplemented{Reason: "full text search is not available, as the racing service was built without FTS5"}
+	}
+
+	match := searchMatch(query)
+	if match == "" {
+		return nil, nil
+	}
+
+	if limit <= 0 {
+		limit = defaultSearchLimit
+	}
+
+	if limit > maxSearchLimit {
+		limit = maxSearchLimit
+	}
+
+	rows, err := r.db.Query(getRaceQueries()[racesSearch], match, limit)
+	if err != nil {
+		return nil, wrapError(err)
+	}
+	defer rows.Close()
+
+	return r.scanSearchResults(rows)
+}
+
+// searchMatch turns free text into an FTS5 query, where every word must prefix a word in the index.
+// Anything other than letters and digits is dropped, so user input can never form FTS5 syntax.
+func searchMatch(query string) string {
+	words := strings.FieldsFunc(query, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	for i, word := range words {
+		words[i] = `"` + word + `"*`
+	}
+
+	return strings.Join(words, " ")
+}
+
+func (r *racesRepo) scanSearchResults(rows *sql.Rows) ([]*racing.RaceSearchResult, error) {
+	var results []*racing.RaceSearchResult
+
+	for rows.Next() {
+		var (
+			race            racing.Race
+			result          = racing.RaceSearchResult{Race: &race}
+			advertisedStart time.Time
+			updated         time.Time
+			state           string
+		)
+
+		if err := rows.Scan(&race.Id, &race.MeetingId, &race.Name, &race.Number, &race.Visible, &advertisedStart, &updated, &state, &result.Snippet, &result.Score); err != nil {
+			return nil, err
+		}
+
+		ts, err := ptypes.TimestampProto(advertisedStart)
+		if err != nil {
+			return nil, err
+		}
+
+		race.AdvertisedStartTime = ts
+
+		if race.UpdateTime, err = ptypes.TimestampProto(updated); err != nil {
+			return nil, err
+		}
+
+		race.State = parseState(state)
+
+		results = append(results, &result)
+	}
+
+	return results, rows.Err()
+}
@@ -0,0 +1,172 @@
+package db
+
+import (
+	"database/sql"
+	"strconv"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+
+	"git.neds.sh/matty/entain/common/errs"
+	"git.neds.sh/matty/entain/racing/proto/racing"
+)
+
+// SuspensionsRepo provides repository access to the suspensions of trading. Its tables are created by the
+// RacesRepo sharing its database, which must be initialised first.
+type SuspensionsRepo interface {
+	// List will return every suspension, oldest first, or only those not yet lifted if unlifted is set.
+	List(unlifted bool) ([]*racing.Suspension, error)
+
+	// Get will return the suspension with the given ID, or an *errs.NotFound error.
+	Get(id int64) (*racing.Suspension, error)
+
+	// Insert will store a new suspension, assigning it an ID.
+	Insert(suspension *racing.Suspension) (*racing.Suspension, error)
+
+	// Lift will record the lifting of a suspension at the given time, on behalf of an actor. It returns an
+	// *errs.Conflict error if the suspension has already been lifted.
+	Lift(id int64, actor, reason string, at time.Time) (*racing.Suspension, error)
+}
+
+type suspensionsRepo struct {
+	db *sql.DB
+}
+
+// NewSuspensionsRepo creates a new suspensions repository.
+func NewSuspensionsRepo(db *sql.DB) SuspensionsRepo {
+	return &suspensionsRepo{db: db}
+}
+
+func (s *suspensionsRepo) List(unlifted bool) ([]*racing.Suspension, error) {
+	rows, err := s.db.Query(getRaceQueries()[suspensionsList], unlifted)
+	if err != nil {
+		return nil, wrapError(err)
+	}
+
+	suspensions, err := scanSuspensions(rows)
+
+	return suspensions, wrapError(err)
+}
+
+func (s *suspensionsRepo) Get(id int64) (*racing.Suspension, error) {
+	rows, err := s.db.Query(getRaceQueries()[suspensionsGet], id)
+	if err != nil {
+		return nil, wrapError(err)
+	}
+
+	suspensions, err := scanSuspensions(rows)
+	if err != nil {
+		return nil, wrapError(err)
+	}
+
+	if len(suspensions) == 0 {
+		return nil, &errs.NotFound{Resource: "suspension", ID: strconv.FormatInt(id, 10)}
+	}
+
+	return suspensions[0], nil
+}
+
+func (s *suspensionsRepo) Insert(suspension *racing.Suspension) (*racing.Suspension, error) {
+	suspendTime, err := ptypes.Timestamp(suspension.SuspendTime)
+	if err != nil {
+		return nil, err
+	}
+
+	var resumeTime interface{}
+	if suspension.ResumeTime != nil {
+		resume, err := ptypes.Timestamp(suspension.ResumeTime)
+		if err != nil {
+			return nil, err
+		}
+
+		resumeTime = formatTime(resume)
+	}
+
+	result, err := s.db.Exec(getRaceQueries()[suspensionsInsert],
+		suspension.Scope.String(),
+		suspension.RaceId,
+		suspension.MeetingId,
+		suspension.Actor,
+		suspension.Reason,
+		formatTime(suspendTime),
+		resumeTime,
+	)
+	if err != nil {
+		return nil, wrapError(err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, wrapError(err)
+	}
+
+	return s.Get(id)
+}
+
+func (s *suspensionsRepo) Lift(id int64, actor, reason string, at time.Time) (*racing.Suspension, error) {
+	result, err := s.db.Exec(getRaceQueries()[suspensionsLift], formatTime(at), actor, reason, id)
+	if err != nil {
+		return nil, wrapError(err)
+	}
+
+	if affected, err := result.RowsAffected(); err != nil {
+		return nil, wrapError(err)
+	} else if affected == 0 {
+		if _, err := s.Get(id); err != nil {
+			return nil, err
+		}
+
+		return nil, &errs.Conflict{Resource: "suspension", ID: strconv.FormatInt(id, 10), Reason: "the suspension has already been lifted"}
+	}
+
+	return s.Get(id)
+}
+
+// formatTime returns a time as it is stored, to the millisecond.
+func formatTime(t time.Time) string {
+	return t.UTC().Truncate(time.Millisecond).Format(time.RFC3339Nano)
+}
+
+// scanSuspensions reads suspensions of suspensionColumns from rows, closing them.
+func scanSuspensions(rows *sql.Rows) ([]*racing.Suspension, error) {
+	defer rows.Close()
+
+	var suspensions []*racing.Suspension
+
+	for rows.Next() {
+		var (
+			suspension           racing.Suspension
+			scope                string
+			suspendTime          time.Time
+			resumeTime, liftTime sql.NullTime
+		)
+
+		if err := rows.Scan(&suspension.Id, &scope, &suspension.RaceId, &suspension.MeetingId, &suspension.Actor, &suspension.Reason,
+			&suspendTime, &resumeTime, &liftTime, &suspension.LiftActor, &suspension.LiftReason); err != nil {
+			return nil, err
+		}
+
+		suspension.Scope = racing.Suspension_Scope(racing.Suspension_Scope_value[scope])
+
+		var err error
+		if suspension.SuspendTime, err = ptypes.TimestampProto(suspendTime); err != nil {
+			return nil, err
+		}
+
+		if resumeTime.Valid {
+			if suspension.ResumeTime, err = ptypes.TimestampProto(resumeTime.Time); err != nil {
+				return nil, err
+			}
+		}
+
+		if liftTime.Valid {
+			if suspension.LiftTime, err = ptypes.TimestampProto(liftTime.Time); err != nil {
+				return nil, err
+			}
+		}
+
+		suspensions = append(suspensions, &suspension)
+	}
+
+	return suspensions, rows.Err()
+}
@@ -0,0 +1,126 @@
+package db
+
+import (
+	"database/sql"
+	"strconv"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+
+	"git.neds.sh/matty/entain/common/errs"
+	"git.neds.sh/matty/entain/racing/proto/racing"
+)
+
+func (r *racesRepo) Transition(id int64, from, to racing.Race_State, actor, reason string) (*racing.Race, *racing.RaceTransition, error) {
+	race, transition, err := r.transition(id, from, to, actor, reason)
+
+	return race, transition, wrapError(err)
+}
+
+func (r *racesRepo) transition(id int64, from, to racing.Race_State, actor, reason string) (*racing.Race, *racing.RaceTransition, error) {
+	race, err := r.Get(id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, nil, err
+	}
+	defer tx.Rollback()
+
+	transition, err := r.transitionTx(tx, id, from, to, actor, reason, time.Now().UTC().Truncate(time.Second))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, nil, err
+	}
+
+	race = proto.Clone(race).(*racing.Race)
+	race.State = to
+	race.UpdateTime = transition.Time
+
+	return race, transition, nil
+}
+
+// transitionTx moves a race from one state to another within a transaction, returning the record of the move.
+func (r *racesRepo) transitionTx(tx *sql.Tx, id int64, from, to racing.Race_State, actor, reason string, now time.Time) (*racing.RaceTransition, error) {
+	queries := getRaceQueries()
+
+	result, err := tx.Exec(queries[racesTransition], to.String(), now.Format(time.RFC3339), id, from.String())
+	if err != nil {
+		return nil, err
+	}
+
+	if affected, err := result.RowsAffected(); err != nil || affected == 0 {
+		return nil, &errs.Conflict{Resource: "race", ID: strconv.FormatInt(id, 10), Reason: "the race is no longer " + from.String()}
+	}
+
+	result, err = tx.Exec(queries[transitionsInsert], id, from.String(), to.String(), actor, reason, now.Format(time.RFC3339))
+	if err != nil {
+		return nil, err
+	}
+
+	transition := &racing.RaceTransition{RaceId: id, From: from, To: to, Actor: actor, Reason: reason}
+
+	if transition.Id, err = result.LastInsertId(); err != nil {
+		return nil, err
+	}
+
+	if transition.Time, err = ptypes.TimestampProto(now); err != nil {
+		return nil, err
+	}
+
+	return transition, nil
+}
+
+func (r *racesRepo) ListTransitions(raceID int64) ([]*racing.RaceTransition, error) {
+	// Races with no transitions are told apart from those that do not exist.
+	if _, err := r.Get(raceID); err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.Query(getRaceQueries()[transitionsList], raceID)
+	if err != nil {
+		return nil, wrapError(err)
+	}
+	defer rows.Close()
+
+	return r.scanTransitions(rows)
+}
+
+func (r *racesRepo) scanTransitions(rows *sql.Rows) ([]*racing.RaceTransition, error) {
+	var transitions []*racing.RaceTransition
+
+	for rows.Next() {
+		var (
+			transition racing.RaceTransition
+			from, to   string
+			at         time.Time
+		)
+
+		if err := rows.Scan(&transition.Id, &transition.RaceId, &from, &to, &transition.Actor, &transition.Reason, &at); err != nil {
+			return nil, err
+		}
+
+		transition.From = parseState(from)
+		transition.To = parseState(to)
+
+		var err error
+		if transition.Time, err = ptypes.TimestampProto(at); err != nil {
+			return nil, err
+		}
+
+		transitions = append(transitions, &transition)
+	}
+
+	return transitions, rows.Err()
+}
+
+// parseState returns the state stored under the given name, which is unspecified should it be unknown.
+func parseState(name string) racing.Race_State {
+	return racing.Race_State(racing.Race_State_value[name])
+}
@@ -0,0 +1,123 @@
+package filtering
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Expr is a node of a parsed filter expression.
+type Expr interface {
+	// Pos returns the 1-based position in the filter at which the expression starts.
+	Pos() int
+	// String renders the expression in a canonical form, such that equivalent filters render the same.
+	String() string
+}
+
+// Operator is a logical operator combining two expressions.
+type Operator string
+
+const (
+	And Operator = "AND"
+	Or  Operator = "OR"
+)
+
+// Binary is two expressions joined by a logical operator.
+type Binary struct {
+	Op          Operator
+	Left, Right Expr
+}
+
+func (b *Binary) Pos() int {
+	return b.Left.Pos()
+}
+
+func (b *Binary) String() string {
+	return "(" + b.Left.String() + " " + string(b.Op) + " " + b.Right.String() + ")"
+}
+
+// Not is the negation of an expression.
+type Not struct {
+	Expr Expr
+
+	pos int
+}
+
+func (n *Not) Pos() int {
+	return n.pos
+}
+
+func (n *Not) String() string {
+	return "NOT " + n.Expr.String()
+}
+
+// Comparator compares a field against one or more values.
+type Comparator string
+
+const (
+	Equals        Comparator = "="
+	NotEquals     Comparator = "!="
+	Less          Comparator = "<"
+	LessEquals    Comparator = "<="
+	Greater       Comparator = ">"
+	GreaterEquals Comparator = ">="
+	Has           Comparator = ":"
+	In            Comparator = "IN"
+)
+
+// Restriction compares a field with a value, or with a list of values in the case of In.
+type Restriction struct {
+	Field  string
+	Op     Comparator
+	Values []*Value
+
+	pos int
+}
+
+func (r *Restriction) Pos() int {
+	return r.pos
+}
+
+func (r *Restriction) String() string {
+	values := make([]string, len(r.Values))
+	for i, value := range r.Values {
+		values[i] = value.String()
+	}
+
+	if r.Op == In {
+		return r.Field + " IN (" + strings.Join(values, ", ") + ")"
+	}
+
+	return r.Field + " " + string(r.Op) + " " + values[0]
+}
+
+// ValueKind describes how a value was written in the filter.
+type ValueKind int
+
+const (
+	// TextValue is a bare word, such as true.
+	TextValue ValueKind = iota
+	// StringValue is a quoted string.
+	StringValue
+	// NumberValue is a numeric literal.
+	NumberValue
+)
+
+// Value is a literal in a filter expression.
+type Value struct {
+	Kind ValueKind
+	Raw  string
+
+	pos int
+}
+
+func (v *Value) Pos() int {
+	return v.pos
+}
+
+func (v *Value) String() string {
+	if v.Kind == StringValue {
+		return strconv.Quote(v.Raw)
+	}
+
+	return v.Raw
+}
@@ -0,0 +1,172 @@
+package filtering
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// tokenKind identifies the kind of a lexical token in a filter expression.
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenText
+	tokenString
+	tokenNumber
+	tokenComparator
+	tokenLParen
+	tokenRParen
+	tokenComma
+	tokenMinus
+	tokenAnd
+	tokenOr
+	tokenNot
+	tokenIn
+)
+
+// keywords are reserved words of the filter language. As per AIP-160 they are case sensitive.
+var keywords = map[string]tokenKind{
+	"AND": tokenAnd,
+	"OR":  tokenOr,
+	"NOT": tokenNot,
+	"IN":  tokenIn,
+}
+
+// token is a lexical token, along with the 1-based position in the filter at which it starts.
+type token struct {
+	kind  tokenKind
+	value string
+	pos   int
+}
+
+func (t token) describe() string {
+	switch t.kind {
+	case tokenEOF:
+		return "end of filter"
+	case tokenString:
+		return "string " + t.value
+	}
+
+	return "\"" + t.value + "\""
+}
+
+// lexer splits a filter expression into tokens.
+type lexer struct {
+	input  string
+	offset int
+}
+
+func (l *lexer) next() (token, error) {
+	for l.offset < len(l.input) {
+		r, size := utf8.DecodeRuneInString(l.input[l.offset:])
+		if !unicode.IsSpace(r) {
+			break
+		}
+
+		l.offset += size
+	}
+
+	start := l.offset
+	tok := func(kind tokenKind, size int) (token, error) {
+		l.offset += size
+		return token{kind: kind, value: l.input[start:l.offset], pos: start + 1}, nil
+	}
+
+	if start >= len(l.input) {
+		return token{kind: tokenEOF, pos: start + 1}, nil
+	}
+
+	rest := l.input[start:]
+
+	switch {
+	case strings.HasPrefix(rest, "<="), strings.HasPrefix(rest, ">="), strings.HasPrefix(rest, "!="):
+		return tok(tokenComparator, 2)
+	case rest[0] == '<', rest[0] == '>', rest[0] == '=', rest[0] == ':':
+		return tok(tokenComparator, 1)
+	case rest[0] == '(':
+		return tok(tokenLParen, 1)
+	case rest[0] == ')':
+		return tok(tokenRParen, 1)
+	case rest[0] == ',':
+		return tok(tokenComma, 1)
+	case rest[0] == '"', rest[0] == '\'':
+		return l.string()
+	case rest[0] == '-' && (len(rest) == 1 || !isDigit(rest[1])):
+		return tok(tokenMinus, 1)
+	case rest[0] == '-' || isDigit(rest[0]):
+		return l.number()
+	}
+
+	size := 0
+	for size < len(rest) {
+		r, width := utf8.DecodeRuneInString(rest[size:])
+		if !isTextRune(r) {
+			break
+		}
+
+		size += width
+	}
+
+	if size == 0 {
+		r, _ := utf8.DecodeRuneInString(rest)
+		return token{}, errorf(start+1, "unexpected character %q", r)
+	}
+
+	if kind, ok := keywords[rest[:size]]; ok {
+		return tok(kind, size)
+	}
+
+	return tok(tokenText, size)
+}
+
+func (l *lexer) string() (token, error) {
+	var (
+		start = l.offset
+		quote = l.input[start]
+		value strings.Builder
+	)
+
+	for i := start + 1; i < len(l.input); i++ {
+		switch c := l.input[i]; {
+		case c == '\\' && i+1 < len(l.input):
+			i++
+			value.WriteByte(l.input[i])
+		case c == quote:
+			l.offset = i + 1
+			return token{kind: tokenString, value: value.String(), pos: start + 1}, nil
+		default:
+			value.WriteByte(c)
+		}
+	}
+
+	return token{}, errorf(start+1, "unterminated string")
+}
+
+func (l *lexer) number() (token, error) {
+	start := l.offset
+	end := start + 1
+
+	for end < len(l.input) && (isDigit(l.input[end]) || l.input[end] == '.') {
+		end++
+	}
+
+	// A number running straight into text, such as 1abc, is most likely a typo.
+	if end < len(l.input) {
+		if r, _ := utf8.DecodeRuneInString(l.input[end:]); isTextRune(r) {
+			return token{}, errorf(start+1, "malformed number %q", l.input[start:end+1])
+		}
+	}
+
+	l.offset = end
+
+	return token{kind: tokenNumber, value: l.input[start:end], pos: start + 1}, nil
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isTextRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' || r == '.' || r == '*'
+}
@@ -0,0 +1,295 @@
+// Package filtering implements the filter language of AIP-160 (https://google.aip.dev/160), for use
+// in List requests.
+//
+// Filters are parsed into an abstract syntax tree, which can then be type checked against a set of
+// fields and compiled into a parameterised SQL condition. Besides the comparators of AIP-160, a
+// field may be compared against a list of values with IN, for example meeting_id IN (1, 2).
+package filtering
+
+import "fmt"
+
+const (
+	// MaxLength is the longest filter we are willing to parse.
+	MaxLength = 2048
+	// maxDepth limits how deeply expressions may be nested, to keep the parser's stack in check.
+	maxDepth = 32
+)
+
+// Error is a problem with a filter, found at the given 1-based position.
+type Error struct {
+	Pos int
+	Msg string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("invalid filter at position %d: %s", e.Pos, e.Msg)
+}
+
+func errorf(pos int, format string, args ...interface{}) *Error {
+	return &Error{Pos: pos, Msg: fmt.Sprintf(format, args...)}
+}
+
+// Parse parses a filter into an expression. An empty filter returns a nil expression, matching everything.
+//
+// Following AIP-160, OR binds more tightly than AND, and a sequence of restrictions separated only by
+// whitespace is equivalent to joining them with AND.
+func Parse(filter string) (Expr, error) {
+	if len(filter) > MaxLength {
+		return nil, errorf(MaxLength+1, "filter is longer than %d characters", MaxLength)
+	}
+
+	p := &parser{lexer: lexer{input: filter}}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+
+	if p.tok.kind == tokenEOF {
+		return nil, nil
+	}
+
+	expr, err := p.expression()
+	if err != nil {
+		return nil, err
+	}
+
+	if p.tok.kind != tokenEOF {
+		return nil, errorf(p.tok.pos, "unexpected %s", p.tok.describe())
+	}
+
+	return expr, nil
+}
+
+// parser is a recursive descent parser over the grammar of AIP-160.
+type parser struct {
+	lexer lexer
+	tok   token
+	depth int
+}
+
+func (p *parser) advance() error {
+	tok, err := p.lexer.next()
+	if err != nil {
+		return err
+	}
+
+	p.tok = tok
+
+	return nil
+}
+
+// expression : sequence {AND sequence}
+func (p *parser) expression() (Expr, error) {
+	p.depth++
+	defer func() { p.depth-- }()
+
+	if p.depth > maxDepth {
+		return nil, errorf(p.tok.pos, "filter is nested more than %d levels deep", maxDepth)
+	}
+
+	left, err := p.sequence()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.tok.kind == tokenAnd {
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+
+		right, err := p.sequence()
+		if err != nil {
+			return nil, err
+		}
+
+		left = &Binary{Op: And, Left: left, Right: right}
+	}
+
+	return left, nil
+}
+
+// sequence : factor {factor}
+func (p *parser) sequence() (Expr, error) {
+	left, err := p.factor()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.startsTerm() {
+		right, err := p.factor()
+		if err != nil {
+			return nil, err
+		}
+
+		left = &Binary{Op: And, Left: left, Right: right}
+	}
+
+	return left, nil
+}
+
+// factor : term {OR term}
+func (p *parser) factor() (Expr, error) {
+	left, err := p.term()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.tok.kind == tokenOr {
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+
+		right, err := p.term()
+		if err != nil {
+			return nil, err
+		}
+
+		left = &Binary{Op: Or, Left: left, Right: right}
+	}
+
+	return left, nil
+}
+
+// term : [NOT | -] simple
+func (p *parser) term() (Expr, error) {
+	if p.tok.kind != tokenNot && p.tok.kind != tokenMinus {
+		return p.simple()
+	}
+
+	pos := p.tok.pos
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+
+	expr, err := p.simple()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Not{Expr: expr, pos: pos}, nil
+}
+
+// simple : restriction | ( expression )
+func (p *parser) simple() (Expr, error) {
+	if p.tok.kind != tokenLParen {
+		return p.restriction()
+	}
+
+	open := p.tok.pos
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+
+	expr, err := p.expression()
+	if err != nil {
+		return nil, err
+	}
+
+	if p.tok.kind != tokenRParen {
+		return nil, errorf(p.tok.pos, "expected ) to close the ( at position %d, found %s", open, p.tok.describe())
+	}
+
+	return expr, p.advance()
+}
+
+// restriction : field comparator value | field IN ( value {, value} )
+func (p *parser) restriction() (Expr, error) {
+	if p.tok.kind != tokenText {
+		return nil, errorf(p.tok.pos, "expected a field name, found %s", p.tok.describe())
+	}
+
+	restriction := &Restriction{Field: p.tok.value, pos: p.tok.pos}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+
+	switch p.tok.kind {
+	case tokenComparator:
+		restriction.Op = Comparator(p.tok.value)
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+
+		value, err := p.value()
+		if err != nil {
+			return nil, err
+		}
+
+		restriction.Values = []*Value{value}
+	case tokenIn:
+		restriction.Op = In
+		values, err := p.list()
+		if err != nil {
+			return nil, err
+		}
+
+		restriction.Values = values
+	default:
+		return nil, errorf(p.tok.pos, "expected a comparator after %s, found %s", restriction.Field, p.tok.describe())
+	}
+
+	return restriction, nil
+}
+
+// list : ( value {, value} )
+func (p *parser) list() ([]*Value, error) {
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+
+	if p.tok.kind != tokenLParen {
+		return nil, errorf(p.tok.pos, "expected ( after IN, found %s", p.tok.describe())
+	}
+
+	var values []*Value
+
+	for {
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+
+		value, err := p.value()
+		if err != nil {
+			return nil, err
+		}
+
+		values = append(values, value)
+
+		switch p.tok.kind {
+		case tokenComma:
+			continue
+		case tokenRParen:
+			return values, p.advance()
+		default:
+			return nil, errorf(p.tok.pos, "expected , or ) in list, found %s", p.tok.describe())
+		}
+	}
+}
+
+func (p *parser) value() (*Value, error) {
+	var kind ValueKind
+
+	switch p.tok.kind {
+	case tokenText:
+		kind = TextValue
+	case tokenString:
+		kind = StringValue
+	case tokenNumber:
+		kind = NumberValue
+	default:
+		return nil, errorf(p.tok.pos, "expected a value, found %s", p.tok.describe())
+	}
+
+	value := &Value{Kind: kind, Raw: p.tok.value, pos: p.tok.pos}
+
+	return value, p.advance()
+}
+
+// startsTerm reports whether the current token may begin another term of a sequence.
+func (p *parser) startsTerm() bool {
+	switch p.tok.kind {
+	case tokenText, tokenNot, tokenMinus, tokenLParen:
+		return true
+	}
+
+	return false
+}
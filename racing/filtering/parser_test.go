@@ -0,0 +1,100 @@
+package filtering
+
+import (
+	"strings"
+	"testing"
+)
+
+// nested returns a restriction wrapped in n pairs of parentheses.
+func nested(n int) string {
+	return strings.Repeat("(", n) + "id = 1" + strings.Repeat(")", n)
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter string
+		want   string
+	}{
+		{name: "empty", filter: "", want: "<nil>"},
+		{name: "blank", filter: "  \t ", want: "<nil>"},
+		{name: "restriction", filter: "id = 1", want: "id = 1"},
+		{name: "every comparator", filter: "a != 1 b < 2 c <= 3 d > 4 e >= 5 f : x", want: "(((((a != 1 AND b < 2) AND c <= 3) AND d > 4) AND e >= 5) AND f : x)"},
+		{name: "quoted strings", filter: `name = "Melbourne \"Cup\"" OR name = 'Derby'`, want: `(name = "Melbourne \"Cup\"" OR name = "Derby")`},
+		{name: "negative number", filter: "id > -5", want: "id > -5"},
+		{name: "and", filter: "a = 1 AND b = 2", want: "(a = 1 AND b = 2)"},
+		{name: "or", filter: "a = 1 OR b = 2", want: "(a = 1 OR b = 2)"},
+		{name: "or binds more tightly than and", filter: "a = 1 AND b = 2 OR c = 3", want: "(a = 1 AND (b = 2 OR c = 3))"},
+		{name: "or binds more tightly than and on the left", filter: "a = 1 OR b = 2 AND c = 3", want: "((a = 1 OR b = 2) AND c = 3)"},
+		{name: "whitespace is and", filter: "a = 1 b = 2 OR c = 3", want: "(a = 1 AND (b = 2 OR c = 3))"},
+		{name: "and is left associative", filter: "a = 1 AND b = 2 AND c = 3", want: "((a = 1 AND b = 2) AND c = 3)"},
+		{name: "parentheses", filter: "(a = 1 AND b = 2) OR c = 3", want: "((a = 1 AND b = 2) OR c = 3)"},
+		{name: "not", filter: "NOT a = 1", want: "NOT a = 1"},
+		{name: "minus", filter: "-a = 1", want: "NOT a = 1"},
+		{name: "not binds more tightly than or", filter: "NOT a = 1 OR b = 2", want: "(NOT a = 1 OR b = 2)"},
+		{name: "not of parentheses", filter: "NOT (a = 1 OR b = 2)", want: "NOT (a = 1 OR b = 2)"},
+		{name: "in", filter: "meeting_id IN (1, 2,3)", want: "meeting_id IN (1, 2, 3)"},
+		{name: "in of one", filter: "state IN (OPEN)", want: "state IN (OPEN)"},
+		{name: "not in", filter: `NOT state IN ("OPEN", "FINAL") visible = true`, want: `(NOT state IN ("OPEN", "FINAL") AND visible = true)`},
+		{name: "keywords are case sensitive", filter: "a = and", want: "a = and"},
+		{name: "as deep as allowed", filter: nested(maxDepth - 1), want: "id = 1"},
+		{name: "as long as allowed", filter: "name = \"" + strings.Repeat("x", MaxLength-9) + "\"", want: "name = \"" + strings.Repeat("x", MaxLength-9) + "\""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expr, err := Parse(tt.filter)
+			if err != nil {
+				t.Fatalf("Parse(%q) = %v", tt.filter, err)
+			}
+
+			got := "<nil>"
+			if expr != nil {
+				got = expr.String()
+			}
+
+			if got != tt.want {
+				t.Errorf("Parse(%q) = %s, want %s", tt.filter, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter string
+		want   Error
+	}{
+		{name: "missing value", filter: "id =", want: Error{Pos: 5, Msg: "expected a value, found end of filter"}},
+		{name: "missing comparator", filter: "id 1", want: Error{Pos: 4, Msg: `expected a comparator after id, found "1"`}},
+		{name: "missing field", filter: "= 1", want: Error{Pos: 1, Msg: `expected a field name, found "="`}},
+		{name: "dangling and", filter: "id = 1 AND", want: Error{Pos: 11, Msg: "expected a field name, found end of filter"}},
+		{name: "unclosed parenthesis", filter: "(id = 1", want: Error{Pos: 8, Msg: "expected ) to close the ( at position 1, found end of filter"}},
+		{name: "unopened parenthesis", filter: "id = 1)", want: Error{Pos: 7, Msg: `unexpected ")"`}},
+		{name: "unterminated string", filter: `name = "Cup`, want: Error{Pos: 8, Msg: "unterminated string"}},
+		{name: "malformed number", filter: "id = 1abc", want: Error{Pos: 6, Msg: `malformed number "1a"`}},
+		{name: "unexpected character", filter: "id = 1 & 2", want: Error{Pos: 8, Msg: `unexpected character '&'`}},
+		{name: "positions count bytes", filter: `name = "Zürich" & id = 1`, want: Error{Pos: 18, Msg: `unexpected character '&'`}},
+		{name: "in without parentheses", filter: "id IN 1, 2", want: Error{Pos: 7, Msg: `expected ( after IN, found "1"`}},
+		{name: "empty in", filter: "id IN ()", want: Error{Pos: 8, Msg: `expected a value, found ")"`}},
+		{name: "unclosed in", filter: "id IN (1, 2", want: Error{Pos: 12, Msg: "expected , or ) in list, found end of filter"}},
+		{name: "too deep", filter: nested(maxDepth), want: Error{Pos: maxDepth + 1, Msg: "filter is nested more than 32 levels deep"}},
+		{name: "too long", filter: "name = \"" + strings.Repeat("x", MaxLength) + "\"", want: Error{Pos: MaxLength + 1, Msg: "filter is longer than 2048 characters"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(tt.filter)
+
+			got, ok := err.(*Error)
+			if !ok {
+				t.Fatalf("Parse(%q) = %v, want an *Error", tt.filter, err)
+			}
+
+			if *got != tt.want {
+				t.Errorf("Parse(%q) = %+v, want %+v", tt.filter, *got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,187 @@
+package filtering
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Type is the type of a field that can be filtered on.
+type Type int
+
+const (
+	Int Type = iota
+	Bool
+	Text
+	Timestamp
+)
+
+func (t Type) String() string {
+	switch t {
+	case Int:
+		return "an integer"
+	case Bool:
+		return "a boolean"
+	case Timestamp:
+		return "a timestamp"
+	}
+
+	return "a string"
+}
+
+// comparators lists the comparators that make sense for each type.
+var comparators = map[Type]map[Comparator]bool{
+	Int:       {Equals: true, NotEquals: true, Less: true, LessEquals: true, Greater: true, GreaterEquals: true, In: true},
+	Bool:      {Equals: true, NotEquals: true},
+	Text:      {Equals: true, NotEquals: true, Has: true, In: true},
+	Timestamp: {Equals: true, NotEquals: true, Less: true, LessEquals: true, Greater: true, GreaterEquals: true},
+}
+
+// Field describes a field that can be filtered on, and the SQL column backing it.
+type Field struct {
+	Column string
+	Type   Type
+}
+
+// Schema maps the names of filterable fields, as they appear in filters, to their columns.
+type Schema map[string]Field
+
+// Compile type checks the expression against the schema, and compiles it into a parameterised SQL
+// condition. Type errors are returned as an *Error pointing at the offending part of the filter.
+func Compile(expr Expr, schema Schema) (string, []interface{}, error) {
+	c := &compiler{schema: schema}
+	if err := c.compile(expr); err != nil {
+		return "", nil, err
+	}
+
+	return c.sql.String(), c.args, nil
+}
+
+type compiler struct {
+	schema Schema
+	sql    strings.Builder
+	args   []interface{}
+}
+
+func (c *compiler) compile(expr Expr) error {
+	switch e := expr.(type) {
+	case *Binary:
+		c.sql.WriteString("(")
+		if err := c.compile(e.Left); err != nil {
+			return err
+		}
+
+		c.sql.WriteString(" " + string(e.Op) + " ")
+		if err := c.compile(e.Right); err != nil {
+			return err
+		}
+
+		c.sql.WriteString(")")
+	case *Not:
+		c.sql.WriteString("NOT (")
+		if err := c.compile(e.Expr); err != nil {
+			return err
+		}
+
+		c.sql.WriteString(")")
+	case *Restriction:
+		return c.restriction(e)
+	default:
+		return errorf(expr.Pos(), "unsupported expression")
+	}
+
+	return nil
+}
+
+func (c *compiler) restriction(r *Restriction) error {
+	field, ok := c.schema[r.Field]
+	if !ok {
+		return errorf(r.Pos(), "unknown field %q", r.Field)
+	}
+
+	if !comparators[field.Type][r.Op] {
+		return errorf(r.Pos(), "%s is %s, which does not support the %s comparator", r.Field, field.Type, r.Op)
+	}
+
+	args := make([]interface{}, len(r.Values))
+	for i, value := range r.Values {
+		arg, err := convert(r.Field, field.Type, value)
+		if err != nil {
+			return err
+		}
+
+		args[i] = arg
+	}
+
+	column := field.Column
+
+	// Timestamps are stored as text, so both sides are normalised by SQLite before they are compared.
+	param := "?"
+	if field.Type == Timestamp {
+		column = "datetime(" + column + ")"
+		param = "datetime(?)"
+	}
+
+	switch r.Op {
+	case In:
+		c.sql.WriteString(column + " IN (" + strings.Repeat(param+",", len(args)-1) + param + ")")
+	case Has:
+		c.sql.WriteString(column + ` LIKE ? ESCAPE '\'`)
+		args[0] = "%" + escapeLike(args[0].(string)) + "%"
+	default:
+		c.sql.WriteString(column + " " + string(r.Op) + " " + param)
+	}
+
+	c.args = append(c.args, args...)
+
+	return nil
+}
+
+// convert checks a value is of the field's type, returning it as a SQL argument.
+func convert(name string, typ Type, value *Value) (interface{}, error) {
+	mismatch := func() error {
+		return errorf(value.Pos(), "%s is %s, but %s is not", name, typ, value)
+	}
+
+	switch typ {
+	case Int:
+		if value.Kind != NumberValue {
+			return nil, mismatch()
+		}
+
+		n, err := strconv.ParseInt(value.Raw, 10, 64)
+		if err != nil {
+			return nil, mismatch()
+		}
+
+		return n, nil
+	case Bool:
+		if value.Kind == NumberValue {
+			return nil, mismatch()
+		}
+
+		b, err := strconv.ParseBool(value.Raw)
+		if err != nil {
+			return nil, mismatch()
+		}
+
+		return b, nil
+	case Timestamp:
+		if value.Kind == NumberValue {
+			return nil, mismatch()
+		}
+
+		t, err := time.Parse(time.RFC3339, value.Raw)
+		if err != nil {
+			return nil, errorf(value.Pos(), "%s is %s, but %s is not in RFC 3339 format", name, typ, value)
+		}
+
+		return t.UTC().Format(time.RFC3339), nil
+	}
+
+	return value.Raw, nil
+}
+
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
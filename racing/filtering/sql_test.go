@@ -0,0 +1,155 @@
+package filtering
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// schema is a schema of one field of each type, as races have.
+var schema = Schema{
+	"id":         {Column: "id", Type: Int},
+	"meeting_id": {Column: "meeting_id", Type: Int},
+	"name":       {Column: "name", Type: Text},
+	"visible":    {Column: "visible", Type: Bool},
+	"start":      {Column: "advertised_start_time", Type: Timestamp},
+}
+
+// compile parses and compiles a filter against the schema.
+func compile(filter string) (string, []interface{}, error) {
+	expr, err := Parse(filter)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return Compile(expr, schema)
+}
+
+func TestCompile(t *testing.T) {
+	tests := []struct {
+		name     string
+		filter   string
+		wantSQL  string
+		wantArgs []interface{}
+	}{
+		{name: "int", filter: "id = 7", wantSQL: "id = ?", wantArgs: []interface{}{int64(7)}},
+		{name: "negative int", filter: "id >= -7", wantSQL: "id >= ?", wantArgs: []interface{}{int64(-7)}},
+		{name: "bool", filter: "visible != false", wantSQL: "visible != ?", wantArgs: []interface{}{false}},
+		{name: "text", filter: "name = Derby", wantSQL: "name = ?", wantArgs: []interface{}{"Derby"}},
+		{name: "quoted text", filter: `name = "Melbourne Cup"`, wantSQL: "name = ?", wantArgs: []interface{}{"Melbourne Cup"}},
+		{name: "has", filter: `name : "Cup"`, wantSQL: `name LIKE ? ESCAPE '\'`, wantArgs: []interface{}{"%Cup%"}},
+		{name: "has escapes wildcards", filter: `name : "100%_\\"`, wantSQL: `name LIKE ? ESCAPE '\'`, wantArgs: []interface{}{`%100\%\_\\%`}},
+		{name: "timestamp in utc", filter: `start < "2026-11-03T15:00:00+11:00"`, wantSQL: "datetime(advertised_start_time) < datetime(?)", wantArgs: []interface{}{"2026-11-03T04:00:00Z"}},
+		{name: "in", filter: "meeting_id IN (1, 2, 3)", wantSQL: "meeting_id IN (?,?,?)", wantArgs: []interface{}{int64(1), int64(2), int64(3)}},
+		{name: "not in", filter: `NOT name IN ("a", "b")`, wantSQL: "NOT (name IN (?,?))", wantArgs: []interface{}{"a", "b"}},
+		{name: "minus", filter: "-visible = true", wantSQL: "NOT (visible = ?)", wantArgs: []interface{}{true}},
+		{
+			name:     "precedence",
+			filter:   "id = 1 AND visible = true OR name = x",
+			wantSQL:  "(id = ? AND (visible = ? OR name = ?))",
+			wantArgs: []interface{}{int64(1), true, "x"},
+		},
+		{
+			name:     "parentheses",
+			filter:   "(id = 1 AND visible = true) OR name = x",
+			wantSQL:  "((id = ? AND visible = ?) OR name = ?)",
+			wantArgs: []interface{}{int64(1), true, "x"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotSQL, gotArgs, err := compile(tt.filter)
+			if err != nil {
+				t.Fatalf("Compile(%q) = %v", tt.filter, err)
+			}
+
+			if gotSQL != tt.wantSQL {
+				t.Errorf("Compile(%q) SQL = %s, want %s", tt.filter, gotSQL, tt.wantSQL)
+			}
+
+			if !reflect.DeepEqual(gotArgs, tt.wantArgs) {
+				t.Errorf("Compile(%q) args = %#v, want %#v", tt.filter, gotArgs, tt.wantArgs)
+			}
+		})
+	}
+}
+
+func TestCompileErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter string
+		want   Error
+	}{
+		{name: "unknown field", filter: "id = 1 AND colour = red", want: Error{Pos: 12, Msg: `unknown field "colour"`}},
+		{name: "text for an int", filter: "id = seven", want: Error{Pos: 6, Msg: "id is an integer, but seven is not"}},
+		{name: "decimal for an int", filter: "id = 1.5", want: Error{Pos: 6, Msg: "id is an integer, but 1.5 is not"}},
+		{name: "string in an int list", filter: `meeting_id IN (1, "2")`, want: Error{Pos: 19, Msg: `meeting_id is an integer, but "2" is not`}},
+		{name: "number for a bool", filter: "visible = 1", want: Error{Pos: 11, Msg: "visible is a boolean, but 1 is not"}},
+		{name: "text for a bool", filter: "visible = yes", want: Error{Pos: 11, Msg: "visible is a boolean, but yes is not"}},
+		{name: "number for a timestamp", filter: "start > 2026", want: Error{Pos: 9, Msg: "start is a timestamp, but 2026 is not"}},
+		{name: "malformed timestamp", filter: `start > "tomorrow"`, want: Error{Pos: 9, Msg: `start is a timestamp, but "tomorrow" is not in RFC 3339 format`}},
+		{name: "ordering a bool", filter: "visible < true", want: Error{Pos: 1, Msg: "visible is a boolean, which does not support the < comparator"}},
+		{name: "has on an int", filter: "id : 1", want: Error{Pos: 1, Msg: "id is an integer, which does not support the : comparator"}},
+		{name: "in on a timestamp", filter: `start IN ("2026-11-03T15:00:00Z")`, want: Error{Pos: 1, Msg: "start is a timestamp, which does not support the IN comparator"}},
+		{name: "positions count bytes", filter: `name = "Zürich" id = x`, want: Error{Pos: 23, Msg: "id is an integer, but x is not"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := compile(tt.filter)
+
+			got, ok := err.(*Error)
+			if !ok {
+				t.Fatalf("Compile(%q) = %v, want an *Error", tt.filter, err)
+			}
+
+			if *got != tt.want {
+				t.Errorf("Compile(%q) = %+v, want %+v", tt.filter, *got, tt.want)
+			}
+		})
+	}
+}
+
+// TestCompileParameterises checks that no literal in a filter, however hostile, reaches the SQL itself.
+func TestCompileParameterises(t *testing.T) {
+	literals := []string{
+		`x'; DROP TABLE races; --`,
+		`") OR 1=1 --`,
+		`Robert'); DELETE FROM races WHERE ('1' = '1`,
+		`%' OR name LIKE '%`,
+	}
+
+	for _, literal := range literals {
+		quoted := `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(literal) + `"`
+
+		for _, filter := range []string{
+			"name = " + quoted,
+			"name != " + quoted,
+			"name : " + quoted,
+			"name IN (" + quoted + ", " + quoted + ")",
+			"NOT (id = 1 OR name = " + quoted + ")",
+		} {
+			sql, args, err := compile(filter)
+			if err != nil {
+				t.Fatalf("Compile(%q) = %v", filter, err)
+			}
+
+			if strings.Count(sql, "?") != len(args) {
+				t.Errorf("Compile(%q) = %s with %d args, want one placeholder per arg", filter, sql, len(args))
+			}
+
+			for _, keyword := range []string{"DROP", "DELETE", "1=1", "'1'", "--"} {
+				if strings.Contains(sql, keyword) {
+					t.Errorf("Compile(%q) SQL = %s, which contains the literal", filter, sql)
+				}
+			}
+
+			for _, arg := range args {
+				if s, ok := arg.(string); ok && s != literal && s != "%"+escapeLike(literal)+"%" {
+					t.Errorf("Compile(%q) arg = %q, want the literal %q", filter, s, literal)
+				}
+			}
+		}
+	}
+}
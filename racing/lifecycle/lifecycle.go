@@ -0,0 +1,61 @@
+// Package lifecycle defines the states a race moves through, and which moves between them are allowed.
+//
+// Races normally go from scheduled, to open, to closed once they jump, to an interim and then a final
+// result. They can be suspended while open, and postponed or abandoned at any time before they are
+// final. Final and abandoned races have nowhere to go: in particular, an abandoned race can never
+// reopen, as every bet on it has been refunded.
+package lifecycle
+
+import (
+	"fmt"
+	"strconv"
+
+	"git.neds.sh/matty/entain/common/errs"
+	"git.neds.sh/matty/entain/racing/proto/racing"
+)
+
+// transitions are the states a race may move to from each state of its lifecycle.
+var transitions = map[racing.Race_State][]racing.Race_State{
+	racing.Race_SCHEDULED: {racing.Race_OPEN, racing.Race_POSTPONED, racing.Race_ABANDONED},
+	racing.Race_OPEN:      {racing.Race_SUSPENDED, racing.Race_CLOSED, racing.Race_POSTPONED, racing.Race_ABANDONED},
+	racing.Race_SUSPENDED: {racing.Race_OPEN, racing.Race_CLOSED, racing.Race_POSTPONED, racing.Race_ABANDONED},
+	racing.Race_CLOSED:    {racing.Race_INTERIM, racing.Race_ABANDONED},
+	racing.Race_INTERIM:   {racing.Race_FINAL, racing.Race_ABANDONED},
+	racing.Race_POSTPONED: {racing.Race_SCHEDULED, racing.Race_OPEN, racing.Race_ABANDONED},
+}
+
+// Next returns the states a race may move to from the given state.
+func Next(from racing.Race_State) []racing.Race_State {
+	return append([]racing.Race_State(nil), transitions[from]...)
+}
+
+// Allowed reports whether a race may move from one state to another.
+func Allowed(from, to racing.Race_State) bool {
+	for _, next := range transitions[from] {
+		if next == to {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Check returns an error explaining why a race cannot move to a state, if it cannot: an
+// *errs.InvalidArgument for a state that does not exist, or an *errs.FailedPrecondition for a move
+// that is not allowed from the state the race is in.
+func Check(race *racing.Race, to racing.Race_State) error {
+	if _, ok := racing.Race_State_name[int32(to)]; !ok || to == racing.Race_STATE_UNSPECIFIED {
+		return errs.Invalid("state", "must be one of SCHEDULED, OPEN, SUSPENDED, CLOSED, INTERIM, FINAL, ABANDONED or POSTPONED")
+	}
+
+	if Allowed(race.State, to) {
+		return nil
+	}
+
+	reason := fmt.Sprintf("races cannot move from %s to %s", race.State, to)
+	if len(transitions[race.State]) == 0 {
+		reason = fmt.Sprintf("%s races cannot change state", race.State)
+	}
+
+	return &errs.FailedPrecondition{Resource: "race", ID: strconv.FormatInt(race.Id, 10), Reason: reason}
+}
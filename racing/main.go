@@ -2,53 +2,134 @@ package main
 
 import (
 	"database/sql"
+	"expvar"
 	"flag"
+	"fmt"
 	"log"
 	"net"
+	"net/http"
+	"os"
+	"time"
 
+	"git.neds.sh/matty/entain/common/errs"
+	"git.neds.sh/matty/entain/common/validate"
 	"git.neds.sh/matty/entain/racing/db"
 	"git.neds.sh/matty/entain/racing/proto/racing"
 	"git.neds.sh/matty/entain/racing/service"
 	"google.golang.org/grpc"
 )
 
+// domain identifies the racing service in the ErrorInfo of its errors.
+const domain = "racing.entain.com"
+
 var (
 	grpcEndpoint = flag.String("grpc-endpoint", "localhost:9000", "gRPC server endpoint")
+	dbPath       = flag.String("db-path", "./db/racing.db", "path to the racing SQLite database")
+
+	cacheSize       = flag.Int("cache-size", 1000, "maximum number of race queries to cache, or 0 to disable caching")
+	cacheTTL        = flag.Duration("cache-ttl", 10*time.Second, "how long a cached race query may be served for")
+	metricsEndpoint = flag.String("metrics-endpoint", "localhost:9001", "endpoint serving metrics at /debug/vars, or empty to disable")
+
+	resumeInterval = flag.Duration("resume-interval", time.Second, "how often to lift the suspensions of trading that have reached their resume time, telling watchers their races have resumed")
 )
 
 func main() {
+	flag.Usage = usage
 	flag.Parse()
 
-	if err := run(); err != nil {
-		log.Fatalf("failed running grpc server: %s\n", err)
+	switch flag.Arg(0) {
+	case "":
+		if err := run(); err != nil {
+			log.Fatalf("failed running grpc server: %s\n", err)
+		}
+	case "import":
+		if err := runImport(flag.Args()[1:]); err != nil {
+			log.Fatalf("failed importing races: %s\n", err)
+		}
+	case "export":
+		if err := runExport(flag.Args()[1:]); err != nil {
+			log.Fatalf("failed exporting races: %s\n", err)
+		}
+	default:
+		usage()
+		os.Exit(2)
 	}
 }
 
+func usage() {
+	fmt.Fprintf(flag.CommandLine.Output(), `Usage: %[1]s [flags] [command]
+
+Without a command, %[1]s serves the racing gRPC API.
+
+Commands:
+  import   create or update races from a CSV or JSON Lines file
+  export   write all races to a CSV or JSON Lines file
+
+Flags:
+`, os.Args[0])
+	flag.PrintDefaults()
+}
+
 func run() error {
 	conn, err := net.Listen("tcp", ":9000")
 	if err != nil {
 		return err
 	}
 
-	racingDB, err := sql.Open("sqlite3", "./db/racing.db")
+	racingDB, err := openDB()
 	if err != nil {
 		return err
 	}
 
-	racesRepo := db.NewRacesRepo(racingDB)
-	if err := racesRepo.Init(); err != nil {
+	racesRepo, err := newRacesRepo(racingDB)
+	if err != nil {
 		return err
 	}
 
-	grpcServer := grpc.NewServer()
+	if *cacheSize > 0 {
+		cachedRacesRepo := db.NewCachedRacesRepo(racesRepo, *cacheSize, *cacheTTL)
+		expvar.Publish("races_cache", expvar.Func(func() interface{} {
+			return cachedRacesRepo.Stats()
+		}))
+
+		racesRepo = cachedRacesRepo
+	}
+
+	if *metricsEndpoint != "" {
+		go func() {
+			// The expvar package serves its metrics on the default mux.
+			log.Printf("metrics listening on: %s\n", *metricsEndpoint)
+
+			if err := http.ListenAndServe(*metricsEndpoint, nil); err != nil {
+				log.Printf("failed serving metrics: %s\n", err)
+			}
+		}()
+	}
 
-	racing.RegisterRacingServer(
-		grpcServer,
-		service.NewRacingService(
-			racesRepo,
-		),
+	// Errors are converted to statuses outermost, so that they cover validation failures too.
+	grpcServer := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(errs.UnaryServerInterceptor(domain), validate.UnaryServerInterceptor()),
+		grpc.ChainStreamInterceptor(errs.StreamServerInterceptor(domain), validate.StreamServerInterceptor()),
 	)
 
+	racingService := service.NewRacingService(
+		racesRepo,
+		db.NewMarketsRepo(racingDB),
+		db.NewSuspensionsRepo(racingDB),
+	)
+
+	racing.RegisterRacingServer(grpcServer, racingService)
+
+	// Suspensions stop being in force at their resume time whether or not they have been lifted, so this
+	// only keeps their record, and watchers, up to date.
+	go func() {
+		for range time.Tick(*resumeInterval) {
+			if err := racingService.ResumeDue(); err != nil {
+				log.Printf("failed resuming trading: %s\n", err)
+			}
+		}
+	}()
+
 	log.Printf("gRPC server listening on: %s\n", *grpcEndpoint)
 
 	if err := grpcServer.Serve(conn); err != nil {
@@ -57,3 +138,28 @@ func run() error {
 
 	return nil
 }
+
+// openRacesRepo opens the racing database, ready for use.
+func openRacesRepo() (db.RacesRepo, error) {
+	racingDB, err := openDB()
+	if err != nil {
+		return nil, err
+	}
+
+	return newRacesRepo(racingDB)
+}
+
+// openDB opens the racing database.
+func openDB() (*sql.DB, error) {
+	return sql.Open("sqlite3", *dbPath)
+}
+
+// newRacesRepo returns the races repository of the racing database, initialising the database first.
+func newRacesRepo(racingDB *sql.DB) (db.RacesRepo, error) {
+	racesRepo := db.NewRacesRepo(racingDB)
+	if err := racesRepo.Init(); err != nil {
+		return nil, err
+	}
+
+	return racesRepo, nil
+}
@@ -0,0 +1,214 @@
+// Package odds checks and renders the fixed-odds prices of runners, which are kept as decimal odds, in
+// the formats punters expect, and measures the margin a market is framed to.
+package odds
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+
+	"git.neds.sh/matty/entain/common/errs"
+	"git.neds.sh/matty/entain/racing/proto/racing"
+	"git.neds.sh/matty/entain/racing/scratchings"
+)
+
+const (
+	// Min is the shortest price that can be offered, as decimal odds.
+	Min = 1.01
+	// Max is the longest price that can be offered, as decimal odds.
+	Max = 1001.0
+
+	// fractionTolerance is how far, relatively, fractional odds may stray from the decimal odds they
+	// render, so that they read as a bookmaker would write them, such as 10/11 rather than 91/100.
+	fractionTolerance = 0.025
+)
+
+// Check returns an error explaining why runners of a race cannot be given prices, if they cannot: an
+// *errs.FailedPrecondition if the race has jumped, or is otherwise not being bet on, or an
+// *errs.InvalidArgument listing every problem with the prices themselves. The race must have its runners
+// and scratchings.
+func Check(race *racing.Race, prices []*racing.PriceUpdate) error {
+	switch race.State {
+	case racing.Race_SCHEDULED, racing.Race_OPEN, racing.Race_SUSPENDED:
+	default:
+		return &errs.FailedPrecondition{
+			Resource: "race",
+			ID:       strconv.FormatInt(race.Id, 10),
+			Reason:   fmt.Sprintf("%s races cannot be priced", race.State),
+		}
+	}
+
+	var violations []errs.FieldViolation
+
+	violate := func(field, format string, args ...interface{}) {
+		violations = append(violations, errs.FieldViolation{Field: field, Description: fmt.Sprintf(format, args...)})
+	}
+
+	runners := make(map[int64]bool, len(race.Runners))
+	for _, runner := range race.Runners {
+		runners[runner.Number] = true
+	}
+
+	priced := make(map[int64]int)
+	scratched := scratchings.Scratched(race)
+
+	for i, price := range prices {
+		field := fmt.Sprintf("prices[%d]", i)
+
+		if !runners[price.RunnerNumber] {
+			violate(field+".runner_number", "%d is not a runner in the race", price.RunnerNumber)
+		} else if scratched[price.RunnerNumber] {
+			violate(field+".runner_number", "%d is scratched", price.RunnerNumber)
+		} else if first, ok := priced[price.RunnerNumber]; ok {
+			violate(field+".runner_number", "%d is already priced by prices[%d]", price.RunnerNumber, first)
+		} else {
+			priced[price.RunnerNumber] = i
+		}
+
+		for _, odds := range []struct {
+			field string
+			price float64
+		}{{"win", price.Win}, {"place", price.Place}} {
+			if odds.price != 0 && (odds.price < Min || odds.price > Max || math.IsNaN(odds.price)) {
+				violate(field+"."+odds.field, "must be 0, or from %.2f to %.0f", Min, Max)
+			}
+		}
+
+		if price.Win != 0 && price.Place > price.Win {
+			violate(field+".place", "must not be longer than the win price")
+		}
+	}
+
+	if len(violations) > 0 {
+		return &errs.InvalidArgument{Violations: violations}
+	}
+
+	return nil
+}
+
+// NewMarket returns the market of a race from the prices of its priced runners, as decimal odds, listing
+// every runner of the race, marking those scratched, and rendering their prices in the given format.
+func NewMarket(race *racing.Race, priced []*racing.RunnerPrices, format racing.OddsFormat) *racing.Market {
+	byNumber := make(map[int64]*racing.RunnerPrices, len(priced))
+	for _, runner := range priced {
+		byNumber[runner.RunnerNumber] = runner
+	}
+
+	market := &racing.Market{RaceId: race.Id, OddsFormat: format}
+
+	var wins, places []float64
+
+	scratched := scratchings.Scratched(race)
+
+	for _, runner := range race.Runners {
+		prices, ok := byNumber[runner.Number]
+		if !ok {
+			prices = &racing.RunnerPrices{RunnerNumber: runner.Number}
+		}
+
+		prices.RunnerName = runner.Name
+		prices.Scratched = scratched[runner.Number]
+		render(prices.Win, format)
+		render(prices.Place, format)
+
+		for _, fluc := range prices.Flucs {
+			render(fluc.Win, format)
+			render(fluc.Place, format)
+		}
+
+		wins = append(wins, prices.GetWin().GetDecimal())
+		places = append(places, prices.GetPlace().GetDecimal())
+		market.Runners = append(market.Runners, prices)
+	}
+
+	market.WinPercentage = Percentage(wins)
+	market.PlacePercentage = Percentage(places)
+
+	return market
+}
+
+// render sets the display of odds, if there are any, in the given format.
+func render(odds *racing.Odds, format racing.OddsFormat) {
+	if odds != nil {
+		odds.Display = Format(odds.Decimal, format)
+	}
+}
+
+// Format renders decimal odds in the given format.
+func Format(decimal float64, format racing.OddsFormat) string {
+	switch format {
+	case racing.OddsFormat_FRACTIONAL:
+		return Fractional(decimal)
+	case racing.OddsFormat_AMERICAN:
+		return American(decimal)
+	default:
+		return Decimal(decimal)
+	}
+}
+
+// Decimal renders decimal odds to two decimal places, such as 3.50.
+func Decimal(decimal float64) string {
+	return fmt.Sprintf("%.2f", decimal)
+}
+
+// Fractional renders decimal odds as the profit on a stake, such as 5/2 for 3.50, using the simplest
+// fraction within 2.5% of them, such as 10/11 for 1.91.
+func Fractional(decimal float64) string {
+	if decimal == 2 {
+		return "evens"
+	}
+
+	// Prices are quoted in cents, and rounding to them stops float error from getting in the way.
+	num, den := approximate(math.Round((decimal-1)*100) / 100)
+
+	return fmt.Sprintf("%d/%d", num, den)
+}
+
+// American renders decimal odds as moneyline odds: the profit on a stake of 100 for prices longer than
+// evens, such as +250 for 3.50, and the stake needed to profit 100 otherwise, such as -200 for 1.50.
+func American(decimal float64) string {
+	if decimal >= 2 {
+		return fmt.Sprintf("+%d", int64(math.Round((decimal-1)*100)))
+	}
+
+	return fmt.Sprintf("-%d", int64(math.Round(100/(decimal-1))))
+}
+
+// Percentage returns the market percentage of a set of prices: the sum of the probabilities they
+// imply, as a percentage. A fair win market adds up to 100%, and anything over that is the overround.
+// Prices of zero are not offered, and are left out.
+func Percentage(prices []float64) float64 {
+	var total float64
+
+	for _, price := range prices {
+		if price > 0 {
+			total += 100 / price
+		}
+	}
+
+	return math.Round(total*100) / 100
+}
+
+// approximate returns the first convergent of the continued fraction of x that is within
+// fractionTolerance of it. Convergents are the closest fractions to x for the size of their
+// denominators, so this is the simplest fraction that close.
+func approximate(x float64) (int64, int64) {
+	// The last two convergents h0/k0 and h1/k1, which start out as 0/1 and 1/0.
+	h0, h1 := int64(0), int64(1)
+	k0, k1 := int64(1), int64(0)
+
+	for r := x; ; {
+		// A little slack keeps float error from turning a whole number into one less than it.
+		a := int64(math.Floor(r + 1e-9))
+
+		h0, h1 = h1, a*h1+h0
+		k0, k1 = k1, a*k1+k0
+
+		frac := r - float64(a)
+		if frac < 1e-9 || math.Abs(float64(h1)/float64(k1)-x) <= x*fractionTolerance || k1 > 1000 {
+			return h1, k1
+		}
+
+		r = 1 / frac
+	}
+}
@@ -1,3 +1,6 @@
 package proto
 
-//go:generate protoc --go_out=. --go-grpc_out=require_unimplemented_servers=false:. racing/racing.proto
+// The protos are shared with the api gateway, and live in the proto directory at the root of the repo.
+// They declare no go_package, so each module maps them onto its own packages here. The validation rules
+// are those of the common module, whose validate package enforces them.
+//go:generate protoc -I ../../proto --go_out . --go_opt paths=source_relative --go_opt Mracing/racing.proto=git.neds.sh/matty/entain/racing/proto/racing --go_opt Mvalidate/validate.proto=git.neds.sh/matty/entain/common/proto/validate --go-grpc_out . --go-grpc_opt paths=source_relative --go-grpc_opt Mracing/racing.proto=git.neds.sh/matty/entain/racing/proto/racing --go-grpc_opt Mvalidate/validate.proto=git.neds.sh/matty/entain/common/proto/validate --go-grpc_opt require_unimplemented_servers=false racing/racing.proto
@@ -0,0 +1,366 @@
+// Command wirecheck fails when the racing service and the api gateway would disagree on the wire.
+//
+// Both generate their code from the shared protos in the proto directory at the root of the repo, but
+// each module does so separately and either may be left stale. wirecheck compares the descriptor
+// compiled into this module with the one embedded in another module's generated code, such as
+// ../api/proto/racing/racing.pb.go, and lists every message, field, enum value and method on which
+// they differ.
+//
+//	go run ./proto/wirecheck ../api/proto/racing/racing.pb.go
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"log"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"git.neds.sh/matty/entain/racing/proto/racing"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+func main() {
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s GENERATED_FILE...\n\nCompares the racing protos compiled into this module with those embedded in other modules' generated code.\n", os.Args[0])
+	}
+	flag.Parse()
+
+	if flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	ours := protodesc.ToFileDescriptorProto(racing.File_racing_racing_proto)
+
+	failed := false
+
+	for _, path := range flag.Args() {
+		theirs, err := readDescriptor(path, racing.File_racing_racing_proto)
+		if err != nil {
+			log.Fatalf("failed reading %s: %s\n", path, err)
+		}
+
+		for _, diff := range compareFiles(ours, theirs) {
+			fmt.Printf("%s: %s\n", path, diff)
+			failed = true
+		}
+	}
+
+	if failed {
+		os.Exit(1)
+	}
+}
+
+// readDescriptor extracts the raw descriptor of a proto file from the Go code generated for it elsewhere.
+func readDescriptor(path string, fd protoreflect.FileDescriptor) (*descriptorpb.FileDescriptorProto, error) {
+	file, err := parser.ParseFile(token.NewFileSet(), path, nil, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	// protoc-gen-go names the variable holding the descriptor after the proto's path, such as
+	// file_racing_racing_proto_rawDesc for racing/racing.proto.
+	name := "file_" + strings.NewReplacer("/", "_", ".", "_", "-", "_").Replace(fd.Path()) + "_rawDesc"
+
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.VAR {
+			continue
+		}
+
+		for _, spec := range gen.Specs {
+			value := spec.(*ast.ValueSpec)
+			if len(value.Names) != 1 || value.Names[0].Name != name || len(value.Values) != 1 {
+				continue
+			}
+
+			raw, err := bytesOf(value.Values[0])
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", name, err)
+			}
+
+			// The options of the descriptor are decoded using the extensions linked into this binary,
+			// so that they are compared by value rather than by their encoding, which is not stable.
+			desc := &descriptorpb.FileDescriptorProto{}
+			if err := proto.Unmarshal(raw, desc); err != nil {
+				return nil, fmt.Errorf("%s: %w", name, err)
+			}
+
+			return desc, nil
+		}
+	}
+
+	return nil, fmt.Errorf("no %s variable, was the file generated from %s?", name, fd.Path())
+}
+
+// bytesOf evaluates a []byte composite literal of integer literals.
+func bytesOf(expr ast.Expr) ([]byte, error) {
+	lit, ok := expr.(*ast.CompositeLit)
+	if !ok {
+		return nil, errors.New("not a composite literal")
+	}
+
+	raw := make([]byte, 0, len(lit.Elts))
+
+	for _, elt := range lit.Elts {
+		basic, ok := elt.(*ast.BasicLit)
+		if !ok || basic.Kind != token.INT {
+			return nil, errors.New("not a byte literal")
+		}
+
+		b, err := strconv.ParseUint(basic.Value, 0, 8)
+		if err != nil {
+			return nil, err
+		}
+
+		raw = append(raw, byte(b))
+	}
+
+	return raw, nil
+}
+
+// compareFiles lists the differences between two descriptors of the same proto file.
+func compareFiles(ours, theirs *descriptorpb.FileDescriptorProto) []string {
+	var diffs []string
+
+	if ours.GetPackage() != theirs.GetPackage() {
+		diffs = append(diffs, fmt.Sprintf("package is %q here but %q there", ours.GetPackage(), theirs.GetPackage()))
+	}
+
+	diffs = append(diffs, compareMessages(ours.GetPackage(), ours.MessageType, theirs.MessageType)...)
+	diffs = append(diffs, compareEnums(ours.GetPackage(), ours.EnumType, theirs.EnumType)...)
+	diffs = append(diffs, compareServices(ours.GetPackage(), ours.Service, theirs.Service)...)
+
+	if len(diffs) == 0 && !proto.Equal(ours, theirs) {
+		// Nothing that changes the encoding of messages differs, but something else does, such as an
+		// HTTP or validation rule. The gateway would still route or document requests differently.
+		diffs = append(diffs, "descriptors differ in their options")
+	}
+
+	return diffs
+}
+
+func compareMessages(scope string, ours, theirs []*descriptorpb.DescriptorProto) []string {
+	var diffs []string
+
+	theirsByName := make(map[string]*descriptorpb.DescriptorProto, len(theirs))
+	for _, msg := range theirs {
+		theirsByName[msg.GetName()] = msg
+	}
+
+	for _, msg := range ours {
+		name := scope + "." + msg.GetName()
+
+		other, ok := theirsByName[msg.GetName()]
+		if !ok {
+			diffs = append(diffs, fmt.Sprintf("message %s is missing there", name))
+			continue
+		}
+
+		delete(theirsByName, msg.GetName())
+
+		diffs = append(diffs, compareFields(name, msg.Field, other.Field)...)
+		diffs = append(diffs, compareMessages(name, msg.NestedType, other.NestedType)...)
+		diffs = append(diffs, compareEnums(name, msg.EnumType, other.EnumType)...)
+	}
+
+	var missing []string
+	for name := range theirsByName {
+		missing = append(missing, fmt.Sprintf("message %s.%s is missing here", scope, name))
+	}
+
+	sort.Strings(missing)
+	diffs = append(diffs, missing...)
+
+	return diffs
+}
+
+func compareFields(message string, ours, theirs []*descriptorpb.FieldDescriptorProto) []string {
+	var diffs []string
+
+	theirsByNumber := make(map[int32]*descriptorpb.FieldDescriptorProto, len(theirs))
+	for _, field := range theirs {
+		theirsByNumber[field.GetNumber()] = field
+	}
+
+	for _, field := range ours {
+		other, ok := theirsByNumber[field.GetNumber()]
+		if !ok {
+			diffs = append(diffs, fmt.Sprintf("field %d (%s) of %s is missing there", field.GetNumber(), field.GetName(), message))
+			continue
+		}
+
+		delete(theirsByNumber, field.GetNumber())
+
+		if describeField(field) != describeField(other) {
+			diffs = append(diffs, fmt.Sprintf("field %d of %s is %s here but %s there", field.GetNumber(), message, describeField(field), describeField(other)))
+		}
+	}
+
+	for _, field := range theirsByNumber {
+		diffs = append(diffs, fmt.Sprintf("field %d (%s) of %s is missing here", field.GetNumber(), field.GetName(), message))
+	}
+
+	sort.Strings(diffs)
+
+	return diffs
+}
+
+// describeField summarises everything about a field that affects its binary or JSON encoding.
+func describeField(field *descriptorpb.FieldDescriptorProto) string {
+	desc := fmt.Sprintf("%s %s %s", strings.ToLower(strings.TrimPrefix(field.GetLabel().String(), "LABEL_")), typeName(field), field.GetName())
+
+	if field.GetJsonName() != "" {
+		desc += fmt.Sprintf(" [json_name = %q]", field.GetJsonName())
+	}
+
+	if field.OneofIndex != nil {
+		desc += fmt.Sprintf(" (oneof %d)", field.GetOneofIndex())
+	}
+
+	if field.GetProto3Optional() {
+		desc += " (optional)"
+	}
+
+	return desc
+}
+
+func typeName(field *descriptorpb.FieldDescriptorProto) string {
+	if field.GetTypeName() != "" {
+		return strings.TrimPrefix(field.GetTypeName(), ".")
+	}
+
+	return strings.ToLower(strings.TrimPrefix(field.GetType().String(), "TYPE_"))
+}
+
+func compareEnums(scope string, ours, theirs []*descriptorpb.EnumDescriptorProto) []string {
+	var diffs []string
+
+	theirsByName := make(map[string]*descriptorpb.EnumDescriptorProto, len(theirs))
+	for _, enum := range theirs {
+		theirsByName[enum.GetName()] = enum
+	}
+
+	for _, enum := range ours {
+		name := scope + "." + enum.GetName()
+
+		other, ok := theirsByName[enum.GetName()]
+		if !ok {
+			diffs = append(diffs, fmt.Sprintf("enum %s is missing there", name))
+			continue
+		}
+
+		delete(theirsByName, enum.GetName())
+
+		theirValues := make(map[int32]string, len(other.Value))
+		for _, value := range other.Value {
+			theirValues[value.GetNumber()] = value.GetName()
+		}
+
+		for _, value := range enum.Value {
+			otherName, ok := theirValues[value.GetNumber()]
+			switch {
+			case !ok:
+				diffs = append(diffs, fmt.Sprintf("value %d (%s) of %s is missing there", value.GetNumber(), value.GetName(), name))
+			case otherName != value.GetName():
+				diffs = append(diffs, fmt.Sprintf("value %d of %s is %s here but %s there", value.GetNumber(), name, value.GetName(), otherName))
+			}
+
+			delete(theirValues, value.GetNumber())
+		}
+
+		for number, valueName := range theirValues {
+			diffs = append(diffs, fmt.Sprintf("value %d (%s) of %s is missing here", number, valueName, name))
+		}
+	}
+
+	var missing []string
+	for name := range theirsByName {
+		missing = append(missing, fmt.Sprintf("enum %s.%s is missing here", scope, name))
+	}
+
+	sort.Strings(missing)
+	diffs = append(diffs, missing...)
+
+	return diffs
+}
+
+func compareServices(scope string, ours, theirs []*descriptorpb.ServiceDescriptorProto) []string {
+	var diffs []string
+
+	theirsByName := make(map[string]*descriptorpb.ServiceDescriptorProto, len(theirs))
+	for _, service := range theirs {
+		theirsByName[service.GetName()] = service
+	}
+
+	for _, service := range ours {
+		name := scope + "." + service.GetName()
+
+		other, ok := theirsByName[service.GetName()]
+		if !ok {
+			diffs = append(diffs, fmt.Sprintf("service %s is missing there", name))
+			continue
+		}
+
+		delete(theirsByName, service.GetName())
+
+		theirMethods := make(map[string]*descriptorpb.MethodDescriptorProto, len(other.Method))
+		for _, method := range other.Method {
+			theirMethods[method.GetName()] = method
+		}
+
+		for _, method := range service.Method {
+			otherMethod, ok := theirMethods[method.GetName()]
+			switch {
+			case !ok:
+				diffs = append(diffs, fmt.Sprintf("method %s/%s is missing there", name, method.GetName()))
+			case describeMethod(method) != describeMethod(otherMethod):
+				diffs = append(diffs, fmt.Sprintf("method %s/%s is %s here but %s there", name, method.GetName(), describeMethod(method), describeMethod(otherMethod)))
+			}
+
+			delete(theirMethods, method.GetName())
+		}
+
+		var missing []string
+		for methodName := range theirMethods {
+			missing = append(missing, fmt.Sprintf("method %s/%s is missing here", name, methodName))
+		}
+
+		sort.Strings(missing)
+		diffs = append(diffs, missing...)
+	}
+
+	var missing []string
+	for name := range theirsByName {
+		missing = append(missing, fmt.Sprintf("service %s.%s is missing here", scope, name))
+	}
+
+	sort.Strings(missing)
+	diffs = append(diffs, missing...)
+
+	return diffs
+}
+
+// describeMethod summarises the signature of a method.
+func describeMethod(method *descriptorpb.MethodDescriptorProto) string {
+	stream := func(streaming bool, typeName string) string {
+		typeName = strings.TrimPrefix(typeName, ".")
+		if streaming {
+			return "stream " + typeName
+		}
+
+		return typeName
+	}
+
+	return fmt.Sprintf("(%s) returns (%s)", stream(method.GetClientStreaming(), method.GetInputType()), stream(method.GetServerStreaming(), method.GetOutputType()))
+}
@@ -0,0 +1,143 @@
+// Package results checks the results submitted for races against their runners and lifecycle.
+//
+// A result places some or all of a race's runners, in order of their positions. Runners that dead heat
+// share a position, and the positions they take up are skipped, so that two runners dead heating for
+// first are followed by a runner in third. Results start out interim, while they may yet change on
+// protest, and become final once they are official. Either may be amended, but a final result can only
+// be amended by another final result, and only with a reason.
+package results
+
+import (
+	"fmt"
+	"strconv"
+
+	"git.neds.sh/matty/entain/common/errs"
+	"git.neds.sh/matty/entain/racing/proto/racing"
+	"git.neds.sh/matty/entain/racing/scratchings"
+	"github.com/golang/protobuf/proto"
+)
+
+// Check returns an error explaining why a result cannot be submitted for a race, if it cannot: an
+// *errs.FailedPrecondition if the race is in no state to be given the result, or an
+// *errs.InvalidArgument listing every problem with the result itself. The race must have its runners and
+// scratchings, and current is the result the race already has, if any.
+func Check(race *racing.Race, current *racing.Result, in *racing.SubmitResultRequest) error {
+	id := strconv.FormatInt(race.Id, 10)
+
+	switch race.State {
+	case racing.Race_CLOSED, racing.Race_INTERIM, racing.Race_FINAL:
+	default:
+		return &errs.FailedPrecondition{Resource: "race", ID: id, Reason: fmt.Sprintf("%s races cannot be given a result", race.State)}
+	}
+
+	if current.GetFinal() && !in.Final {
+		return &errs.FailedPrecondition{Resource: "race", ID: id, Reason: "a final result can only be amended by another final result"}
+	}
+
+	var violations []errs.FieldViolation
+
+	violate := func(field, format string, args ...interface{}) {
+		violations = append(violations, errs.FieldViolation{Field: field, Description: fmt.Sprintf(format, args...)})
+	}
+
+	if current.GetFinal() && in.Reason == "" {
+		violate("reason", "is required to amend a final result")
+	}
+
+	if in.Final && in.Protest == racing.Result_LODGED {
+		violate("protest", "must be settled before the result is final")
+	}
+
+	violations = append(violations, placingViolations(race, in.Placings)...)
+
+	if len(violations) > 0 {
+		return &errs.InvalidArgument{Violations: violations}
+	}
+
+	return nil
+}
+
+// CheckPlacings returns an *errs.InvalidArgument listing every problem with placings of a race, if
+// there are any, as Check does for the placings of a result. The race must have its runners and
+// scratchings.
+func CheckPlacings(race *racing.Race, placings []*racing.Placing) error {
+	if violations := placingViolations(race, placings); len(violations) > 0 {
+		return &errs.InvalidArgument{Violations: violations}
+	}
+
+	return nil
+}
+
+// placingViolations returns every problem with placings of a race.
+func placingViolations(race *racing.Race, placings []*racing.Placing) []errs.FieldViolation {
+	var violations []errs.FieldViolation
+
+	violate := func(field, format string, args ...interface{}) {
+		violations = append(violations, errs.FieldViolation{Field: field, Description: fmt.Sprintf(format, args...)})
+	}
+
+	runners := make(map[int64]bool, len(race.Runners))
+	for _, runner := range race.Runners {
+		runners[runner.Number] = true
+	}
+
+	placed := make(map[int64]int)
+	scratched := scratchings.Scratched(race)
+
+	for i, placing := range placings {
+		field := fmt.Sprintf("placings[%d]", i)
+
+		if !runners[placing.RunnerNumber] {
+			violate(field+".runner_number", "%d is not a runner in the race", placing.RunnerNumber)
+		} else if scratched[placing.RunnerNumber] {
+			violate(field+".runner_number", "%d is scratched", placing.RunnerNumber)
+		} else if first, ok := placed[placing.RunnerNumber]; ok {
+			violate(field+".runner_number", "%d is already placed by placings[%d]", placing.RunnerNumber, first)
+		} else {
+			placed[placing.RunnerNumber] = i
+		}
+
+		// Each runner either dead heats with the one before it, or is placed after every runner before it.
+		deadHeat := i > 0 && placing.Position == placings[i-1].Position
+		if !deadHeat && placing.Position != int64(i+1) {
+			violate(field+".position", "must be %d, or that of the runner before it for a dead heat", i+1)
+		}
+
+		switch {
+		case placing.Margin < 0:
+			violate(field+".margin", "must not be negative")
+		case placing.Margin != 0 && (i == 0 || deadHeat):
+			violate(field+".margin", "must be 0 for a winner or a dead heat")
+		}
+	}
+
+	return violations
+}
+
+// Placings returns a copy of the placings of a result, marking those that dead heat.
+func Placings(placings []*racing.Placing) []*racing.Placing {
+	marked := make([]*racing.Placing, len(placings))
+
+	for i, placing := range placings {
+		marked[i] = proto.Clone(placing).(*racing.Placing)
+		marked[i].DeadHeat = i > 0 && placing.Position == placings[i-1].Position ||
+			i+1 < len(placings) && placing.Position == placings[i+1].Position
+	}
+
+	return marked
+}
+
+// Moves returns the states a race in the given state moves through on being given a result, in order.
+func Moves(from racing.Race_State, final bool) []racing.Race_State {
+	var moves []racing.Race_State
+
+	if from == racing.Race_CLOSED {
+		moves = append(moves, racing.Race_INTERIM)
+	}
+
+	if final && from != racing.Race_FINAL {
+		moves = append(moves, racing.Race_FINAL)
+	}
+
+	return moves
+}
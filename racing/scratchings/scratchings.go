@@ -0,0 +1,167 @@
+// Package scratchings checks the withdrawal of runners from races, and works out the deductions it
+// brings to fixed-odds bets.
+//
+// A runner scratched after bets are struck leaves the other runners with a better chance than their
+// prices allowed for, so the winnings of bets struck before the scratching are reduced by a deduction:
+// the percentage given by the standard deduction table for the last price the scratched runner had.
+// Deductions for several scratchings add up, to at most MaxDeduction.
+package scratchings
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+
+	"git.neds.sh/matty/entain/common/errs"
+	"git.neds.sh/matty/entain/racing/proto/racing"
+)
+
+// MaxDeduction is the most that can be deducted from the winnings of a bet, as a percentage, however
+// many runners are scratched after it is struck.
+const MaxDeduction = 75.0
+
+// deductionTable is the standard deduction table: the percentages deducted from the winnings of win and
+// place bets for a scratched runner whose last win price, as decimal odds, was at most the given price.
+// Runners at longer prices bring no deduction.
+var deductionTable = []struct {
+	price float64
+	win   float64
+	place float64
+}{
+	{1.10, 90, 70},
+	{1.18, 85, 65},
+	{1.25, 80, 60},
+	{1.30, 75, 55},
+	{1.40, 70, 50},
+	{1.50, 65, 45},
+	{1.65, 60, 40},
+	{1.80, 55, 35},
+	{1.95, 50, 30},
+	{2.20, 45, 25},
+	{2.50, 40, 20},
+	{2.80, 35, 20},
+	{3.40, 30, 15},
+	{4.20, 25, 15},
+	{5.50, 20, 10},
+	{7.00, 15, 10},
+	{11.00, 10, 5},
+	{15.00, 5, 5},
+}
+
+// Check returns an error explaining why a runner cannot be scratched from a race, if it cannot: an
+// *errs.FailedPrecondition if the race has jumped or the runner is already scratched, or an
+// *errs.InvalidArgument if the runner is not in the race. The race must have its runners and scratchings.
+func Check(race *racing.Race, runnerNumber int64) error {
+	id := strconv.FormatInt(race.Id, 10)
+
+	switch race.State {
+	case racing.Race_SCHEDULED, racing.Race_OPEN, racing.Race_SUSPENDED, racing.Race_POSTPONED:
+	default:
+		return &errs.FailedPrecondition{Resource: "race", ID: id, Reason: fmt.Sprintf("%s races cannot have runners scratched", race.State)}
+	}
+
+	if !isRunner(race, runnerNumber) {
+		return errs.Invalid("runner_number", "%d is not a runner in the race", runnerNumber)
+	}
+
+	if Scratched(race)[runnerNumber] {
+		return &errs.FailedPrecondition{Resource: "race", ID: id, Reason: fmt.Sprintf("runner %d is already scratched", runnerNumber)}
+	}
+
+	return nil
+}
+
+// New returns the scratching asked for, with the deductions for the last win price of the runner, whose
+// prices may be nil if it was never priced. The prices must include the runner's flucs. The scratch time is left for
+// the caller to set.
+func New(in *racing.ScratchRunnerRequest, prices *racing.RunnerPrices) *racing.Scratching {
+	scratching := &racing.Scratching{
+		RaceId:       in.RaceId,
+		RunnerNumber: in.RunnerNumber,
+		Actor:        in.Actor,
+		Reason:       in.Reason,
+	}
+
+	scratching.Price = lastPrice(prices)
+	scratching.WinDeduction, scratching.PlaceDeduction = Deduction(scratching.Price)
+
+	return scratching
+}
+
+// Deduction returns the percentages the standard deduction table deducts from win and place bets for a
+// runner scratched at the given win price, as decimal odds. A runner that was never priced, at 0, brings
+// no deduction.
+func Deduction(price float64) (win, place float64) {
+	if price <= 0 {
+		return 0, 0
+	}
+
+	for _, row := range deductionTable {
+		if price <= row.price {
+			return row.win, row.place
+		}
+	}
+
+	return 0, 0
+}
+
+// Deductions returns the percentages to deduct from the winnings of win and place bets struck at the
+// given time, for the runners scratched after it, up to MaxDeduction each.
+func Deductions(scratchings []*racing.Scratching, struck time.Time) (win, place float64) {
+	for _, scratching := range scratchings {
+		at, err := ptypes.Timestamp(scratching.ScratchTime)
+		if err != nil || !at.After(struck) {
+			continue
+		}
+
+		win += scratching.WinDeduction
+		place += scratching.PlaceDeduction
+	}
+
+	if win > MaxDeduction {
+		win = MaxDeduction
+	}
+
+	if place > MaxDeduction {
+		place = MaxDeduction
+	}
+
+	return win, place
+}
+
+// Scratched returns the numbers of the scratched runners of a race.
+func Scratched(race *racing.Race) map[int64]bool {
+	scratched := make(map[int64]bool, len(race.Scratchings))
+	for _, scratching := range race.Scratchings {
+		scratched[scratching.RunnerNumber] = true
+	}
+
+	return scratched
+}
+
+// lastPrice returns the last win price a runner had, or 0 if it never had one.
+func lastPrice(prices *racing.RunnerPrices) float64 {
+	if prices == nil {
+		return 0
+	}
+
+	// A price taken down before the scratching still counts, so look back through the flucs for it.
+	win := prices.Win.GetDecimal()
+	for i := len(prices.Flucs) - 1; i >= 0 && win == 0; i-- {
+		win = prices.Flucs[i].Win.GetDecimal()
+	}
+
+	return win
+}
+
+func isRunner(race *racing.Race, number int64) bool {
+	for _, runner := range race.Runners {
+		if runner.Number == number {
+			return true
+		}
+	}
+
+	return false
+}
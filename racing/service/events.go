@@ -0,0 +1,102 @@
+package service
+
+import (
+	"sync"
+
+	"git.neds.sh/matty/entain/racing/proto/racing"
+)
+
+// watchBuffer is how many events a watcher may fall behind by before it is disconnected.
+const watchBuffer = 256
+
+// broker fans race events out to everyone watching races.
+type broker struct {
+	mu       sync.Mutex
+	watchers map[chan *racing.RaceEvent]struct{}
+}
+
+func newBroker() *broker {
+	return &broker{watchers: make(map[chan *racing.RaceEvent]struct{})}
+}
+
+// subscribe returns a channel of the events published from now on, and a function to stop them.
+// The channel is closed if the watcher falls too far behind, as it can then no longer be told
+// about every change.
+func (b *broker) subscribe() (<-chan *racing.RaceEvent, func()) {
+	events := make(chan *racing.RaceEvent, watchBuffer)
+
+	b.mu.Lock()
+	b.watchers[events] = struct{}{}
+	b.mu.Unlock()
+
+	return events, func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+
+		if _, ok := b.watchers[events]; ok {
+			delete(b.watchers, events)
+			close(events)
+		}
+	}
+}
+
+// publish sends events to every watcher, without waiting for any of them.
+func (b *broker) publish(events ...*racing.RaceEvent) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for watcher := range b.watchers {
+		if !send(watcher, events) {
+			delete(b.watchers, watcher)
+			close(watcher)
+		}
+	}
+}
+
+// send queues events for a watcher, reporting false if it has no room left for them.
+func send(watcher chan<- *racing.RaceEvent, events []*racing.RaceEvent) bool {
+	for _, event := range events {
+		select {
+		case watcher <- event:
+		default:
+			return false
+		}
+	}
+
+	return true
+}
+
+// matches reports whether a race is one the filter asks for.
+func matches(filter *racing.ListRacesRequestFilter, race *racing.Race) bool {
+	return matchesAny(filter.GetMeetingIds(), race.GetMeetingId()) && matchesState(filter.GetStates(), race.GetState())
+}
+
+// matchesAny reports whether an ID is one of those given, or true when none are given.
+func matchesAny(ids []int64, id int64) bool {
+	if len(ids) == 0 {
+		return true
+	}
+
+	for _, want := range ids {
+		if id == want {
+			return true
+		}
+	}
+
+	return false
+}
+
+// matchesState reports whether a state is one of those given, or true when none are given.
+func matchesState(states []racing.Race_State, state racing.Race_State) bool {
+	if len(states) == 0 {
+		return true
+	}
+
+	for _, want := range states {
+		if state == want {
+			return true
+		}
+	}
+
+	return false
+}
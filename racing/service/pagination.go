@@ -0,0 +1,66 @@
+package service
+
+import (
+	"encoding/base64"
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"strconv"
+	"strings"
+
+	"git.neds.sh/matty/entain/common/errs"
+	"git.neds.sh/matty/entain/racing/proto/racing"
+)
+
+// pageTokenVersion prefixes page tokens, so that their format can change without misreading old ones.
+const pageTokenVersion = "v1"
+
+// encodePageToken returns a token continuing a listing after the race with the given ID.
+//
+// Tokens are opaque to clients. They record a fingerprint of the request's filters, so that a token
+// cannot be used to continue a different listing.
+func encodePageToken(in *racing.ListRacesRequest, afterID int64) string {
+	token := fmt.Sprintf("%s:%d:%x", pageTokenVersion, afterID, fingerprint(in))
+
+	return base64.RawURLEncoding.EncodeToString([]byte(token))
+}
+
+// decodePageToken returns the ID of the last race listed by the previous page, or zero for the first page.
+func decodePageToken(in *racing.ListRacesRequest) (int64, error) {
+	if in.PageToken == "" {
+		return 0, nil
+	}
+
+	invalid := errs.Invalid("page_token", "is invalid, or was issued for a different filter")
+
+	raw, err := base64.RawURLEncoding.DecodeString(in.PageToken)
+	if err != nil {
+		return 0, invalid
+	}
+
+	parts := strings.Split(string(raw), ":")
+	if len(parts) != 3 || parts[0] != pageTokenVersion || parts[2] != fmt.Sprintf("%x", fingerprint(in)) {
+		return 0, invalid
+	}
+
+	afterID, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil || afterID < 0 {
+		return 0, invalid
+	}
+
+	return afterID, nil
+}
+
+// fingerprint hashes the fields of a request that select which races are listed.
+func fingerprint(in *racing.ListRacesRequest) uint64 {
+	meetingIDs := append([]int64(nil), in.GetFilter().GetMeetingIds()...)
+	sort.Slice(meetingIDs, func(i, j int) bool { return meetingIDs[i] < meetingIDs[j] })
+
+	states := append([]racing.Race_State(nil), in.GetFilter().GetStates()...)
+	sort.Slice(states, func(i, j int) bool { return states[i] < states[j] })
+
+	h := fnv.New64a()
+	fmt.Fprintf(h, "%v|%v|%s", meetingIDs, states, in.FilterExpression)
+
+	return h.Sum64()
+}
@@ -1,31 +1,497 @@
 package service
 
 import (
+	"errors"
+	"io"
+	"strconv"
+
+	"git.neds.sh/matty/entain/common/errs"
 	"git.neds.sh/matty/entain/racing/db"
+	"git.neds.sh/matty/entain/racing/filtering"
+	"git.neds.sh/matty/entain/racing/lifecycle"
+	"git.neds.sh/matty/entain/racing/odds"
 	"git.neds.sh/matty/entain/racing/proto/racing"
+	"git.neds.sh/matty/entain/racing/results"
+	"git.neds.sh/matty/entain/racing/scratchings"
+	"git.neds.sh/matty/entain/racing/suspensions"
+	"git.neds.sh/matty/entain/racing/tote"
+	"git.neds.sh/matty/entain/racing/transfer"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/empty"
 	"golang.org/x/net/context"
 )
 
 type Racing interface {
 	// ListRaces will return a collection of races.
 	ListRaces(ctx context.Context, in *racing.ListRacesRequest) (*racing.ListRacesResponse, error)
+
+	// GetRace will return a single race by ID.
+	GetRace(ctx context.Context, in *racing.GetRaceRequest) (*racing.Race, error)
+
+	// CreateRace will create a race.
+	CreateRace(ctx context.Context, in *racing.CreateRaceRequest) (*racing.Race, error)
+
+	// UpdateRace will change some or all of the fields of a race.
+	UpdateRace(ctx context.Context, in *racing.UpdateRaceRequest) (*racing.Race, error)
+
+	// DeleteRace will delete a race.
+	DeleteRace(ctx context.Context, in *racing.DeleteRaceRequest) (*empty.Empty, error)
+
+	// TransitionRace will move a race to another state of its lifecycle.
+	TransitionRace(ctx context.Context, in *racing.TransitionRaceRequest) (*racing.TransitionRaceResponse, error)
+
+	// ListRaceTransitions will return the state changes of a race.
+	ListRaceTransitions(ctx context.Context, in *racing.ListRaceTransitionsRequest) (*racing.ListRaceTransitionsResponse, error)
+
+	// SubmitResult will record or amend the result of a race.
+	SubmitResult(ctx context.Context, in *racing.SubmitResultRequest) (*racing.Result, error)
+
+	// GetResult will return the result of a race, and optionally its history.
+	GetResult(ctx context.Context, in *racing.GetResultRequest) (*racing.GetResultResponse, error)
+
+	// ScratchRunner will withdraw a runner from a race, working out the deductions it brings.
+	ScratchRunner(ctx context.Context, in *racing.ScratchRunnerRequest) (*racing.ScratchRunnerResponse, error)
+
+	// UpdatePrices will set the fixed-odds prices of runners in a race.
+	UpdatePrices(ctx context.Context, in *racing.UpdatePricesRequest) (*racing.Market, error)
+
+	// GetMarket will return the fixed-odds market of a race.
+	GetMarket(ctx context.Context, in *racing.GetMarketRequest) (*racing.Market, error)
+
+	// EstimateDividends will estimate the dividends of tote pools on a race.
+	EstimateDividends(ctx context.Context, in *racing.EstimateDividendsRequest) (*racing.EstimateDividendsResponse, error)
+
+	// WatchRaces will stream changes to races as they are made.
+	WatchRaces(in *racing.WatchRacesRequest, stream racing.Racing_WatchRacesServer) error
+
+	// SearchRaces will return the races best matching a free text query.
+	SearchRaces(ctx context.Context, in *racing.SearchRacesRequest) (*racing.SearchRacesResponse, error)
+
+	// ImportRaces will create or update a stream of races, all or nothing.
+	ImportRaces(stream racing.Racing_ImportRacesServer) error
+
+	// SuspendTrading will suspend trading on a race, a meeting or all racing.
+	SuspendTrading(ctx context.Context, in *racing.SuspendTradingRequest) (*racing.Suspension, error)
+
+	// ResumeTrading will lift a suspension of trading.
+	ResumeTrading(ctx context.Context, in *racing.ResumeTradingRequest) (*racing.Suspension, error)
+
+	// ListSuspensions will return the suspensions of trading, or only those in force.
+	ListSuspensions(ctx context.Context, in *racing.ListSuspensionsRequest) (*racing.ListSuspensionsResponse, error)
+
+	// ResumeDue will lift the suspensions of trading that have reached their resume time. It is not an
+	// RPC, and is called by the server as often as it likes.
+	ResumeDue() error
 }
 
 // racingService implements the Racing interface.
 type racingService struct {
-	racesRepo db.RacesRepo
+	racesRepo       db.RacesRepo
+	marketsRepo     db.MarketsRepo
+	suspensionsRepo db.SuspensionsRepo
+	events          *broker
 }
 
 // NewRacingService instantiates and returns a new racingService.
-func NewRacingService(racesRepo db.RacesRepo) Racing {
-	return &racingService{racesRepo}
+func NewRacingService(racesRepo db.RacesRepo, marketsRepo db.MarketsRepo, suspensionsRepo db.SuspensionsRepo) Racing {
+	return &racingService{racesRepo: racesRepo, marketsRepo: marketsRepo, suspensionsRepo: suspensionsRepo, events: newBroker()}
 }
 
 func (s *racingService) ListRaces(ctx context.Context, in *racing.ListRacesRequest) (*racing.ListRacesResponse, error) {
-	races, err := s.racesRepo.List(in.Filter)
+	expr, err := filtering.Parse(in.FilterExpression)
+	if err != nil {
+		return nil, filterError(err)
+	}
+
+	afterID, err := decodePageToken(in)
+	if err != nil {
+		return nil, err
+	}
+
+	page := db.Page{AfterID: afterID}
+	if in.PageSize > 0 {
+		// One race more than asked for tells us whether there is another page.
+		page.Size = int(in.PageSize) + 1
+	}
+
+	races, err := s.racesRepo.List(in.Filter, expr, page)
+	if err != nil {
+		return nil, filterError(err)
+	}
+
+	if err := s.suspend(races...); err != nil {
+		return nil, err
+	}
+
+	resp := &racing.ListRacesResponse{Races: races}
+
+	if in.PageSize > 0 && len(races) > int(in.PageSize) {
+		resp.Races = races[:in.PageSize]
+		resp.NextPageToken = encodePageToken(in, resp.Races[len(resp.Races)-1].Id)
+	}
+
+	return resp, nil
+}
+
+func (s *racingService) GetRace(ctx context.Context, in *racing.GetRaceRequest) (*racing.Race, error) {
+	race, err := s.getRace(in.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	if race.Result, _, err = s.racesRepo.Result(race.Id, false); err != nil {
+		return nil, err
+	}
+
+	return race, nil
+}
+
+// getRace returns a race along with its runners and the suspensions in force on it.
+func (s *racingService) getRace(id int64) (*racing.Race, error) {
+	race, err := s.racesRepo.Get(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if race.Runners, err = s.racesRepo.Runners(id); err != nil {
+		return nil, err
+	}
+
+	if err := s.suspend(race); err != nil {
+		return nil, err
+	}
+
+	return race, nil
+}
+
+func (s *racingService) CreateRace(ctx context.Context, in *racing.CreateRaceRequest) (*racing.Race, error) {
+	if err := transfer.ValidateNewRace(in.Race); err != nil {
+		return nil, withPrefix("race.", err)
+	}
+
+	race, err := s.racesRepo.Create(in.Race)
+	if err != nil {
+		return nil, err
+	}
+
+	s.events.publish(&racing.RaceEvent{Type: racing.RaceEvent_CREATED, Race: race})
+
+	return race, nil
+}
+
+func (s *racingService) UpdateRace(ctx context.Context, in *racing.UpdateRaceRequest) (*racing.Race, error) {
+	race, err := s.getRace(in.Race.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := applyUpdate(race, in.Race, in.UpdateMask.GetPaths()); err != nil {
+		return nil, err
+	}
+
+	if err := transfer.ValidateRace(race); err != nil {
+		return nil, withPrefix("race.", err)
+	}
+
+	if race, err = s.racesRepo.Update(race); err != nil {
+		return nil, err
+	}
+
+	s.events.publish(&racing.RaceEvent{Type: racing.RaceEvent_UPDATED, Race: race})
+
+	return race, nil
+}
+
+func (s *racingService) DeleteRace(ctx context.Context, in *racing.DeleteRaceRequest) (*empty.Empty, error) {
+	race, err := s.racesRepo.Delete(in.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	s.events.publish(&racing.RaceEvent{Type: racing.RaceEvent_DELETED, Race: race})
+
+	return &empty.Empty{}, nil
+}
+
+func (s *racingService) TransitionRace(ctx context.Context, in *racing.TransitionRaceRequest) (*racing.TransitionRaceResponse, error) {
+	race, err := s.racesRepo.Get(in.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := lifecycle.Check(race, in.State); err != nil {
+		return nil, err
+	}
+
+	// A race with a result must have its placings, so only SubmitResult can give it one.
+	if in.State == racing.Race_INTERIM || in.State == racing.Race_FINAL {
+		return nil, errs.Invalid("state", "%s is reached by submitting a result", in.State)
+	}
+
+	race, transition, err := s.racesRepo.Transition(race.Id, race.State, in.State, in.Actor, in.Reason)
+	if err != nil {
+		return nil, err
+	}
+
+	s.events.publish(&racing.RaceEvent{Type: racing.RaceEvent_UPDATED, Race: race, Transition: transition})
+
+	return &racing.TransitionRaceResponse{Race: race, Transition: transition}, nil
+}
+
+func (s *racingService) ListRaceTransitions(ctx context.Context, in *racing.ListRaceTransitionsRequest) (*racing.ListRaceTransitionsResponse, error) {
+	transitions, err := s.racesRepo.ListTransitions(in.RaceId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &racing.ListRaceTransitionsResponse{Transitions: transitions}, nil
+}
+
+func (s *racingService) SubmitResult(ctx context.Context, in *racing.SubmitResultRequest) (*racing.Result, error) {
+	race, err := s.getRace(in.RaceId)
+	if err != nil {
+		return nil, err
+	}
+
+	current, _, err := s.racesRepo.Result(race.Id, false)
 	if err != nil {
 		return nil, err
 	}
 
-	return &racing.ListRacesResponse{Races: races}, nil
+	if err := results.Check(race, current, in); err != nil {
+		return nil, err
+	}
+
+	result, transitions, err := s.racesRepo.SaveResult(&racing.Result{
+		RaceId:   race.Id,
+		Placings: results.Placings(in.Placings),
+		Final:    in.Final,
+		Protest:  in.Protest,
+		Actor:    in.Actor,
+		Reason:   in.Reason,
+	}, current.GetVersion(), race.State, results.Moves(race.State, in.Final))
+	if err != nil {
+		return nil, err
+	}
+
+	var events []*racing.RaceEvent
+
+	for _, transition := range transitions {
+		race = proto.Clone(race).(*racing.Race)
+		race.State = transition.To
+		race.UpdateTime = transition.Time
+
+		events = append(events, &racing.RaceEvent{Type: racing.RaceEvent_UPDATED, Race: race, Transition: transition})
+	}
+
+	race = proto.Clone(race).(*racing.Race)
+	race.Result = result
+
+	s.events.publish(append(events, &racing.RaceEvent{Type: racing.RaceEvent_RESULTED, Race: race})...)
+
+	return result, nil
+}
+
+func (s *racingService) GetResult(ctx context.Context, in *racing.GetResultRequest) (*racing.GetResultResponse, error) {
+	// A race that does not exist is told apart from one without a result.
+	if _, err := s.racesRepo.Get(in.RaceId); err != nil {
+		return nil, err
+	}
+
+	result, history, err := s.racesRepo.Result(in.RaceId, in.History)
+	if err != nil {
+		return nil, err
+	}
+
+	if result == nil {
+		return nil, &errs.NotFound{Resource: "result", ID: strconv.FormatInt(in.RaceId, 10)}
+	}
+
+	return &racing.GetResultResponse{Result: result, History: history}, nil
+}
+
+func (s *racingService) ScratchRunner(ctx context.Context, in *racing.ScratchRunnerRequest) (*racing.ScratchRunnerResponse, error) {
+	race, err := s.getRace(in.RaceId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := scratchings.Check(race, in.RunnerNumber); err != nil {
+		return nil, err
+	}
+
+	priced, err := s.marketsRepo.Prices(race.Id, true)
+	if err != nil {
+		return nil, err
+	}
+
+	var last *racing.RunnerPrices
+
+	for _, prices := range priced {
+		if prices.RunnerNumber == in.RunnerNumber {
+			last = prices
+		}
+	}
+
+	scratched, scratching, err := s.racesRepo.Scratch(scratchings.New(in, last))
+	if err != nil {
+		return nil, err
+	}
+
+	scratched.Runners = race.Runners
+
+	s.events.publish(&racing.RaceEvent{Type: racing.RaceEvent_UPDATED, Race: scratched})
+
+	return &racing.ScratchRunnerResponse{Race: scratched, Scratching: scratching}, nil
+}
+
+func (s *racingService) UpdatePrices(ctx context.Context, in *racing.UpdatePricesRequest) (*racing.Market, error) {
+	race, err := s.getRace(in.RaceId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := suspensions.CheckRace(race); err != nil {
+		return nil, err
+	}
+
+	if err := odds.Check(race, in.Prices); err != nil {
+		return nil, err
+	}
+
+	if err := s.marketsRepo.Update(race.Id, in.Prices); err != nil {
+		return nil, err
+	}
+
+	return s.market(race, in.OddsFormat, false)
+}
+
+func (s *racingService) GetMarket(ctx context.Context, in *racing.GetMarketRequest) (*racing.Market, error) {
+	race, err := s.getRace(in.RaceId)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.market(race, in.OddsFormat, in.Flucs)
+}
+
+// market returns the market of a race, which must have its runners.
+func (s *racingService) market(race *racing.Race, format racing.OddsFormat, flucs bool) (*racing.Market, error) {
+	priced, err := s.marketsRepo.Prices(race.Id, flucs)
+	if err != nil {
+		return nil, err
+	}
+
+	return odds.NewMarket(race, priced, format), nil
+}
+
+func (s *racingService) EstimateDividends(ctx context.Context, in *racing.EstimateDividendsRequest) (*racing.EstimateDividendsResponse, error) {
+	race, err := s.GetRace(ctx, &racing.GetRaceRequest{Id: in.RaceId})
+	if err != nil {
+		return nil, err
+	}
+
+	return tote.EstimateDividends(race, in)
+}
+
+func (s *racingService) WatchRaces(in *racing.WatchRacesRequest, stream racing.Racing_WatchRacesServer) error {
+	events, stop := s.events.subscribe()
+	defer stop()
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		case event, ok := <-events:
+			if !ok {
+				return &errs.Unavailable{Err: errors.New("watcher fell too far behind")}
+			}
+
+			if !matches(in.Filter, event.Race) {
+				continue
+			}
+
+			if err := stream.Send(event); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (s *racingService) SearchRaces(ctx context.Context, in *racing.SearchRacesRequest) (*racing.SearchRacesResponse, error) {
+	results, err := s.racesRepo.Search(in.Query, int(in.Limit))
+	if err != nil {
+		return nil, err
+	}
+
+	races := make([]*racing.Race, len(results))
+	for i, result := range results {
+		races[i] = result.Race
+	}
+
+	if err := s.suspend(races...); err != nil {
+		return nil, err
+	}
+
+	return &racing.SearchRacesResponse{Results: results}, nil
+}
+
+// filterError reports problems with the filter expression as an invalid filter, leaving other errors as they are.
+func filterError(err error) error {
+	var filterErr *filtering.Error
+	if errors.As(err, &filterErr) {
+		return &errs.InvalidFilter{Field: "filter_expression", Pos: filterErr.Pos, Description: filterErr.Msg}
+	}
+
+	return err
+}
+
+func (s *racingService) ImportRaces(stream racing.Racing_ImportRacesServer) error {
+	var (
+		batch  transfer.Batch
+		dryRun bool
+	)
+
+	for line := 1; ; line++ {
+		in, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			return err
+		}
+
+		// Any message asking for a dry run makes the whole import a dry run, to err on the side of caution.
+		dryRun = dryRun || in.DryRun
+
+		batch.Add(line, in.Race)
+	}
+
+	if len(batch.Errors) > 0 {
+		resp := &racing.ImportRacesResponse{}
+
+		for _, lineErr := range batch.Errors {
+			resp.Errors = append(resp.Errors, &racing.ImportError{
+				Line:    int64(lineErr.Line),
+				Message: lineErr.Err.Error(),
+			})
+		}
+
+		return stream.SendAndClose(resp)
+	}
+
+	result, err := s.racesRepo.Import(batch.Races, dryRun)
+	if err != nil {
+		return err
+	}
+
+	if !dryRun {
+		s.events.publish(result.Events...)
+	}
+
+	return stream.SendAndClose(&racing.ImportRacesResponse{
+		Created: result.Created,
+		Updated: result.Updated,
+	})
 }
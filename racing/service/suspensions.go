@@ -0,0 +1,176 @@
+package service
+
+import (
+	"errors"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"golang.org/x/net/context"
+
+	"git.neds.sh/matty/entain/common/errs"
+	"git.neds.sh/matty/entain/racing/db"
+	"git.neds.sh/matty/entain/racing/proto/racing"
+	"git.neds.sh/matty/entain/racing/suspensions"
+)
+
+func (s *racingService) SuspendTrading(ctx context.Context, in *racing.SuspendTradingRequest) (*racing.Suspension, error) {
+	if err := suspensions.Check(in); err != nil {
+		return nil, err
+	}
+
+	// Meetings are only known by the races in them, so only a suspended race can be told not to exist.
+	if in.Scope == racing.Suspension_RACE {
+		if _, err := s.racesRepo.Get(in.RaceId); err != nil {
+			return nil, err
+		}
+	}
+
+	suspension, err := suspensions.New(in, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	if suspension, err = s.suspensionsRepo.Insert(suspension); err != nil {
+		return nil, err
+	}
+
+	s.announce(racing.RaceEvent_TRADING_SUSPENDED, suspension)
+
+	return suspension, nil
+}
+
+func (s *racingService) ResumeTrading(ctx context.Context, in *racing.ResumeTradingRequest) (*racing.Suspension, error) {
+	suspension, err := s.suspensionsRepo.Get(in.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+
+	if !suspensions.InForce(suspension, now) {
+		return nil, &errs.FailedPrecondition{Resource: "suspension", ID: strconv.FormatInt(in.Id, 10), Reason: "the suspension is no longer in force"}
+	}
+
+	if suspension, err = s.suspensionsRepo.Lift(in.Id, in.Actor, in.Reason, now); err != nil {
+		return nil, err
+	}
+
+	s.announce(racing.RaceEvent_TRADING_RESUMED, suspension)
+
+	return suspension, nil
+}
+
+func (s *racingService) ListSuspensions(ctx context.Context, in *racing.ListSuspensionsRequest) (*racing.ListSuspensionsResponse, error) {
+	listed, err := s.suspensionsRepo.List(in.InForce)
+	if err != nil {
+		return nil, err
+	}
+
+	if !in.InForce {
+		return &racing.ListSuspensionsResponse{Suspensions: listed}, nil
+	}
+
+	resp := &racing.ListSuspensionsResponse{}
+	now := time.Now()
+
+	for _, suspension := range listed {
+		if suspensions.InForce(suspension, now) {
+			resp.Suspensions = append(resp.Suspensions, suspension)
+		}
+	}
+
+	return resp, nil
+}
+
+func (s *racingService) ResumeDue() error {
+	unlifted, err := s.suspensionsRepo.List(true)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+
+	for _, suspension := range unlifted {
+		if !suspensions.Due(suspension, now) {
+			continue
+		}
+
+		// Trading resumed at the resume time, however long after it the suspension is lifted.
+		resumeTime, err := ptypes.Timestamp(suspension.ResumeTime)
+		if err != nil {
+			return err
+		}
+
+		lifted, err := s.suspensionsRepo.Lift(suspension.Id, suspensions.ResumeActor, "", resumeTime)
+
+		// A trader may have lifted it first, in which case they have announced it.
+		var conflict *errs.Conflict
+		if errors.As(err, &conflict) {
+			continue
+		}
+
+		if err != nil {
+			return err
+		}
+
+		s.announce(racing.RaceEvent_TRADING_RESUMED, lifted)
+	}
+
+	return nil
+}
+
+// suspend sets the suspensions of races to those in force on them now.
+func (s *racingService) suspend(races ...*racing.Race) error {
+	unlifted, err := s.suspensionsRepo.List(true)
+	if err != nil {
+		return err
+	}
+
+	suspensions.Apply(races, unlifted, time.Now())
+
+	return nil
+}
+
+// announce tells watchers about a suspension made or lifted, with an event of the given type for each
+// race it covers that has not yet run, or for the race itself when it suspends a single race. The
+// suspension is made or lifted whether or not they can be told, so failures are only logged.
+func (s *racingService) announce(eventType racing.RaceEvent_Type, suspension *racing.Suspension) {
+	races, err := s.covered(suspension)
+	if err == nil {
+		err = s.suspend(races...)
+	}
+
+	if err != nil {
+		log.Printf("failed announcing suspension %d: %s\n", suspension.Id, err)
+		return
+	}
+
+	events := make([]*racing.RaceEvent, len(races))
+	for i, race := range races {
+		events[i] = &racing.RaceEvent{Type: eventType, Race: race, Suspension: suspension}
+	}
+
+	s.events.publish(events...)
+}
+
+// covered returns the race a suspension of a single race covers, or the races that have not yet run of
+// the meeting or all racing that it covers.
+func (s *racingService) covered(suspension *racing.Suspension) ([]*racing.Race, error) {
+	if suspension.Scope == racing.Suspension_RACE {
+		race, err := s.racesRepo.Get(suspension.RaceId)
+		if err != nil {
+			return nil, err
+		}
+
+		return []*racing.Race{race}, nil
+	}
+
+	filter := &racing.ListRacesRequestFilter{States: suspensions.UnrunStates}
+	if suspension.Scope == racing.Suspension_MEETING {
+		filter.MeetingIds = []int64{suspension.MeetingId}
+	}
+
+	return s.racesRepo.List(filter, nil, db.Page{})
+}
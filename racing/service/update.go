@@ -0,0 +1,65 @@
+package service
+
+import (
+	"errors"
+
+	"git.neds.sh/matty/entain/common/errs"
+	"git.neds.sh/matty/entain/racing/proto/racing"
+)
+
+// updatableFields copy each field of a race that UpdateRace may change, by its name in an update mask.
+var updatableFields = map[string]func(dst, src *racing.Race){
+	"meeting_id":            func(dst, src *racing.Race) { dst.MeetingId = src.MeetingId },
+	"name":                  func(dst, src *racing.Race) { dst.Name = src.Name },
+	"number":                func(dst, src *racing.Race) { dst.Number = src.Number },
+	"visible":               func(dst, src *racing.Race) { dst.Visible = src.Visible },
+	"advertised_start_time": func(dst, src *racing.Race) { dst.AdvertisedStartTime = src.AdvertisedStartTime },
+	"runners":               func(dst, src *racing.Race) { dst.Runners = src.Runners },
+}
+
+// applyUpdate copies the fields named by the paths of an update mask from src to dst, or every
+// updatable field when there are none.
+func applyUpdate(dst, src *racing.Race, paths []string) error {
+	if len(paths) == 0 {
+		for _, update := range updatableFields {
+			update(dst, src)
+		}
+
+		return nil
+	}
+
+	for _, path := range paths {
+		// The ID names the race to update, so it may be in the mask, but it is never changed.
+		if path == "id" {
+			continue
+		}
+
+		update, ok := updatableFields[path]
+		if !ok {
+			return errs.Invalid("update_mask", "%q is not a field that can be updated", path)
+		}
+
+		update(dst, src)
+	}
+
+	return nil
+}
+
+// withPrefix prefixes the fields of an invalid argument error, so that they name fields of the
+// request rather than of the message that was validated.
+func withPrefix(prefix string, err error) error {
+	var invalid *errs.InvalidArgument
+	if !errors.As(err, &invalid) {
+		return err
+	}
+
+	prefixed := &errs.InvalidArgument{}
+	for _, violation := range invalid.Violations {
+		prefixed.Violations = append(prefixed.Violations, errs.FieldViolation{
+			Field:       prefix + violation.Field,
+			Description: violation.Description,
+		})
+	}
+
+	return prefixed
+}
@@ -0,0 +1,158 @@
+// Package suspensions checks and applies the suspensions of trading that traders make on a race, on every
+// race of a meeting, or on all racing at once, such as after a track incident.
+//
+// Suspensions are kept apart from the lifecycle of races: a race stays in whatever state it is in, and
+// is only suspended from trading while a suspension covering it is in force. No bets are taken on it and
+// its prices cannot be changed until every such suspension is lifted, either by a trader or, for those
+// given a duration, on reaching their resume time.
+package suspensions
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+
+	"git.neds.sh/matty/entain/common/errs"
+	"git.neds.sh/matty/entain/racing/proto/racing"
+)
+
+// ResumeActor is who suspensions that reach their resume time are lifted by.
+const ResumeActor = "auto-resume"
+
+// UnrunStates are the states of races that have not yet run, which are told when suspensions of their
+// meeting or of all racing are made and lifted.
+var UnrunStates = []racing.Race_State{racing.Race_SCHEDULED, racing.Race_OPEN, racing.Race_SUSPENDED, racing.Race_POSTPONED}
+
+// Check returns an *errs.InvalidArgument explaining what is wrong with a request to suspend trading, if
+// anything is: the race or meeting it names must match its scope, and its duration must be positive.
+func Check(in *racing.SuspendTradingRequest) error {
+	switch in.Scope {
+	case racing.Suspension_RACE:
+		if in.RaceId == 0 {
+			return errs.Invalid("race_id", "is required to suspend a race")
+		}
+
+		if in.MeetingId != 0 {
+			return errs.Invalid("meeting_id", "must not be given to suspend a race")
+		}
+	case racing.Suspension_MEETING:
+		if in.MeetingId == 0 {
+			return errs.Invalid("meeting_id", "is required to suspend a meeting")
+		}
+
+		if in.RaceId != 0 {
+			return errs.Invalid("race_id", "must not be given to suspend a meeting")
+		}
+	case racing.Suspension_GLOBAL:
+		if in.RaceId != 0 || in.MeetingId != 0 {
+			return errs.Invalid("scope", "GLOBAL suspensions cover every race, and take no race_id or meeting_id")
+		}
+	default:
+		return errs.Invalid("scope", "must be one of RACE, MEETING or GLOBAL")
+	}
+
+	if in.Duration != nil {
+		if duration, err := ptypes.Duration(in.Duration); err != nil || duration <= 0 {
+			return errs.Invalid("duration", "must be positive")
+		}
+	}
+
+	return nil
+}
+
+// New returns the suspension asked for, made at the given time, which resumes by itself once its
+// duration is up if it has one. The request must have been checked.
+func New(in *racing.SuspendTradingRequest, now time.Time) (*racing.Suspension, error) {
+	suspension := &racing.Suspension{
+		Scope:     in.Scope,
+		RaceId:    in.RaceId,
+		MeetingId: in.MeetingId,
+		Actor:     in.Actor,
+		Reason:    in.Reason,
+	}
+
+	var err error
+	if suspension.SuspendTime, err = ptypes.TimestampProto(now); err != nil {
+		return nil, err
+	}
+
+	if in.Duration != nil {
+		duration, err := ptypes.Duration(in.Duration)
+		if err != nil {
+			return nil, err
+		}
+
+		if suspension.ResumeTime, err = ptypes.TimestampProto(now.Add(duration)); err != nil {
+			return nil, err
+		}
+	}
+
+	return suspension, nil
+}
+
+// InForce reports whether a suspension is in force at the given time: it has not been lifted, and has
+// not reached its resume time if it has one.
+func InForce(suspension *racing.Suspension, now time.Time) bool {
+	if suspension.LiftTime != nil {
+		return false
+	}
+
+	if suspension.ResumeTime == nil {
+		return true
+	}
+
+	resume, err := ptypes.Timestamp(suspension.ResumeTime)
+
+	return err != nil || now.Before(resume)
+}
+
+// Due reports whether a suspension has reached its resume time at the given time, and is yet to be lifted.
+func Due(suspension *racing.Suspension, now time.Time) bool {
+	return suspension.LiftTime == nil && suspension.ResumeTime != nil && !InForce(suspension, now)
+}
+
+// Covers reports whether a suspension covers a race, whatever its state.
+func Covers(suspension *racing.Suspension, race *racing.Race) bool {
+	switch suspension.Scope {
+	case racing.Suspension_RACE:
+		return suspension.RaceId == race.Id
+	case racing.Suspension_MEETING:
+		return suspension.MeetingId == race.MeetingId
+	case racing.Suspension_GLOBAL:
+		return true
+	}
+
+	return false
+}
+
+// Apply sets the suspensions of races to those of the given suspensions that are in force at the given
+// time and cover them, in the order they are given.
+func Apply(races []*racing.Race, suspensions []*racing.Suspension, now time.Time) {
+	for _, race := range races {
+		race.Suspensions = nil
+
+		for _, suspension := range suspensions {
+			if InForce(suspension, now) && Covers(suspension, race) {
+				race.Suspensions = append(race.Suspensions, suspension)
+			}
+		}
+	}
+}
+
+// CheckRace returns an *errs.FailedPrecondition if trading on a race is suspended, naming the reason of
+// the oldest suspension in force on it. The race must have its suspensions.
+func CheckRace(race *racing.Race) error {
+	if len(race.Suspensions) == 0 {
+		return nil
+	}
+
+	suspension := race.Suspensions[0]
+
+	return &errs.FailedPrecondition{
+		Resource: "race",
+		ID:       strconv.FormatInt(race.Id, 10),
+		Reason:   fmt.Sprintf("trading is suspended: %s", suspension.Reason),
+	}
+}
@@ -0,0 +1,266 @@
+package suspensions
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"git.neds.sh/matty/entain/common/errs"
+	"git.neds.sh/matty/entain/racing/proto/racing"
+)
+
+// suspended is when the suspensions of the tests were made.
+var suspended = time.Date(2026, 11, 3, 14, 0, 0, 0, time.UTC)
+
+// at returns the time the given number of minutes after the suspensions were made.
+func at(minutes int) time.Time {
+	return suspended.Add(time.Duration(minutes) * time.Minute)
+}
+
+// timestamp returns the time the given number of minutes after the suspensions were made, as a timestamp.
+func timestamp(minutes int) *timestamppb.Timestamp {
+	ts, _ := ptypes.TimestampProto(at(minutes))
+	return ts
+}
+
+// races returns races 1 to 4, the first two at meeting 1 and the others at meeting 2.
+func races() []*racing.Race {
+	return []*racing.Race{
+		{Id: 1, MeetingId: 1, State: racing.Race_OPEN},
+		{Id: 2, MeetingId: 1, State: racing.Race_OPEN},
+		{Id: 3, MeetingId: 2, State: racing.Race_OPEN},
+		{Id: 4, MeetingId: 2, State: racing.Race_CLOSED},
+	}
+}
+
+func TestCheck(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      *racing.SuspendTradingRequest
+		wantErr bool
+	}{
+		{name: "race", in: &racing.SuspendTradingRequest{Scope: racing.Suspension_RACE, RaceId: 1}},
+		{name: "race without an ID", in: &racing.SuspendTradingRequest{Scope: racing.Suspension_RACE}, wantErr: true},
+		{name: "race with a meeting", in: &racing.SuspendTradingRequest{Scope: racing.Suspension_RACE, RaceId: 1, MeetingId: 1}, wantErr: true},
+		{name: "meeting", in: &racing.SuspendTradingRequest{Scope: racing.Suspension_MEETING, MeetingId: 1}},
+		{name: "meeting without an ID", in: &racing.SuspendTradingRequest{Scope: racing.Suspension_MEETING}, wantErr: true},
+		{name: "meeting with a race", in: &racing.SuspendTradingRequest{Scope: racing.Suspension_MEETING, RaceId: 1, MeetingId: 1}, wantErr: true},
+		{name: "global", in: &racing.SuspendTradingRequest{Scope: racing.Suspension_GLOBAL}},
+		{name: "global with a meeting", in: &racing.SuspendTradingRequest{Scope: racing.Suspension_GLOBAL, MeetingId: 1}, wantErr: true},
+		{name: "no scope", in: &racing.SuspendTradingRequest{RaceId: 1}, wantErr: true},
+		{name: "for a while", in: &racing.SuspendTradingRequest{Scope: racing.Suspension_GLOBAL, Duration: ptypes.DurationProto(time.Minute)}},
+		{name: "for no time", in: &racing.SuspendTradingRequest{Scope: racing.Suspension_GLOBAL, Duration: ptypes.DurationProto(0)}, wantErr: true},
+		{name: "for negative time", in: &racing.SuspendTradingRequest{Scope: racing.Suspension_GLOBAL, Duration: ptypes.DurationProto(-time.Minute)}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Check(tt.in)
+			if _, invalid := err.(*errs.InvalidArgument); invalid != tt.wantErr || (err != nil && !invalid) {
+				t.Errorf("Check() = %v, want invalid %t", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name       string
+		in         *racing.SuspendTradingRequest
+		wantResume *timestamppb.Timestamp
+	}{
+		{
+			name: "until lifted",
+			in:   &racing.SuspendTradingRequest{Scope: racing.Suspension_RACE, RaceId: 1, Actor: "trader", Reason: "protest"},
+		},
+		{
+			name:       "for a while",
+			in:         &racing.SuspendTradingRequest{Scope: racing.Suspension_RACE, RaceId: 1, Actor: "trader", Reason: "protest", Duration: ptypes.DurationProto(5 * time.Minute)},
+			wantResume: timestamp(5),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := New(tt.in, suspended)
+			if err != nil {
+				t.Fatalf("New() = %v", err)
+			}
+
+			want := &racing.Suspension{
+				Scope:       racing.Suspension_RACE,
+				RaceId:      1,
+				Actor:       "trader",
+				Reason:      "protest",
+				SuspendTime: timestamp(0),
+				ResumeTime:  tt.wantResume,
+			}
+
+			if !proto.Equal(got, want) {
+				t.Errorf("New() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestCovers(t *testing.T) {
+	tests := []struct {
+		name       string
+		suspension *racing.Suspension
+		want       []int64
+	}{
+		{name: "race", suspension: &racing.Suspension{Scope: racing.Suspension_RACE, RaceId: 2}, want: []int64{2}},
+		{name: "meeting", suspension: &racing.Suspension{Scope: racing.Suspension_MEETING, MeetingId: 2}, want: []int64{3, 4}},
+		{name: "global", suspension: &racing.Suspension{Scope: racing.Suspension_GLOBAL}, want: []int64{1, 2, 3, 4}},
+		{name: "unknown race", suspension: &racing.Suspension{Scope: racing.Suspension_RACE, RaceId: 9}},
+		{name: "no scope", suspension: &racing.Suspension{RaceId: 2, MeetingId: 2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []int64
+			for _, race := range races() {
+				if Covers(tt.suspension, race) {
+					got = append(got, race.Id)
+				}
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Covers() races = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInForce(t *testing.T) {
+	tests := []struct {
+		name        string
+		suspension  *racing.Suspension
+		now         time.Time
+		wantInForce bool
+		wantDue     bool
+	}{
+		{
+			name:        "until lifted",
+			suspension:  &racing.Suspension{SuspendTime: timestamp(0)},
+			now:         at(60),
+			wantInForce: true,
+		},
+		{
+			name:       "lifted",
+			suspension: &racing.Suspension{SuspendTime: timestamp(0), LiftTime: timestamp(1)},
+			now:        at(2),
+		},
+		{
+			name:        "before its resume time",
+			suspension:  &racing.Suspension{SuspendTime: timestamp(0), ResumeTime: timestamp(5)},
+			now:         at(5).Add(-time.Nanosecond),
+			wantInForce: true,
+		},
+		{
+			name:       "at its resume time",
+			suspension: &racing.Suspension{SuspendTime: timestamp(0), ResumeTime: timestamp(5)},
+			now:        at(5),
+			wantDue:    true,
+		},
+		{
+			name:       "after its resume time",
+			suspension: &racing.Suspension{SuspendTime: timestamp(0), ResumeTime: timestamp(5)},
+			now:        at(10),
+			wantDue:    true,
+		},
+		{
+			name:       "lifted before its resume time",
+			suspension: &racing.Suspension{SuspendTime: timestamp(0), ResumeTime: timestamp(5), LiftTime: timestamp(1)},
+			now:        at(10),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := InForce(tt.suspension, tt.now); got != tt.wantInForce {
+				t.Errorf("InForce() = %t, want %t", got, tt.wantInForce)
+			}
+
+			if got := Due(tt.suspension, tt.now); got != tt.wantDue {
+				t.Errorf("Due() = %t, want %t", got, tt.wantDue)
+			}
+		})
+	}
+}
+
+func TestApply(t *testing.T) {
+	race := &racing.Suspension{Id: 1, Scope: racing.Suspension_RACE, RaceId: 1, SuspendTime: timestamp(0)}
+	meeting := &racing.Suspension{Id: 2, Scope: racing.Suspension_MEETING, MeetingId: 1, SuspendTime: timestamp(0), ResumeTime: timestamp(5)}
+	global := &racing.Suspension{Id: 3, Scope: racing.Suspension_GLOBAL, SuspendTime: timestamp(0)}
+	lifted := &racing.Suspension{Id: 4, Scope: racing.Suspension_RACE, RaceId: 3, SuspendTime: timestamp(0), LiftTime: timestamp(1)}
+
+	tests := []struct {
+		name        string
+		suspensions []*racing.Suspension
+		now         time.Time
+		want        map[int64][]int64
+	}{
+		{
+			name:        "in force",
+			suspensions: []*racing.Suspension{race, meeting, lifted},
+			now:         at(2),
+			want:        map[int64][]int64{1: {1, 2}, 2: {2}},
+		},
+		{
+			name:        "resumed",
+			suspensions: []*racing.Suspension{race, meeting, lifted},
+			now:         at(5),
+			want:        map[int64][]int64{1: {1}},
+		},
+		{
+			name:        "global",
+			suspensions: []*racing.Suspension{global, race},
+			now:         at(2),
+			want:        map[int64][]int64{1: {3, 1}, 2: {3}, 3: {3}, 4: {3}},
+		},
+		{
+			name: "none",
+			now:  at(2),
+			want: map[int64][]int64{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			applied := races()
+			applied[0].Suspensions = []*racing.Suspension{lifted}
+
+			Apply(applied, tt.suspensions, tt.now)
+
+			got := make(map[int64][]int64)
+			for _, race := range applied {
+				for _, suspension := range race.Suspensions {
+					got[race.Id] = append(got[race.Id], suspension.Id)
+				}
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("suspensions by race = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckRace(t *testing.T) {
+	race := &racing.Race{Id: 1}
+	if err := CheckRace(race); err != nil {
+		t.Errorf("CheckRace() = %v, want nil for a race trading", err)
+	}
+
+	race.Suspensions = []*racing.Suspension{{Id: 1, Reason: "protest"}, {Id: 2, Reason: "track incident"}}
+
+	err := CheckRace(race)
+	if precondition, ok := err.(*errs.FailedPrecondition); !ok || precondition.Reason != "trading is suspended: protest" {
+		t.Errorf("CheckRace() = %v, want the oldest suspension's reason", err)
+	}
+}
@@ -0,0 +1,63 @@
+package tote
+
+import (
+	"sort"
+	"strings"
+)
+
+// Jurisdiction is where a tote is licensed, which sets the commission it takes from each kind of pool.
+type Jurisdiction struct {
+	// Commissions are the shares of each kind of gross pool taken by the operator.
+	Commissions map[PoolType]float64
+	// Breakage is the amount dividends are rounded down to a multiple of.
+	Breakage float64
+}
+
+// Rules returns the rules a pool of the given type is run on in the jurisdiction.
+func (j Jurisdiction) Rules(t PoolType) Rules {
+	return Rules{Commission: j.Commissions[t], Breakage: j.Breakage}
+}
+
+// jurisdictions are the jurisdictions dividends can be estimated for, by their state code. Operators
+// publish their rates, and change them from time to time, so these must be kept up to date with them.
+var jurisdictions = map[string]Jurisdiction{
+	"NSW": {
+		Commissions: map[PoolType]float64{Win: 0.1450, Place: 0.1450, Quinella: 0.1750, Exacta: 0.2000, Trifecta: 0.2000, FirstFour: 0.2250},
+		Breakage:    0.10,
+	},
+	"VIC": {
+		Commissions: map[PoolType]float64{Win: 0.1425, Place: 0.1425, Quinella: 0.1775, Exacta: 0.2000, Trifecta: 0.2100, FirstFour: 0.2300},
+		Breakage:    0.10,
+	},
+	"QLD": {
+		Commissions: map[PoolType]float64{Win: 0.1500, Place: 0.1500, Quinella: 0.1750, Exacta: 0.2000, Trifecta: 0.2100, FirstFour: 0.2200},
+		Breakage:    0.10,
+	},
+	"SA": {
+		Commissions: map[PoolType]float64{Win: 0.1600, Place: 0.1600, Quinella: 0.1800, Exacta: 0.2000, Trifecta: 0.2100, FirstFour: 0.2500},
+		Breakage:    0.05,
+	},
+	"WA": {
+		Commissions: map[PoolType]float64{Win: 0.1600, Place: 0.1600, Quinella: 0.1800, Exacta: 0.2000, Trifecta: 0.2100, FirstFour: 0.2500},
+		Breakage:    0.10,
+	},
+}
+
+// LookupJurisdiction returns the jurisdiction with the given state code, such as NSW, in any case.
+func LookupJurisdiction(code string) (Jurisdiction, bool) {
+	jurisdiction, ok := jurisdictions[strings.ToUpper(code)]
+
+	return jurisdiction, ok
+}
+
+// Jurisdictions returns the state codes of the jurisdictions dividends can be estimated for.
+func Jurisdictions() []string {
+	codes := make([]string, 0, len(jurisdictions))
+	for code := range jurisdictions {
+		codes = append(codes, code)
+	}
+
+	sort.Strings(codes)
+
+	return codes
+}
@@ -0,0 +1,98 @@
+package tote
+
+import (
+	"strconv"
+	"strings"
+
+	"git.neds.sh/matty/entain/common/errs"
+	"git.neds.sh/matty/entain/racing/proto/racing"
+	"git.neds.sh/matty/entain/racing/results"
+)
+
+// EstimateDividends estimates the dividends of the pools of a request on a race, which must have its
+// runners and scratchings. The runners scratched from the race are refunded along with any the request
+// gives. Unless the request gives placings, they are those of the race's result, and the race must have
+// one.
+func EstimateDividends(race *racing.Race, in *racing.EstimateDividendsRequest) (*racing.EstimateDividendsResponse, error) {
+	jurisdiction, ok := LookupJurisdiction(in.Jurisdiction)
+	if !ok {
+		return nil, errs.Invalid("jurisdiction", "%q is not a jurisdiction, expected one of %s",
+			in.Jurisdiction, strings.Join(Jurisdictions(), ", "))
+	}
+
+	placings := in.Placings
+	if len(placings) == 0 {
+		if race.Result == nil {
+			return nil, &errs.FailedPrecondition{
+				Resource: "race",
+				ID:       strconv.FormatInt(race.Id, 10),
+				Reason:   "races without a result need placings to estimate dividends for",
+			}
+		}
+
+		placings = race.Result.Placings
+	} else if err := results.CheckPlacings(race, placings); err != nil {
+		return nil, err
+	}
+
+	toteRace := Race{Scratched: in.Scratched}
+
+	for _, scratching := range race.Scratchings {
+		if !contains(in.Scratched, scratching.RunnerNumber) {
+			toteRace.Scratched = append(toteRace.Scratched, scratching.RunnerNumber)
+		}
+	}
+
+	for _, runner := range race.Runners {
+		toteRace.Runners = append(toteRace.Runners, runner.Number)
+	}
+
+	for _, placing := range placings {
+		toteRace.Placings = append(toteRace.Placings, Placing{Position: placing.Position, Runner: placing.RunnerNumber})
+	}
+
+	pools := make([]Pool, len(in.Pools))
+
+	for i, pool := range in.Pools {
+		pools[i] = Pool{Type: PoolType(pool.Type), Total: pool.Total}
+
+		for _, investment := range pool.Investments {
+			pools[i].Investments = append(pools[i].Investments, Investment{Runners: investment.Runners, Amount: investment.Amount})
+		}
+	}
+
+	if err := Check(toteRace, pools); err != nil {
+		return nil, err
+	}
+
+	resp := &racing.EstimateDividendsResponse{RaceId: race.Id, Jurisdiction: strings.ToUpper(in.Jurisdiction)}
+
+	for _, pool := range pools {
+		rules := jurisdiction.Rules(pool.Type)
+		estimate := Estimate(toteRace, pool, rules)
+
+		dividends := &racing.PoolDividends{
+			Type:           racing.Pool_Type(estimate.Type),
+			Outcome:        racing.PoolDividends_Outcome(estimate.Outcome),
+			Total:          estimate.Total,
+			Refunded:       estimate.Refunded,
+			CommissionRate: rules.Commission,
+			Commission:     estimate.Commission,
+			Net:            estimate.Net,
+			Breakage:       estimate.Breakage,
+			Jackpot:        estimate.Jackpot,
+		}
+
+		for _, dividend := range estimate.Dividends {
+			dividends.Dividends = append(dividends.Dividends, &racing.Dividend{
+				Runners:    dividend.Runners,
+				Amount:     dividend.Amount,
+				Investment: dividend.Investment,
+			})
+		}
+
+		resp.Pools = append(resp.Pools, dividends)
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,522 @@
+// Package tote estimates the dividends of tote pools, in which everyone betting on a race shares in
+// what everyone else stakes.
+//
+// The stakes invested in a pool, less those refunded on scratched runners, make up its gross pool. The
+// operator takes a commission from it, and the rest, the net pool, goes to the winning combinations. Each
+// gets its stake back and a share of what the losing combinations invested, and these are shared equally
+// between the winning combinations, so that dead heats halve the profit of each runner in them. Each
+// dividend is then rounded down to the breakage of the jurisdiction, such as 10 cents, and the operator
+// keeps the difference. A pool that no one has invested in the winning combinations of jackpots, carrying
+// its net pool over to a later pool, and a pool that cannot be decided, such as a trifecta with fewer
+// than three starters, is refunded.
+//
+// Dividends are for a unit of $1, and so the return on a stake of $1 if it wins.
+package tote
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"git.neds.sh/matty/entain/common/errs"
+)
+
+// Minimum is the smallest dividend paid: the tote never returns less than the stake.
+const Minimum = 1.0
+
+// PoolType is the kind of bet a pool takes.
+type PoolType int
+
+const (
+	// Win pays the runners that finish first.
+	Win PoolType = iota + 1
+	// Place pays the runners that finish in the places: the first three when there are eight or more
+	// starters, and the first two when there are five to seven.
+	Place
+	// Quinella pays the first two runners, in either order.
+	Quinella
+	// Exacta pays the first two runners, in order.
+	Exacta
+	// Trifecta pays the first three runners, in order.
+	Trifecta
+	// FirstFour pays the first four runners, in order.
+	FirstFour
+)
+
+func (t PoolType) String() string {
+	switch t {
+	case Win:
+		return "win"
+	case Place:
+		return "place"
+	case Quinella:
+		return "quinella"
+	case Exacta:
+		return "exacta"
+	case Trifecta:
+		return "trifecta"
+	case FirstFour:
+		return "first four"
+	}
+
+	return fmt.Sprintf("PoolType(%d)", int(t))
+}
+
+// Legs returns how many runners a combination of the pool names.
+func (t PoolType) Legs() int {
+	switch t {
+	case Quinella, Exacta:
+		return 2
+	case Trifecta:
+		return 3
+	case FirstFour:
+		return 4
+	}
+
+	return 1
+}
+
+// Pool is what was invested in a pool, combination by combination.
+type Pool struct {
+	Type PoolType
+	// Total is the gross amount invested, before refunds. It defaults to the sum of the investments, and
+	// may be more when they are only the combinations of interest.
+	Total float64
+	// Investments are the amounts invested in each combination, which may only be given once.
+	Investments []Investment
+}
+
+// Investment is the amount invested in a combination of runners.
+type Investment struct {
+	// Runners are the numbers of the runners, in the order they must finish for ordered pools.
+	Runners []int64
+	Amount  float64
+}
+
+// Placing is where a runner finished. Runners that dead heat share a position, and the positions they
+// take up are skipped, so that a dead heat for first is followed by third.
+type Placing struct {
+	Position int64
+	Runner   int64
+}
+
+// Race is what a pool is decided on.
+type Race struct {
+	// Runners are the numbers of every runner entered in the race, including those scratched.
+	Runners []int64
+	// Scratched are the numbers of the runners withdrawn from the race, whose investments are refunded.
+	Scratched []int64
+	// Placings are the placed runners of the result, in order of their positions.
+	Placings []Placing
+}
+
+// Rules are the terms a pool is run on.
+type Rules struct {
+	// Commission is the share of the gross pool the operator takes, such as 0.145 for 14.5%.
+	Commission float64
+	// Breakage is the amount dividends are rounded down to a multiple of, such as 0.10.
+	Breakage float64
+}
+
+// Outcome is how a pool was decided.
+type Outcome int
+
+const (
+	// Paid pools pay dividends on their winning combinations.
+	Paid Outcome = iota + 1
+	// Jackpot pools had nothing invested in their winning combinations, and carry their net pool over.
+	Jackpot
+	// Refunded pools cannot be decided, and return every investment.
+	Refunded
+)
+
+// Dividends are how a pool was decided, and what it pays.
+type Dividends struct {
+	Type    PoolType
+	Outcome Outcome
+	// Total is the gross amount invested in the pool, before refunds.
+	Total float64
+	// Refunded is the amount returned to investors, on scratched runners or for the whole pool.
+	Refunded float64
+	// Commission is the amount the operator takes.
+	Commission float64
+	// Net is the amount left to pay the winning combinations.
+	Net float64
+	// Dividends are what each winning combination that was invested in pays.
+	Dividends []Dividend
+	// Breakage is what the rounding of the dividends kept from the net pool. It is negative if the
+	// minimum dividend paid out more than the net pool.
+	Breakage float64
+	// Jackpot is the net pool carried over, if no one won it.
+	Jackpot float64
+}
+
+// Dividend is what a winning combination pays.
+type Dividend struct {
+	// Runners are the numbers of the runners, in the order they finished for ordered pools.
+	Runners []int64
+	// Amount is the return on a unit of $1.
+	Amount float64
+	// Investment is the amount invested in the combination.
+	Investment float64
+}
+
+// Check returns an *errs.InvalidArgument listing every problem with estimating the dividends of pools
+// on a race, if there are any. The fields it names are those of the EstimateDividends request.
+func Check(race Race, pools []Pool) error {
+	var violations []errs.FieldViolation
+
+	violate := func(field, format string, args ...interface{}) {
+		violations = append(violations, errs.FieldViolation{Field: field, Description: fmt.Sprintf(format, args...)})
+	}
+
+	runners := make(map[int64]bool, len(race.Runners))
+	for _, runner := range race.Runners {
+		runners[runner] = true
+	}
+
+	placed := make(map[int64]bool, len(race.Placings))
+	for _, placing := range race.Placings {
+		placed[placing.Runner] = true
+	}
+
+	scratched := make(map[int64]bool, len(race.Scratched))
+
+	for i, runner := range race.Scratched {
+		field := fmt.Sprintf("scratched[%d]", i)
+
+		switch {
+		case !runners[runner]:
+			violate(field, "%d is not a runner in the race", runner)
+		case scratched[runner]:
+			violate(field, "%d is already scratched", runner)
+		case placed[runner]:
+			violate(field, "%d is placed in the result", runner)
+		}
+
+		scratched[runner] = true
+	}
+
+	starters := len(race.Runners) - len(scratched)
+	types := make(map[PoolType]int)
+
+	for i, pool := range pools {
+		field := fmt.Sprintf("pools[%d]", i)
+
+		if pool.Type < Win || pool.Type > FirstFour {
+			violate(field+".type", "must be set")
+			continue
+		}
+
+		if first, ok := types[pool.Type]; ok {
+			violate(field+".type", "%s is already estimated by pools[%d]", pool.Type, first)
+		} else {
+			types[pool.Type] = i
+		}
+
+		combinations := make(map[string]int)
+
+		var staked float64
+
+		for j, investment := range pool.Investments {
+			field := fmt.Sprintf("%s.investments[%d]", field, j)
+
+			if len(investment.Runners) != pool.Type.Legs() {
+				violate(field+".runners", "a %s combination must name %d runners", pool.Type, pool.Type.Legs())
+			}
+
+			named := make(map[int64]bool, len(investment.Runners))
+			for _, runner := range investment.Runners {
+				switch {
+				case !runners[runner]:
+					violate(field+".runners", "%d is not a runner in the race", runner)
+				case named[runner]:
+					violate(field+".runners", "%d is named more than once", runner)
+				}
+
+				named[runner] = true
+			}
+
+			key := combinationKey(pool.Type, investment.Runners)
+			if first, ok := combinations[key]; ok {
+				violate(field+".runners", "the combination is already invested in by investments[%d]", first)
+			} else {
+				combinations[key] = j
+			}
+
+			if !(investment.Amount > 0) || math.IsInf(investment.Amount, 0) {
+				violate(field+".amount", "must be greater than 0")
+			}
+
+			staked += investment.Amount
+		}
+
+		switch {
+		case pool.Total < 0 || math.IsNaN(pool.Total) || math.IsInf(pool.Total, 0):
+			violate(field+".total", "must not be negative")
+		case pool.Total > 0 && pool.Total < staked:
+			violate(field+".total", "must be at least the %.2f invested in its combinations", staked)
+		}
+
+		if needed := placesDecided(pool.Type, starters); needed > len(race.Placings) {
+			violate("placings", "must place at least %d runners to decide a %s pool", needed, pool.Type)
+		}
+	}
+
+	if len(violations) > 0 {
+		return &errs.InvalidArgument{Violations: violations}
+	}
+
+	return nil
+}
+
+// Estimate returns the dividends of a pool on a race, under the given rules. The pool must have passed
+// Check.
+func Estimate(race Race, pool Pool, rules Rules) Dividends {
+	scratched := make(map[int64]bool, len(race.Scratched))
+	for _, runner := range race.Scratched {
+		scratched[runner] = true
+	}
+
+	dividends := Dividends{Type: pool.Type, Total: pool.Total}
+	invested := make(map[string]float64, len(pool.Investments))
+
+	var staked float64
+
+	for _, investment := range pool.Investments {
+		staked += investment.Amount
+
+		if refunded(investment.Runners, scratched) {
+			dividends.Refunded += investment.Amount
+		} else {
+			invested[combinationKey(pool.Type, investment.Runners)] += investment.Amount
+		}
+	}
+
+	if dividends.Total == 0 {
+		dividends.Total = staked
+	}
+
+	dividends.Total = cents(dividends.Total)
+
+	if placesDecided(pool.Type, len(race.Runners)-len(race.Scratched)) == 0 {
+		dividends.Outcome = Refunded
+		dividends.Refunded = dividends.Total
+
+		return dividends
+	}
+
+	dividends.Refunded = cents(dividends.Refunded)
+	gross := dividends.Total - dividends.Refunded
+	dividends.Commission = cents(gross * rules.Commission)
+	dividends.Net = cents(gross - dividends.Commission)
+
+	var (
+		winners []winner
+		weights float64
+		stakes  float64
+	)
+
+	for _, winner := range winningCombinations(pool.Type, race) {
+		winner.investment = invested[combinationKey(pool.Type, winner.runners)]
+		if winner.investment > 0 {
+			winners = append(winners, winner)
+			weights += winner.weight
+			stakes += winner.investment
+		}
+	}
+
+	if len(winners) == 0 {
+		dividends.Outcome = Jackpot
+		dividends.Jackpot = dividends.Net
+
+		return dividends
+	}
+
+	dividends.Outcome = Paid
+	profit := dividends.Net - stakes
+	paid := 0.0
+
+	for _, winner := range winners {
+		amount := breakDown(1+profit*winner.weight/weights/winner.investment, rules.Breakage)
+		if amount < Minimum {
+			amount = Minimum
+		}
+
+		dividends.Dividends = append(dividends.Dividends, Dividend{
+			Runners:    winner.runners,
+			Amount:     amount,
+			Investment: cents(winner.investment),
+		})
+
+		paid += amount * winner.investment
+	}
+
+	dividends.Breakage = cents(dividends.Net - paid)
+
+	return dividends
+}
+
+// winner is a winning combination, and the share of the net pool it takes relative to the others.
+type winner struct {
+	runners    []int64
+	weight     float64
+	investment float64
+}
+
+// winningCombinations returns the winning combinations of a pool, before it is known which were
+// invested in.
+func winningCombinations(t PoolType, race Race) []winner {
+	// A runner in a dead heat takes up each of the positions of the dead heat.
+	shared := make(map[int64]int64)
+	for _, placing := range race.Placings {
+		shared[placing.Position]++
+	}
+
+	covers := func(placing Placing, position int64) bool {
+		return placing.Position <= position && position < placing.Position+shared[placing.Position]
+	}
+
+	if t == Place {
+		// Each place is a share of the pool, split between the runners that dead heat for it.
+		places := int64(placesDecided(Place, len(race.Runners)-len(race.Scratched)))
+
+		var winners []winner
+
+		for _, placing := range race.Placings {
+			var weight float64
+
+			for position := int64(1); position <= places; position++ {
+				if covers(placing, position) {
+					weight += 1 / float64(shared[placing.Position])
+				}
+			}
+
+			if weight > 0 {
+				winners = append(winners, winner{runners: []int64{placing.Runner}, weight: weight})
+			}
+		}
+
+		return winners
+	}
+
+	// The winning orders are those in which every runner finishes in a position it takes up.
+	var orders [][]int64
+
+	var order func(runners []int64)
+	order = func(runners []int64) {
+		if len(runners) == t.Legs() {
+			orders = append(orders, append([]int64(nil), runners...))
+			return
+		}
+
+		position := int64(len(runners) + 1)
+
+		for _, placing := range race.Placings {
+			if covers(placing, position) && !contains(runners, placing.Runner) {
+				order(append(runners, placing.Runner))
+			}
+		}
+	}
+
+	order(nil)
+
+	var (
+		winners []winner
+		seen    = make(map[string]bool)
+	)
+
+	for _, runners := range orders {
+		if t == Quinella {
+			sortRunners(runners)
+		}
+
+		if key := combinationKey(t, runners); !seen[key] {
+			seen[key] = true
+			winners = append(winners, winner{runners: runners, weight: 1})
+		}
+	}
+
+	return winners
+}
+
+// placesDecided returns how many placings it takes to decide a pool with the given number of starters,
+// or 0 if the pool cannot be decided and is refunded.
+func placesDecided(t PoolType, starters int) int {
+	if t == Place {
+		switch {
+		case starters >= 8:
+			return 3
+		case starters >= 5:
+			return 2
+		default:
+			return 0
+		}
+	}
+
+	if starters < t.Legs() {
+		return 0
+	}
+
+	return t.Legs()
+}
+
+// combinationKey identifies a combination of runners in a pool, in which the order of the runners only
+// matters if the pool is ordered.
+func combinationKey(t PoolType, runners []int64) string {
+	if t == Quinella {
+		runners = append([]int64(nil), runners...)
+		sortRunners(runners)
+	}
+
+	numbers := make([]string, len(runners))
+	for i, runner := range runners {
+		numbers[i] = strconv.FormatInt(runner, 10)
+	}
+
+	return strings.Join(numbers, "-")
+}
+
+// refunded returns whether a combination names a scratched runner.
+func refunded(runners []int64, scratched map[int64]bool) bool {
+	for _, runner := range runners {
+		if scratched[runner] {
+			return true
+		}
+	}
+
+	return false
+}
+
+// breakDown rounds a dividend down to a multiple of the breakage.
+func breakDown(amount, breakage float64) float64 {
+	if breakage <= 0 {
+		return cents(amount)
+	}
+
+	// Working in cents, with a little slack, keeps float error from rounding a dividend down a step.
+	step := math.Round(breakage * 100)
+
+	return math.Floor(amount*100/step+1e-6) * step / 100
+}
+
+// cents rounds an amount to the nearest cent.
+func cents(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
+
+func contains(runners []int64, runner int64) bool {
+	for _, r := range runners {
+		if r == runner {
+			return true
+		}
+	}
+
+	return false
+}
+
+func sortRunners(runners []int64) {
+	sort.Slice(runners, func(i, j int) bool { return runners[i] < runners[j] })
+}
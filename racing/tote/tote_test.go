@@ -0,0 +1,371 @@
+package tote
+
+import (
+	"reflect"
+	"testing"
+
+	"git.neds.sh/matty/entain/common/errs"
+)
+
+// runners returns the numbers of a field of n runners, from 1 to n.
+func runners(n int64) []int64 {
+	numbers := make([]int64, n)
+	for i := range numbers {
+		numbers[i] = int64(i + 1)
+	}
+
+	return numbers
+}
+
+// placed returns placings of runners in order, each a length or more clear of the next.
+func placed(numbers ...int64) []Placing {
+	placings := make([]Placing, len(numbers))
+	for i, number := range numbers {
+		placings[i] = Placing{Position: int64(i + 1), Runner: number}
+	}
+
+	return placings
+}
+
+// on returns an investment in a combination of runners.
+func on(amount float64, numbers ...int64) Investment {
+	return Investment{Runners: numbers, Amount: amount}
+}
+
+func TestEstimate(t *testing.T) {
+	nsw := Rules{Commission: 0.145, Breakage: 0.10}
+
+	tests := []struct {
+		name  string
+		race  Race
+		pool  Pool
+		rules Rules
+		want  Dividends
+	}{
+		{
+			name:  "win",
+			race:  Race{Runners: runners(10), Placings: placed(1, 2, 3)},
+			pool:  Pool{Type: Win, Investments: []Investment{on(2000, 1), on(3000, 2), on(5000, 3)}},
+			rules: nsw,
+			want: Dividends{
+				Type: Win, Outcome: Paid, Total: 10000, Commission: 1450, Net: 8550,
+				Dividends: []Dividend{{Runners: []int64{1}, Amount: 4.20, Investment: 2000}},
+				Breakage:  150,
+			},
+		},
+		{
+			name:  "win with breakage to 5 cents",
+			race:  Race{Runners: runners(10), Placings: placed(1, 2, 3)},
+			pool:  Pool{Type: Win, Investments: []Investment{on(2000, 1), on(3000, 2), on(5000, 3)}},
+			rules: Rules{Commission: 0.145, Breakage: 0.05},
+			want: Dividends{
+				Type: Win, Outcome: Paid, Total: 10000, Commission: 1450, Net: 8550,
+				Dividends: []Dividend{{Runners: []int64{1}, Amount: 4.25, Investment: 2000}},
+				Breakage:  50,
+			},
+		},
+		{
+			name:  "win with a total beyond the combinations given",
+			race:  Race{Runners: runners(10), Placings: placed(1, 2, 3)},
+			pool:  Pool{Type: Win, Total: 20000, Investments: []Investment{on(2000, 1)}},
+			rules: nsw,
+			want: Dividends{
+				Type: Win, Outcome: Paid, Total: 20000, Commission: 2900, Net: 17100,
+				Dividends: []Dividend{{Runners: []int64{1}, Amount: 8.50, Investment: 2000}},
+				Breakage:  100,
+			},
+		},
+		{
+			name: "win dead heat",
+			race: Race{Runners: runners(10), Placings: []Placing{{1, 1}, {1, 2}, {3, 3}}},
+			pool: Pool{Type: Win, Investments: []Investment{on(2000, 1), on(3000, 2), on(5000, 3)}},
+			// Each winner gets its stake back and half of the 3550 profit.
+			rules: nsw,
+			want: Dividends{
+				Type: Win, Outcome: Paid, Total: 10000, Commission: 1450, Net: 8550,
+				Dividends: []Dividend{
+					{Runners: []int64{1}, Amount: 1.80, Investment: 2000},
+					{Runners: []int64{2}, Amount: 1.50, Investment: 3000},
+				},
+				Breakage: 450,
+			},
+		},
+		{
+			name:  "win refunds scratched runners",
+			race:  Race{Runners: runners(10), Scratched: []int64{5}, Placings: placed(1, 2, 3)},
+			pool:  Pool{Type: Win, Investments: []Investment{on(2000, 1), on(3000, 2), on(1000, 5)}},
+			rules: nsw,
+			want: Dividends{
+				Type: Win, Outcome: Paid, Total: 6000, Refunded: 1000, Commission: 725, Net: 4275,
+				Dividends: []Dividend{{Runners: []int64{1}, Amount: 2.10, Investment: 2000}},
+				Breakage:  75,
+			},
+		},
+		{
+			name:  "place pays three places with eight or more starters",
+			race:  Race{Runners: runners(10), Placings: placed(1, 2, 3)},
+			pool:  Pool{Type: Place, Investments: []Investment{on(1000, 1), on(2000, 2), on(3000, 3), on(4000, 4)}},
+			rules: nsw,
+			want: Dividends{
+				Type: Place, Outcome: Paid, Total: 10000, Commission: 1450, Net: 8550,
+				Dividends: []Dividend{
+					{Runners: []int64{1}, Amount: 1.80, Investment: 1000},
+					{Runners: []int64{2}, Amount: 1.40, Investment: 2000},
+					{Runners: []int64{3}, Amount: 1.20, Investment: 3000},
+				},
+				Breakage: 350,
+			},
+		},
+		{
+			name:  "place pays two places with seven starters",
+			race:  Race{Runners: runners(7), Placings: placed(1, 2, 3)},
+			pool:  Pool{Type: Place, Investments: []Investment{on(1000, 1), on(2000, 2), on(3000, 3), on(4000, 4)}},
+			rules: nsw,
+			want: Dividends{
+				Type: Place, Outcome: Paid, Total: 10000, Commission: 1450, Net: 8550,
+				Dividends: []Dividend{
+					{Runners: []int64{1}, Amount: 3.70, Investment: 1000},
+					{Runners: []int64{2}, Amount: 2.30, Investment: 2000},
+				},
+				Breakage: 250,
+			},
+		},
+		{
+			name: "place dead heat for third",
+			race: Race{Runners: runners(10), Placings: []Placing{{1, 1}, {2, 2}, {3, 3}, {3, 4}}},
+			pool: Pool{Type: Place, Investments: []Investment{on(1000, 1), on(2000, 2), on(3000, 3), on(4000, 4), on(10000, 5)}},
+			// The dead heaters share the third of the 7100 profit that third place takes.
+			rules: nsw,
+			want: Dividends{
+				Type: Place, Outcome: Paid, Total: 20000, Commission: 2900, Net: 17100,
+				Dividends: []Dividend{
+					{Runners: []int64{1}, Amount: 3.30, Investment: 1000},
+					{Runners: []int64{2}, Amount: 2.10, Investment: 2000},
+					{Runners: []int64{3}, Amount: 1.30, Investment: 3000},
+					{Runners: []int64{4}, Amount: 1.20, Investment: 4000},
+				},
+				Breakage: 900,
+			},
+		},
+		{
+			name:  "place minus pool pays the minimum dividend",
+			race:  Race{Runners: runners(10), Placings: placed(1, 2, 3)},
+			pool:  Pool{Type: Place, Investments: []Investment{on(9000, 1), on(500, 2), on(500, 3)}},
+			rules: nsw,
+			want: Dividends{
+				Type: Place, Outcome: Paid, Total: 10000, Commission: 1450, Net: 8550,
+				Dividends: []Dividend{
+					{Runners: []int64{1}, Amount: 1, Investment: 9000},
+					{Runners: []int64{2}, Amount: 1, Investment: 500},
+					{Runners: []int64{3}, Amount: 1, Investment: 500},
+				},
+				Breakage: -1450,
+			},
+		},
+		{
+			name:  "place refunded with four starters",
+			race:  Race{Runners: runners(6), Scratched: []int64{5, 6}, Placings: placed(1, 2)},
+			pool:  Pool{Type: Place, Investments: []Investment{on(100, 1), on(100, 2)}},
+			rules: nsw,
+			want:  Dividends{Type: Place, Outcome: Refunded, Total: 200, Refunded: 200},
+		},
+		{
+			name:  "quinella in either order",
+			race:  Race{Runners: runners(10), Placings: placed(1, 2, 3)},
+			pool:  Pool{Type: Quinella, Investments: []Investment{on(500, 2, 1), on(300, 2, 3), on(200, 1, 3)}},
+			rules: Rules{Commission: 0.175, Breakage: 0.10},
+			want: Dividends{
+				Type: Quinella, Outcome: Paid, Total: 1000, Commission: 175, Net: 825,
+				Dividends: []Dividend{{Runners: []int64{1, 2}, Amount: 1.60, Investment: 500}},
+				Breakage:  25,
+			},
+		},
+		{
+			name:  "quinella dead heat for second",
+			race:  Race{Runners: runners(10), Placings: []Placing{{1, 1}, {2, 2}, {2, 3}}},
+			pool:  Pool{Type: Quinella, Investments: []Investment{on(400, 1, 2), on(100, 1, 3), on(500, 2, 3)}},
+			rules: Rules{Commission: 0.175, Breakage: 0.10},
+			want: Dividends{
+				Type: Quinella, Outcome: Paid, Total: 1000, Commission: 175, Net: 825,
+				Dividends: []Dividend{
+					{Runners: []int64{1, 2}, Amount: 1.40, Investment: 400},
+					{Runners: []int64{1, 3}, Amount: 2.60, Investment: 100},
+				},
+				Breakage: 5,
+			},
+		},
+		{
+			name:  "exacta dead heat for first pays both orders",
+			race:  Race{Runners: runners(10), Placings: []Placing{{1, 1}, {1, 2}, {3, 3}}},
+			pool:  Pool{Type: Exacta, Investments: []Investment{on(300, 1, 2), on(100, 2, 1), on(600, 1, 3)}},
+			rules: Rules{Commission: 0.20, Breakage: 0.10},
+			want: Dividends{
+				Type: Exacta, Outcome: Paid, Total: 1000, Commission: 200, Net: 800,
+				Dividends: []Dividend{
+					{Runners: []int64{1, 2}, Amount: 1.60, Investment: 300},
+					{Runners: []int64{2, 1}, Amount: 3.00, Investment: 100},
+				},
+				Breakage: 20,
+			},
+		},
+		{
+			name:  "trifecta",
+			race:  Race{Runners: runners(10), Placings: placed(4, 7, 2)},
+			pool:  Pool{Type: Trifecta, Total: 5003, Investments: []Investment{on(10, 4, 7, 2), on(50, 7, 4, 2), on(40, 4, 7, 1)}},
+			rules: Rules{Commission: 0.20, Breakage: 0.10},
+			want: Dividends{
+				Type: Trifecta, Outcome: Paid, Total: 5003, Commission: 1000.6, Net: 4002.4,
+				Dividends: []Dividend{{Runners: []int64{4, 7, 2}, Amount: 400.20, Investment: 10}},
+				Breakage:  0.4,
+			},
+		},
+		{
+			name:  "first four jackpots when no one wins it",
+			race:  Race{Runners: runners(10), Placings: placed(1, 2, 3, 4)},
+			pool:  Pool{Type: FirstFour, Investments: []Investment{on(100, 4, 3, 2, 1)}},
+			rules: Rules{Commission: 0.225, Breakage: 0.10},
+			want:  Dividends{Type: FirstFour, Outcome: Jackpot, Total: 100, Commission: 22.5, Net: 77.5, Jackpot: 77.5},
+		},
+		{
+			name:  "trifecta refunds combinations with scratched runners",
+			race:  Race{Runners: runners(10), Scratched: []int64{9}, Placings: placed(1, 2, 3)},
+			pool:  Pool{Type: Trifecta, Investments: []Investment{on(10, 1, 2, 3), on(90, 1, 2, 9), on(100, 3, 2, 1)}},
+			rules: Rules{Commission: 0.20, Breakage: 0.10},
+			want: Dividends{
+				Type: Trifecta, Outcome: Paid, Total: 200, Refunded: 90, Commission: 22, Net: 88,
+				Dividends: []Dividend{{Runners: []int64{1, 2, 3}, Amount: 8.80, Investment: 10}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := Check(tt.race, []Pool{tt.pool}); err != nil {
+				t.Fatalf("Check() = %v", err)
+			}
+
+			if got := Estimate(tt.race, tt.pool, tt.rules); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Estimate() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheck(t *testing.T) {
+	tests := []struct {
+		name  string
+		race  Race
+		pools []Pool
+		want  []errs.FieldViolation
+	}{
+		{
+			name:  "valid",
+			race:  Race{Runners: runners(10), Placings: placed(1, 2, 3, 4)},
+			pools: []Pool{{Type: Win, Investments: []Investment{on(10, 1)}}, {Type: FirstFour, Investments: []Investment{on(10, 1, 2, 3, 4)}}},
+		},
+		{
+			name:  "type unset",
+			race:  Race{Runners: runners(10), Placings: placed(1)},
+			pools: []Pool{{Investments: []Investment{on(10, 1)}}},
+			want:  []errs.FieldViolation{{Field: "pools[0].type", Description: "must be set"}},
+		},
+		{
+			name:  "type repeated",
+			race:  Race{Runners: runners(10), Placings: placed(1)},
+			pools: []Pool{{Type: Win}, {Type: Win}},
+			want:  []errs.FieldViolation{{Field: "pools[1].type", Description: "win is already estimated by pools[0]"}},
+		},
+		{
+			name:  "combination of the wrong size",
+			race:  Race{Runners: runners(10), Placings: placed(1, 2, 3)},
+			pools: []Pool{{Type: Trifecta, Investments: []Investment{on(10, 1, 2)}}},
+			want: []errs.FieldViolation{
+				{Field: "pools[0].investments[0].runners", Description: "a trifecta combination must name 3 runners"},
+			},
+		},
+		{
+			name:  "combination of unknown and repeated runners",
+			race:  Race{Runners: runners(10), Placings: placed(1, 2)},
+			pools: []Pool{{Type: Exacta, Investments: []Investment{on(10, 11, 2), on(10, 3, 3)}}},
+			want: []errs.FieldViolation{
+				{Field: "pools[0].investments[0].runners", Description: "11 is not a runner in the race"},
+				{Field: "pools[0].investments[1].runners", Description: "3 is named more than once"},
+			},
+		},
+		{
+			name:  "quinella combination given twice",
+			race:  Race{Runners: runners(10), Placings: placed(1, 2)},
+			pools: []Pool{{Type: Quinella, Investments: []Investment{on(10, 1, 2), on(10, 2, 1)}}},
+			want: []errs.FieldViolation{
+				{Field: "pools[0].investments[1].runners", Description: "the combination is already invested in by investments[0]"},
+			},
+		},
+		{
+			name:  "amount and total",
+			race:  Race{Runners: runners(10), Placings: placed(1)},
+			pools: []Pool{{Type: Win, Total: 15, Investments: []Investment{on(10, 1), on(10, 2), on(0, 3)}}},
+			want: []errs.FieldViolation{
+				{Field: "pools[0].investments[2].amount", Description: "must be greater than 0"},
+				{Field: "pools[0].total", Description: "must be at least the 20.00 invested in its combinations"},
+			},
+		},
+		{
+			name:  "scratched runners",
+			race:  Race{Runners: runners(10), Scratched: []int64{11, 1, 5, 5}, Placings: placed(1)},
+			pools: []Pool{{Type: Win}},
+			want: []errs.FieldViolation{
+				{Field: "scratched[0]", Description: "11 is not a runner in the race"},
+				{Field: "scratched[1]", Description: "1 is placed in the result"},
+				{Field: "scratched[3]", Description: "5 is already scratched"},
+			},
+		},
+		{
+			name:  "too few placings",
+			race:  Race{Runners: runners(10), Placings: placed(1, 2, 3)},
+			pools: []Pool{{Type: FirstFour}},
+			want:  []errs.FieldViolation{{Field: "placings", Description: "must place at least 4 runners to decide a first four pool"}},
+		},
+		{
+			name:  "too few starters needs no placings",
+			race:  Race{Runners: runners(5), Scratched: []int64{4, 5}, Placings: placed(1, 2)},
+			pools: []Pool{{Type: FirstFour}, {Type: Place}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []errs.FieldViolation
+			if err := Check(tt.race, tt.pools); err != nil {
+				invalid, ok := err.(*errs.InvalidArgument)
+				if !ok {
+					t.Fatalf("Check() = %v, want an *errs.InvalidArgument", err)
+				}
+
+				got = invalid.Violations
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Check() violations = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBreakDown(t *testing.T) {
+	tests := []struct {
+		amount, breakage, want float64
+	}{
+		{4.275, 0.10, 4.20},
+		{4.275, 0.05, 4.25},
+		{4.30, 0.10, 4.30},
+		{1.999999, 0.10, 1.90},
+		{2.0000001, 0.10, 2.00},
+		{3.456, 0, 3.46},
+	}
+
+	for _, tt := range tests {
+		if got := breakDown(tt.amount, tt.breakage); got != tt.want {
+			t.Errorf("breakDown(%v, %v) = %v, want %v", tt.amount, tt.breakage, got, tt.want)
+		}
+	}
+}
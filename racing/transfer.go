@@ -0,0 +1,164 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+
+	"git.neds.sh/matty/entain/racing/db"
+	"git.neds.sh/matty/entain/racing/transfer"
+)
+
+// runImport implements the import command, loading races from a file into the database.
+func runImport(args []string) error {
+	fs := flag.NewFlagSet("import", flag.ExitOnError)
+	format := fs.String("format", "", "format of the file, csv or jsonl (default inferred from the file extension)")
+	dryRun := fs.Bool("dry-run", false, "validate and apply the import, then roll it back")
+
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: %s import [flags] FILE\n\nUse - as the FILE to read from stdin.\n\nFlags:\n", os.Args[0])
+		fs.PrintDefaults()
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return errors.New("expected exactly one file to import")
+	}
+
+	path := fs.Arg(0)
+
+	f, err := resolveFormat(*format, path)
+	if err != nil {
+		return err
+	}
+
+	in := os.Stdin
+	if path != "-" {
+		if in, err = os.Open(path); err != nil {
+			return err
+		}
+		defer in.Close()
+	}
+
+	var (
+		batch  transfer.Batch
+		reader = transfer.NewReader(in, f)
+	)
+
+	for {
+		race, line, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+
+		var lineErr *transfer.LineError
+		if errors.As(err, &lineErr) {
+			batch.Errors = append(batch.Errors, lineErr)
+			continue
+		}
+
+		if err != nil {
+			return err
+		}
+
+		batch.Add(line, race)
+	}
+
+	if len(batch.Errors) > 0 {
+		for _, lineErr := range batch.Errors {
+			fmt.Fprintln(os.Stderr, lineErr)
+		}
+
+		return fmt.Errorf("%d invalid records, nothing was imported", len(batch.Errors))
+	}
+
+	racesRepo, err := openRacesRepo()
+	if err != nil {
+		return err
+	}
+
+	result, err := racesRepo.Import(batch.Races, *dryRun)
+	if err != nil {
+		return err
+	}
+
+	if *dryRun {
+		log.Printf("dry run: would import %d races (%d created, %d updated)\n", len(batch.Races), result.Created, result.Updated)
+	} else {
+		log.Printf("imported %d races (%d created, %d updated)\n", len(batch.Races), result.Created, result.Updated)
+	}
+
+	return nil
+}
+
+// runExport implements the export command, writing every race in the database to a file.
+func runExport(args []string) error {
+	fs := flag.NewFlagSet("export", flag.ExitOnError)
+	format := fs.String("format", "", "format of the file, csv or jsonl (default inferred from the file extension, or csv for stdout)")
+
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: %s export [flags] [FILE]\n\nWithout a FILE, races are written to stdout.\n\nFlags:\n", os.Args[0])
+		fs.PrintDefaults()
+	}
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	path := fs.Arg(0)
+	if path == "" {
+		path = "-"
+	}
+
+	f, err := resolveFormat(*format, path)
+	if err != nil {
+		return err
+	}
+
+	racesRepo, err := openRacesRepo()
+	if err != nil {
+		return err
+	}
+
+	races, err := racesRepo.List(nil, nil, db.Page{})
+	if err != nil {
+		return err
+	}
+
+	out := os.Stdout
+	if path != "-" {
+		if out, err = os.Create(path); err != nil {
+			return err
+		}
+		defer out.Close()
+	}
+
+	writer := transfer.NewWriter(out, f)
+
+	for _, race := range races {
+		if err := writer.Write(race); err != nil {
+			return err
+		}
+	}
+
+	return writer.Flush()
+}
+
+// resolveFormat returns the explicitly requested format, otherwise the one implied by the path.
+func resolveFormat(format, path string) (transfer.Format, error) {
+	switch {
+	case format != "":
+		return transfer.ParseFormat(format)
+	case path == "-":
+		return transfer.CSV, nil
+	default:
+		return transfer.FormatFromPath(path)
+	}
+}
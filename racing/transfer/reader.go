@@ -0,0 +1,179 @@
+package transfer
+
+import (
+	"bufio"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/golang/protobuf/ptypes"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"git.neds.sh/matty/entain/racing/proto/racing"
+)
+
+// maxLineSize is the longest JSON Lines record we are willing to read.
+const maxLineSize = 1 << 20
+
+// Reader reads races from an import file.
+type Reader struct {
+	format  Format
+	csv     *csv.Reader
+	scanner *bufio.Scanner
+	header  map[string]int
+	line    int
+}
+
+// NewReader creates a Reader of races in the given format.
+func NewReader(r io.Reader, format Format) *Reader {
+	reader := &Reader{format: format}
+
+	switch format {
+	case CSV:
+		reader.csv = csv.NewReader(r)
+		reader.csv.FieldsPerRecord = -1
+		reader.csv.TrimLeadingSpace = true
+	default:
+		reader.scanner = bufio.NewScanner(r)
+		reader.scanner.Buffer(nil, maxLineSize)
+	}
+
+	return reader
+}
+
+// Read returns the next race along with the line it was read from, or io.EOF once there are no more.
+//
+// A record that cannot be decoded is reported as a *LineError, after which reading may continue.
+// Any other error, such as a CSV header without the required columns, means the rest of the input cannot be read.
+func (r *Reader) Read() (*racing.Race, int, error) {
+	if r.format == CSV {
+		return r.readCSV()
+	}
+
+	return r.readJSONL()
+}
+
+func (r *Reader) readCSV() (*racing.Race, int, error) {
+	if r.header == nil {
+		if err := r.readHeader(); err != nil {
+			return nil, r.line, err
+		}
+	}
+
+	record, err := r.csv.Read()
+	if err != nil {
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			r.line = parseErr.Line
+			return nil, r.line, &LineError{Line: r.line, Err: parseErr.Err}
+		}
+
+		return nil, r.line, err
+	}
+
+	r.line++
+
+	race, err := r.decodeRecord(record)
+	if err != nil {
+		return nil, r.line, &LineError{Line: r.line, Err: err}
+	}
+
+	return race, r.line, nil
+}
+
+func (r *Reader) readHeader() error {
+	record, err := r.csv.Read()
+	if err == io.EOF {
+		return err
+	}
+
+	if err != nil {
+		return fmt.Errorf("reading header: %w", err)
+	}
+
+	r.line++
+	r.header = make(map[string]int, len(record))
+
+	for i, name := range record {
+		r.header[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+
+	for _, name := range columns {
+		if _, ok := r.header[name]; !ok {
+			return fmt.Errorf("line %d: header is missing the %q column", r.line, name)
+		}
+	}
+
+	return nil
+}
+
+func (r *Reader) decodeRecord(record []string) (*racing.Race, error) {
+	field := func(name string) string {
+		if i := r.header[name]; i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+
+		return ""
+	}
+
+	var (
+		race racing.Race
+		err  error
+	)
+
+	if race.Id, err = strconv.ParseInt(field("id"), 10, 64); err != nil {
+		return nil, fmt.Errorf("id: %q is not a number", field("id"))
+	}
+
+	if race.MeetingId, err = strconv.ParseInt(field("meeting_id"), 10, 64); err != nil {
+		return nil, fmt.Errorf("meeting_id: %q is not a number", field("meeting_id"))
+	}
+
+	race.Name = field("name")
+
+	if race.Number, err = strconv.ParseInt(field("number"), 10, 64); err != nil {
+		return nil, fmt.Errorf("number: %q is not a number", field("number"))
+	}
+
+	if race.Visible, err = strconv.ParseBool(field("visible")); err != nil {
+		return nil, fmt.Errorf("visible: %q is not true or false", field("visible"))
+	}
+
+	advertisedStart, err := parseTime(field("advertised_start_time"))
+	if err != nil {
+		return nil, fmt.Errorf("advertised_start_time: %q is not an RFC 3339 timestamp", field("advertised_start_time"))
+	}
+
+	if race.AdvertisedStartTime, err = ptypes.TimestampProto(advertisedStart); err != nil {
+		return nil, fmt.Errorf("advertised_start_time: %w", err)
+	}
+
+	return &race, nil
+}
+
+func (r *Reader) readJSONL() (*racing.Race, int, error) {
+	for r.scanner.Scan() {
+		r.line++
+
+		line := strings.TrimSpace(r.scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		var race racing.Race
+		if err := protojson.Unmarshal([]byte(line), &race); err != nil {
+			return nil, r.line, &LineError{Line: r.line, Err: err}
+		}
+
+		return &race, r.line, nil
+	}
+
+	if err := r.scanner.Err(); err != nil {
+		return nil, r.line, err
+	}
+
+	return nil, r.line, io.EOF
+}
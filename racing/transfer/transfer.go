@@ -0,0 +1,174 @@
+package transfer
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"git.neds.sh/matty/entain/common/errs"
+	"git.neds.sh/matty/entain/racing/proto/racing"
+)
+
+// Format is a file format races can be imported from and exported to.
+type Format string
+
+const (
+	// CSV is comma separated values, with a header row naming the columns.
+	CSV Format = "csv"
+	// JSONL is JSON Lines, with one race object per line.
+	JSONL Format = "jsonl"
+)
+
+// columns are the CSV columns, in the order they are written.
+var columns = []string{"id", "meeting_id", "name", "number", "visible", "advertised_start_time"}
+
+// ParseFormat returns the Format with the given name.
+func ParseFormat(name string) (Format, error) {
+	switch Format(strings.ToLower(name)) {
+	case CSV:
+		return CSV, nil
+	case JSONL, "ndjson":
+		return JSONL, nil
+	}
+
+	return "", fmt.Errorf("unknown format %q, expected csv or jsonl", name)
+}
+
+// FormatFromPath infers the Format from the extension of the given file path.
+func FormatFromPath(path string) (Format, error) {
+	ext := strings.TrimPrefix(filepath.Ext(path), ".")
+	if ext == "" {
+		return "", fmt.Errorf("cannot infer format of %q, please specify one", path)
+	}
+
+	return ParseFormat(ext)
+}
+
+// LineError is an error found in a single record of an import.
+type LineError struct {
+	Line int
+	Err  error
+}
+
+func (e *LineError) Error() string {
+	return fmt.Sprintf("line %d: %s", e.Line, e.Err)
+}
+
+func (e *LineError) Unwrap() error {
+	return e.Err
+}
+
+// Batch collects the races of an import, along with every record that failed validation.
+type Batch struct {
+	Races  []*racing.Race
+	Errors []*LineError
+
+	seen map[int64]int
+}
+
+// Add validates the race read from the given line, adding it to the batch if it is valid.
+func (b *Batch) Add(line int, race *racing.Race) {
+	if err := ValidateRace(race); err != nil {
+		b.Fail(line, err)
+		return
+	}
+
+	if b.seen == nil {
+		b.seen = make(map[int64]int)
+	}
+
+	if first, ok := b.seen[race.Id]; ok {
+		b.Fail(line, fmt.Errorf("id: %d is duplicated, first seen on line %d", race.Id, first))
+		return
+	}
+
+	b.seen[race.Id] = line
+	b.Races = append(b.Races, race)
+}
+
+// Fail records that the record on the given line could not be imported.
+func (b *Batch) Fail(line int, err error) {
+	b.Errors = append(b.Errors, &LineError{Line: line, Err: err})
+}
+
+// ValidateRace checks that a race has everything it needs to be stored, returning an
+// *errs.InvalidArgument listing every problem with it.
+func ValidateRace(race *racing.Race) error {
+	return validateRace(race, true)
+}
+
+// ValidateNewRace is ValidateRace for a race yet to be created, which may leave its ID for the
+// service to assign.
+func ValidateNewRace(race *racing.Race) error {
+	return validateRace(race, false)
+}
+
+func validateRace(race *racing.Race, needID bool) error {
+	if race == nil {
+		return errs.Invalid("race", "is required")
+	}
+
+	var violations []errs.FieldViolation
+
+	violate := func(field, description string) {
+		violations = append(violations, errs.FieldViolation{Field: field, Description: description})
+	}
+
+	switch {
+	case race.Id < 0, race.Id == 0 && needID:
+		violate("id", "must be greater than 0")
+	}
+
+	if race.MeetingId <= 0 {
+		violate("meeting_id", "must be greater than 0")
+	}
+
+	if strings.TrimSpace(race.Name) == "" {
+		violate("name", "must not be empty")
+	}
+
+	if race.Number <= 0 {
+		violate("number", "must be greater than 0")
+	}
+
+	if race.AdvertisedStartTime == nil {
+		violate("advertised_start_time", "is required")
+	} else if err := race.AdvertisedStartTime.CheckValid(); err != nil {
+		violate("advertised_start_time", err.Error())
+	}
+
+	numbers := make(map[int64]bool, len(race.Runners))
+
+	for i, runner := range race.Runners {
+		field := fmt.Sprintf("runners[%d]", i)
+
+		switch {
+		case runner.Number <= 0:
+			violate(field+".number", "must be greater than 0")
+		case numbers[runner.Number]:
+			violate(field+".number", fmt.Sprintf("%d is taken by another runner", runner.Number))
+		}
+
+		numbers[runner.Number] = true
+
+		if strings.TrimSpace(runner.Name) == "" {
+			violate(field+".name", "must not be empty")
+		}
+
+		if runner.Barrier < 0 {
+			violate(field+".barrier", "must not be negative")
+		}
+	}
+
+	if len(violations) > 0 {
+		return &errs.InvalidArgument{Violations: violations}
+	}
+
+	return nil
+}
+
+// parseTime accepts RFC 3339 timestamps, as written by export.
+func parseTime(value string) (time.Time, error) {
+	return time.Parse(time.RFC3339, strings.TrimSpace(value))
+}
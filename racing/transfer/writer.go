@@ -0,0 +1,94 @@
+package transfer
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"git.neds.sh/matty/entain/racing/proto/racing"
+)
+
+// Writer writes races to an export file.
+type Writer struct {
+	format      Format
+	w           io.Writer
+	csv         *csv.Writer
+	wroteHeader bool
+}
+
+// NewWriter creates a Writer of races in the given format.
+func NewWriter(w io.Writer, format Format) *Writer {
+	writer := &Writer{format: format, w: w}
+
+	if format == CSV {
+		writer.csv = csv.NewWriter(w)
+	}
+
+	return writer
+}
+
+// Write writes a single race.
+func (w *Writer) Write(race *racing.Race) error {
+	if w.format == CSV {
+		return w.writeCSV(race)
+	}
+
+	line, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(race)
+	if err != nil {
+		return err
+	}
+
+	_, err = w.w.Write(append(line, '\n'))
+
+	return err
+}
+
+func (w *Writer) writeCSV(race *racing.Race) error {
+	if err := w.writeHeader(); err != nil {
+		return err
+	}
+
+	advertisedStart, err := ptypes.Timestamp(race.AdvertisedStartTime)
+	if err != nil {
+		return err
+	}
+
+	return w.csv.Write([]string{
+		strconv.FormatInt(race.Id, 10),
+		strconv.FormatInt(race.MeetingId, 10),
+		race.Name,
+		strconv.FormatInt(race.Number, 10),
+		strconv.FormatBool(race.Visible),
+		advertisedStart.UTC().Format(time.RFC3339),
+	})
+}
+
+func (w *Writer) writeHeader() error {
+	if w.wroteHeader {
+		return nil
+	}
+
+	w.wroteHeader = true
+
+	return w.csv.Write(columns)
+}
+
+// Flush writes any buffered data, and must be called once all races have been written.
+func (w *Writer) Flush() error {
+	if w.csv == nil {
+		return nil
+	}
+
+	// Even an empty export should describe its columns.
+	if err := w.writeHeader(); err != nil {
+		return err
+	}
+
+	w.csv.Flush()
+
+	return w.csv.Error()
+}
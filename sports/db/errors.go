@@ -0,0 +1,26 @@
+package db
+
+import (
+	"errors"
+	"time"
+
+	"github.com/mattn/go-sqlite3"
+
+	"git.neds.sh/matty/entain/common/errs"
+)
+
+// busyRetryAfter is how long clients are asked to wait when the database is busy.
+const busyRetryAfter = time.Second
+
+// wrapError translates database errors into the errors of the sports domain, where one applies.
+func wrapError(err error) error {
+	var sqliteErr sqlite3.Error
+	if errors.As(err, &sqliteErr) {
+		switch sqliteErr.Code {
+		case sqlite3.ErrBusy, sqlite3.ErrLocked:
+			return &errs.Unavailable{RetryAfter: busyRetryAfter, Err: err}
+		}
+	}
+
+	return err
+}
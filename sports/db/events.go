@@ -0,0 +1,289 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	_ "github.com/mattn/go-sqlite3"
+
+	"git.neds.sh/matty/entain/common/errs"
+	"git.neds.sh/matty/entain/sports/proto/sports"
+)
+
+// EventsRepo provides repository access to sports events.
+type EventsRepo interface {
+	// Init will initialise our events repository, creating its tables.
+	Init() error
+
+	// List will return every event with a status, or of every status if it is unspecified, soonest first.
+	List(status sports.Event_Status) ([]*sports.Event, error)
+
+	// Get will return the event with the given ID, or an *errs.NotFound error.
+	Get(id int64) (*sports.Event, error)
+
+	// Insert will store a new event with its outcomes, assigning it an ID and update time, and return it as
+	// it was stored.
+	Insert(event *sports.Event) (*sports.Event, error)
+
+	// Update will change the event with the given ID by applying a change to it as it is stored, and return
+	// it as changed. The event is read, changed and written in one transaction, so that changes made at the
+	// same time are applied one after the other. An error from the change is returned, changing nothing.
+	// Only the status, result and reason of an event, and the prices and results of its outcomes, change.
+	Update(id int64, change func(event *sports.Event) error) (*sports.Event, error)
+}
+
+// Open opens the sports database at the given path. Its transactions take the database's write lock as
+// they begin, so that an event read to be changed cannot be changed by another transaction before it is
+// written, and waiting for the lock is bounded by the busy timeout.
+func Open(path string) (*sql.DB, error) {
+	return sql.Open("sqlite3", fmt.Sprintf("file:%s?_txlock=immediate&_busy_timeout=5000", path))
+}
+
+type eventsRepo struct {
+	db   *sql.DB
+	init sync.Once
+}
+
+// NewEventsRepo creates a new events repository, on a database opened by Open.
+func NewEventsRepo(db *sql.DB) EventsRepo {
+	return &eventsRepo{db: db}
+}
+
+// Init prepares the events repository, migrating its database.
+func (r *eventsRepo) Init() error {
+	var err error
+
+	r.init.Do(func() {
+		err = migrate(r.db)
+	})
+
+	return err
+}
+
+// queryer is what the repository queries, the database or one of its transactions.
+type queryer interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+	Query(query string, args ...interface{}) (*sql.Rows, error)
+}
+
+func (r *eventsRepo) List(status sports.Event_Status) ([]*sports.Event, error) {
+	name := ""
+	if status != sports.Event_STATUS_UNSPECIFIED {
+		name = status.String()
+	}
+
+	events, err := r.query(r.db, eventsList, name, name)
+
+	return events, wrapError(err)
+}
+
+func (r *eventsRepo) Get(id int64) (*sports.Event, error) {
+	event, err := r.get(r.db, id)
+
+	return event, wrapError(err)
+}
+
+func (r *eventsRepo) get(q queryer, id int64) (*sports.Event, error) {
+	events, err := r.query(q, eventsGet, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(events) == 0 {
+		return nil, &errs.NotFound{Resource: "event", ID: strconv.FormatInt(id, 10)}
+	}
+
+	return events[0], nil
+}
+
+func (r *eventsRepo) Insert(event *sports.Event) (*sports.Event, error) {
+	inserted, err := r.insert(event)
+
+	return inserted, wrapError(err)
+}
+
+func (r *eventsRepo) insert(event *sports.Event) (*sports.Event, error) {
+	start, err := ptypes.Timestamp(event.AdvertisedStartTime)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	queries := getEventQueries()
+
+	result, err := tx.Exec(queries[eventsInsert], event.Name, event.Competition, start.UTC().Format(time.RFC3339Nano),
+		event.Status.String(), event.ResultVersion, event.Reason, now())
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, outcome := range event.Outcomes {
+		if _, err := tx.Exec(queries[outcomesInsert], id, outcome.Id, outcome.Name, outcome.Price, outcome.Result.String()); err != nil {
+			return nil, err
+		}
+	}
+
+	inserted, err := r.get(tx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return inserted, tx.Commit()
+}
+
+func (r *eventsRepo) Update(id int64, change func(event *sports.Event) error) (*sports.Event, error) {
+	updated, err := r.update(id, change)
+
+	return updated, wrapError(err)
+}
+
+func (r *eventsRepo) update(id int64, change func(event *sports.Event) error) (*sports.Event, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	event, err := r.get(tx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := change(event); err != nil {
+		return nil, err
+	}
+
+	queries := getEventQueries()
+
+	if _, err := tx.Exec(queries[eventsUpdate], event.Status.String(), event.ResultVersion, event.Reason, now(), id); err != nil {
+		return nil, err
+	}
+
+	for _, outcome := range event.Outcomes {
+		if _, err := tx.Exec(queries[outcomesUpdate], outcome.Price, outcome.Result.String(), id, outcome.Id); err != nil {
+			return nil, err
+		}
+	}
+
+	updated, err := r.get(tx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return updated, tx.Commit()
+}
+
+// query runs one of the event queries, returning the events it selects with their outcomes.
+func (r *eventsRepo) query(q queryer, name string, args ...interface{}) ([]*sports.Event, error) {
+	rows, err := q.Query(getEventQueries()[name], args...)
+	if err != nil {
+		return nil, err
+	}
+
+	events, err := scanEvents(rows)
+	if err != nil || len(events) == 0 {
+		return events, err
+	}
+
+	return events, withOutcomes(q, events)
+}
+
+// withOutcomes reads the outcomes of events into them.
+func withOutcomes(q queryer, events []*sports.Event) error {
+	byID := make(map[int64]*sports.Event, len(events))
+	minID, maxID := events[0].Id, events[0].Id
+
+	for _, event := range events {
+		byID[event.Id] = event
+
+		if event.Id < minID {
+			minID = event.Id
+		}
+
+		if event.Id > maxID {
+			maxID = event.Id
+		}
+	}
+
+	rows, err := q.Query(getEventQueries()[outcomesList], minID, maxID)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			eventID int64
+			outcome sports.Outcome
+			result  string
+		)
+
+		if err := rows.Scan(&eventID, &outcome.Id, &outcome.Name, &outcome.Price, &result); err != nil {
+			return err
+		}
+
+		outcome.Result = sports.Outcome_Result(sports.Outcome_Result_value[result])
+
+		// Events between those listed that were not themselves listed are skipped.
+		if event, ok := byID[eventID]; ok {
+			event.Outcomes = append(event.Outcomes, &outcome)
+		}
+	}
+
+	return rows.Err()
+}
+
+// scanEvents reads events of eventColumns from rows, closing them.
+func scanEvents(rows *sql.Rows) ([]*sports.Event, error) {
+	defer rows.Close()
+
+	var events []*sports.Event
+
+	for rows.Next() {
+		var (
+			event      sports.Event
+			start      time.Time
+			status     string
+			updateTime time.Time
+		)
+
+		if err := rows.Scan(&event.Id, &event.Name, &event.Competition, &start, &status, &event.ResultVersion,
+			&event.Reason, &updateTime); err != nil {
+			return nil, err
+		}
+
+		event.Status = sports.Event_Status(sports.Event_Status_value[status])
+
+		var err error
+		if event.AdvertisedStartTime, err = ptypes.TimestampProto(start); err != nil {
+			return nil, err
+		}
+
+		if event.UpdateTime, err = ptypes.TimestampProto(updateTime); err != nil {
+			return nil, err
+		}
+
+		events = append(events, &event)
+	}
+
+	return events, rows.Err()
+}
+
+// now returns the current time as stored.
+func now() string {
+	return time.Now().UTC().Truncate(time.Millisecond).Format(time.RFC3339Nano)
+}
@@ -0,0 +1,56 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// migrations create the tables of the sports database, and bring databases created by earlier versions of
+// the sports service up to date. Each must be safe to run against a database it has already been applied
+// to.
+var migrations = []func(db *sql.DB) error{
+	func(db *sql.DB) error {
+		return execAll(db, []string{
+			`CREATE TABLE IF NOT EXISTS events (
+				id INTEGER PRIMARY KEY,
+				name TEXT NOT NULL,
+				competition TEXT NOT NULL,
+				advertised_start_time DATETIME NOT NULL,
+				status TEXT NOT NULL,
+				result_version INTEGER NOT NULL,
+				reason TEXT NOT NULL,
+				update_time DATETIME NOT NULL
+			)`,
+			`CREATE TABLE IF NOT EXISTS outcomes (
+				event_id INTEGER NOT NULL,
+				id INTEGER NOT NULL,
+				name TEXT NOT NULL,
+				price REAL NOT NULL,
+				result TEXT NOT NULL,
+				PRIMARY KEY (event_id, id)
+			)`,
+		})
+	},
+}
+
+// migrate applies every migration in order.
+func migrate(db *sql.DB) error {
+	for i, migration := range migrations {
+		if err := migration(db); err != nil {
+			return fmt.Errorf("applying migration %d: %w", i+1, err)
+		}
+	}
+
+	return nil
+}
+
+// execAll runs each statement in turn.
+func execAll(db *sql.DB, statements []string) error {
+	for _, statement := range statements {
+		if _, err := db.Exec(statement); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
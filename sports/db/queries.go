@@ -0,0 +1,55 @@
+package db
+
+const (
+	eventsList   = "list"
+	eventsGet    = "get"
+	eventsInsert = "insert"
+	eventsUpdate = "update"
+
+	outcomesList   = "outcomes_list"
+	outcomesInsert = "outcomes_insert"
+	outcomesUpdate = "outcomes_update"
+)
+
+func getEventQueries() map[string]string {
+	return map[string]string{
+		// An unspecified status lists events of every status.
+		eventsList: `
+			SELECT ` + eventColumns + `
+			FROM events
+			WHERE ? = '' OR status = ?
+			ORDER BY advertised_start_time, id
+		`,
+		eventsGet: `
+			SELECT ` + eventColumns + `
+			FROM events
+			WHERE id = ?
+		`,
+		eventsInsert: `
+			INSERT INTO events(name, competition, advertised_start_time, status, result_version, reason, update_time)
+			VALUES (?,?,?,?,?,?,?)
+		`,
+		eventsUpdate: `
+			UPDATE events SET status = ?, result_version = ?, reason = ?, update_time = ?
+			WHERE id = ?
+		`,
+		// The outcomes of several events are read at once, by the range of their IDs.
+		outcomesList: `
+			SELECT event_id, id, name, price, result
+			FROM outcomes
+			WHERE event_id BETWEEN ? AND ?
+			ORDER BY event_id, id
+		`,
+		outcomesInsert: `
+			INSERT INTO outcomes(event_id, id, name, price, result)
+			VALUES (?,?,?,?,?)
+		`,
+		outcomesUpdate: `
+			UPDATE outcomes SET price = ?, result = ?
+			WHERE event_id = ? AND id = ?
+		`,
+	}
+}
+
+// eventColumns are the columns of an event, in the order scanEvents reads them.
+const eventColumns = `id, name, competition, advertised_start_time, status, result_version, reason, update_time`
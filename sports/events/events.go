@@ -0,0 +1,158 @@
+// Package events works out the changes made to sports events over their life: pricing their outcomes
+// while they are open, and resulting or abandoning them.
+package events
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+
+	"git.neds.sh/matty/entain/common/errs"
+	"git.neds.sh/matty/entain/sports/proto/sports"
+)
+
+// New returns an event to create as asked for: open, with its outcomes numbered from 1 in the order given
+// and without results. It returns an *errs.InvalidArgument error if an outcome is not priced.
+func New(in *sports.Event) (*sports.Event, error) {
+	var violations []errs.FieldViolation
+
+	event := &sports.Event{
+		Name:                in.Name,
+		Competition:         in.Competition,
+		AdvertisedStartTime: in.AdvertisedStartTime,
+		Status:              sports.Event_OPEN,
+	}
+
+	for i, outcome := range in.Outcomes {
+		if !valid(outcome.Price) {
+			violations = append(violations, errs.FieldViolation{
+				Field:       fmt.Sprintf("event.outcomes[%d].price", i),
+				Description: "must be greater than 1",
+			})
+		}
+
+		event.Outcomes = append(event.Outcomes, &sports.Outcome{Id: int64(i + 1), Name: outcome.Name, Price: outcome.Price})
+	}
+
+	if len(violations) > 0 {
+		return nil, &errs.InvalidArgument{Violations: violations}
+	}
+
+	return event, nil
+}
+
+// Reprice sets the prices of outcomes of an event. It returns an *errs.FailedPrecondition error if the
+// event is not open, or an *errs.InvalidArgument error if a price is not valid or is for an outcome the
+// event does not have.
+func Reprice(event *sports.Event, prices []*sports.OutcomePrice) error {
+	if event.Status != sports.Event_OPEN {
+		return notOpen(event, "priced")
+	}
+
+	for i, price := range prices {
+		outcome := Outcome(event, price.OutcomeId)
+		if outcome == nil {
+			return errs.Invalid(fmt.Sprintf("prices[%d].outcome_id", i), "%d is not an outcome of the event", price.OutcomeId)
+		}
+
+		if !valid(price.Price) {
+			return errs.Invalid(fmt.Sprintf("prices[%d].price", i), "must be greater than 1")
+		}
+
+		outcome.Price = price.Price
+	}
+
+	return nil
+}
+
+// Result settles which outcomes of an event won and which are void, the rest having lost, and makes the
+// event final with a new version of its result. It returns an *errs.FailedPrecondition error if the event
+// was abandoned, or an *errs.InvalidArgument error if an outcome named is not one of the event's or is
+// named both a winner and void.
+func Result(event *sports.Event, winners, void []int64) error {
+	if event.Status == sports.Event_ABANDONED {
+		return notOpen(event, "resulted")
+	}
+
+	results := make(map[int64]sports.Outcome_Result)
+
+	named := []struct {
+		field  string
+		ids    []int64
+		result sports.Outcome_Result
+	}{
+		{"winners", winners, sports.Outcome_WON},
+		{"void", void, sports.Outcome_VOID},
+	}
+
+	for _, outcomes := range named {
+		for _, id := range outcomes.ids {
+			if Outcome(event, id) == nil {
+				return errs.Invalid(outcomes.field, "%d is not an outcome of the event", id)
+			}
+
+			if _, ok := results[id]; ok {
+				return errs.Invalid("void", "%d cannot be both a winner and void", id)
+			}
+
+			results[id] = outcomes.result
+		}
+	}
+
+	for _, outcome := range event.Outcomes {
+		outcome.Result = sports.Outcome_LOST
+		if result, ok := results[outcome.Id]; ok {
+			outcome.Result = result
+		}
+	}
+
+	event.Status = sports.Event_FINAL
+	event.ResultVersion++
+
+	return nil
+}
+
+// Abandon abandons an event, voiding every outcome. It returns an *errs.FailedPrecondition error if the
+// event is final. Abandoning an abandoned event does nothing.
+func Abandon(event *sports.Event, reason string) error {
+	switch event.Status {
+	case sports.Event_ABANDONED:
+		return nil
+	case sports.Event_FINAL:
+		return notOpen(event, "abandoned")
+	}
+
+	for _, outcome := range event.Outcomes {
+		outcome.Result = sports.Outcome_VOID
+	}
+
+	event.Status = sports.Event_ABANDONED
+	event.Reason = reason
+
+	return nil
+}
+
+// Outcome returns an outcome of an event, or nil if the event does not have it.
+func Outcome(event *sports.Event, id int64) *sports.Outcome {
+	for _, outcome := range event.Outcomes {
+		if outcome.Id == id {
+			return outcome
+		}
+	}
+
+	return nil
+}
+
+// valid reports whether a price is one an outcome may have.
+func valid(price float64) bool {
+	return price > 1 && !math.IsInf(price, 0)
+}
+
+// notOpen returns the error for an event that cannot be changed in its status.
+func notOpen(event *sports.Event, change string) error {
+	return &errs.FailedPrecondition{
+		Resource: "event",
+		ID:       strconv.FormatInt(event.Id, 10),
+		Reason:   fmt.Sprintf("%s events cannot be %s", event.Status, change),
+	}
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"flag"
+	"log"
+	"net"
+
+	"git.neds.sh/matty/entain/common/errs"
+	"git.neds.sh/matty/entain/common/validate"
+	"git.neds.sh/matty/entain/sports/db"
+	"git.neds.sh/matty/entain/sports/proto/sports"
+	"git.neds.sh/matty/entain/sports/service"
+	"google.golang.org/grpc"
+)
+
+// domain identifies the sports service in the ErrorInfo of its errors.
+const domain = "sports.entain.com"
+
+var (
+	grpcEndpoint = flag.String("grpc-endpoint", "localhost:9300", "gRPC server endpoint")
+	dbPath       = flag.String("db-path", "./db/sports.db", "path to the sports SQLite database")
+)
+
+func main() {
+	flag.Parse()
+
+	if err := run(); err != nil {
+		log.Fatalf("failed running grpc server: %s\n", err)
+	}
+}
+
+func run() error {
+	conn, err := net.Listen("tcp", *grpcEndpoint)
+	if err != nil {
+		return err
+	}
+
+	sportsDB, err := db.Open(*dbPath)
+	if err != nil {
+		return err
+	}
+
+	eventsRepo := db.NewEventsRepo(sportsDB)
+	if err := eventsRepo.Init(); err != nil {
+		return err
+	}
+
+	// Errors are converted to statuses outermost, so that they cover validation failures too.
+	grpcServer := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(errs.UnaryServerInterceptor(domain), validate.UnaryServerInterceptor()),
+		grpc.ChainStreamInterceptor(errs.StreamServerInterceptor(domain), validate.StreamServerInterceptor()),
+	)
+
+	sports.RegisterSportsServer(
+		grpcServer,
+		service.NewSportsService(eventsRepo),
+	)
+
+	log.Printf("gRPC server listening on: %s\n", *grpcEndpoint)
+
+	return grpcServer.Serve(conn)
+}
@@ -0,0 +1,78 @@
+package service
+
+import (
+	"git.neds.sh/matty/entain/sports/db"
+	"git.neds.sh/matty/entain/sports/events"
+	"git.neds.sh/matty/entain/sports/proto/sports"
+	"golang.org/x/net/context"
+)
+
+type Sports interface {
+	// ListEvents will return events, soonest first.
+	ListEvents(ctx context.Context, in *sports.ListEventsRequest) (*sports.ListEventsResponse, error)
+
+	// GetEvent will return a single event by ID.
+	GetEvent(ctx context.Context, in *sports.GetEventRequest) (*sports.Event, error)
+
+	// CreateEvent will create an open event with its outcomes.
+	CreateEvent(ctx context.Context, in *sports.CreateEventRequest) (*sports.Event, error)
+
+	// UpdatePrices will set the prices of outcomes of an open event.
+	UpdatePrices(ctx context.Context, in *sports.UpdatePricesRequest) (*sports.Event, error)
+
+	// ResultEvent will settle which outcomes of an event won.
+	ResultEvent(ctx context.Context, in *sports.ResultEventRequest) (*sports.Event, error)
+
+	// AbandonEvent will abandon an event, voiding its outcomes.
+	AbandonEvent(ctx context.Context, in *sports.AbandonEventRequest) (*sports.Event, error)
+}
+
+// sportsService implements the Sports interface.
+type sportsService struct {
+	eventsRepo db.EventsRepo
+}
+
+// NewSportsService instantiates and returns a new sportsService.
+func NewSportsService(eventsRepo db.EventsRepo) Sports {
+	return &sportsService{eventsRepo: eventsRepo}
+}
+
+func (s *sportsService) ListEvents(ctx context.Context, in *sports.ListEventsRequest) (*sports.ListEventsResponse, error) {
+	list, err := s.eventsRepo.List(in.Status)
+	if err != nil {
+		return nil, err
+	}
+
+	return &sports.ListEventsResponse{Events: list}, nil
+}
+
+func (s *sportsService) GetEvent(ctx context.Context, in *sports.GetEventRequest) (*sports.Event, error) {
+	return s.eventsRepo.Get(in.Id)
+}
+
+func (s *sportsService) CreateEvent(ctx context.Context, in *sports.CreateEventRequest) (*sports.Event, error) {
+	event, err := events.New(in.Event)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.eventsRepo.Insert(event)
+}
+
+func (s *sportsService) UpdatePrices(ctx context.Context, in *sports.UpdatePricesRequest) (*sports.Event, error) {
+	return s.eventsRepo.Update(in.EventId, func(event *sports.Event) error {
+		return events.Reprice(event, in.Prices)
+	})
+}
+
+func (s *sportsService) ResultEvent(ctx context.Context, in *sports.ResultEventRequest) (*sports.Event, error) {
+	return s.eventsRepo.Update(in.EventId, func(event *sports.Event) error {
+		return events.Result(event, in.Winners, in.Void)
+	})
+}
+
+func (s *sportsService) AbandonEvent(ctx context.Context, in *sports.AbandonEventRequest) (*sports.Event, error) {
+	return s.eventsRepo.Update(in.EventId, func(event *sports.Event) error {
+		return events.Abandon(event, in.Reason)
+	})
+}